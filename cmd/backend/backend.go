@@ -6,7 +6,11 @@ import (
 	"os"
 
 	"tracker/server"
-	"tracker/trackable/show"
+	"tracker/trackable"
+	_ "tracker/trackable/all"
+	"tracker/trackable/calendar"
+
+	"go.uber.org/zap"
 )
 
 func main() {
@@ -17,15 +21,21 @@ func main() {
 }
 
 func run() error {
-	apis := map[string]server.API{
-		"api/show": &show.API{},
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return fmt.Errorf("unable to create logger: %w", err)
 	}
+	defer logger.Sync()
+	defer zap.ReplaceGlobals(logger)()
 
 	settings, err := server.NewSettings()
 	if err != nil {
 		return fmt.Errorf("unable to parse settings: %w", err)
 	}
 
+	apis := trackable.APIs()
+	apis["api/calendar"] = &calendar.API{}
+
 	backend, err := server.NewBackend(settings, apis)
 	if err != nil {
 		return fmt.Errorf("unable to initialize backend server: %w", err)
@@ -6,8 +6,17 @@ import (
 	"net/http"
 	"os"
 
+	"tracker/database"
+	"tracker/internal/account"
+	sqldb "tracker/internal/database/sql"
 	"tracker/internal/frontend"
 	"tracker/internal/httpserver"
+	"tracker/internal/importer"
+	"tracker/internal/profile"
+	"tracker/internal/social"
+	"tracker/internal/watch"
+	"tracker/server/auth"
+	_ "tracker/trackable/all"
 	"tracker/web"
 
 	oldserver "tracker/server"
@@ -56,19 +65,95 @@ func run() error {
 		cfg.BackendAddr = fmt.Sprintf("%s:%d", settings.APIHostname, settings.APIPort)
 	}
 
-	// Initialize the show frontend
-	showFrontend, err := frontend.NewShow(cfg.BackendAddr)
+	// Initialize the frontend of every kind of trackable
+	components, err := frontend.Trackables(cfg.BackendAddr)
 	if err != nil {
-		return fmt.Errorf("unable to init show frontend: %w", err)
+		return err
 	}
 
-	s := httpserver.NewServer(map[string]httpserver.Component{
-		"/show":   showFrontend,
-		"/public": frontend.NewStatic(web.Static),
-		"/":       frontend.NewRedirect(http.StatusTemporaryRedirect, "/show/"),
-	},
-		httpserver.Logger(log),
-	)
+	// Initialize the calendar of all kinds of trackables
+	calendarFrontend, err := frontend.NewCalendar(cfg.BackendAddr)
+	if err != nil {
+		return fmt.Errorf("unable to init calendar frontend: %w", err)
+	}
+	components["/schedule"] = calendarFrontend
+
+	// Initialize the social frontend
+	accountsDB, err := database.Open("accounts")
+	if err != nil {
+		return fmt.Errorf("unable to open accounts database: %w", err)
+	}
+	accounts := sqldb.NewDatabase(accountsDB)
+	socialService := social.NewService(accounts.Users(), accounts.Follows(),
+		accounts.Activity())
+	socialFrontend, err := frontend.NewSocial(socialService)
+	if err != nil {
+		return fmt.Errorf("unable to init social frontend: %w", err)
+	}
+
+	// Initialize the profiles and the watch state of each profile
+	profileService := profile.NewService(accounts.Profiles())
+	auth.UseProfiles(profileService)
+	profilesFrontend, err := frontend.NewProfiles(profileService)
+	if err != nil {
+		return fmt.Errorf("unable to init profiles frontend: %w", err)
+	}
+	watchFrontend, err := frontend.NewWatch(watch.NewService(accounts.Watch(), socialService))
+	if err != nil {
+		return fmt.Errorf("unable to init watch frontend: %w", err)
+	}
+
+	// Initialize the state of every kind of trackable kept for each profile
+	states, err := frontend.UserStates(accounts)
+	if err != nil {
+		return err
+	}
+	for path, c := range states {
+		components[path] = c
+	}
+
+	// Initialize importing watch history from other trackers
+	trackerDB, err := database.Open("tracker")
+	if err != nil {
+		return fmt.Errorf("unable to open tracker database: %w", err)
+	}
+	importFrontend, err := frontend.NewImport(importer.New(
+		importer.NewSQLCatalog(trackerDB), accounts.Watch(), accounts.ImportQueue()))
+	if err != nil {
+		return fmt.Errorf("unable to init import frontend: %w", err)
+	}
+
+	// Initialize exporting and deleting accounts
+	auth.UseSessions(accounts.Sessions())
+	accountFrontend, err := frontend.NewAccount(account.NewService(account.Stores{
+		Users:       accounts.Users(),
+		Profiles:    accounts.Profiles(),
+		Follows:     accounts.Follows(),
+		Activity:    accounts.Activity(),
+		Watch:       accounts.Watch(),
+		Reading:     accounts.Reading(),
+		Backlog:     accounts.Backlog(),
+		Listening:   accounts.Listening(),
+		ImportQueue: accounts.ImportQueue(),
+		Sessions:    accounts.Sessions(),
+	}), log)
+	if err != nil {
+		return fmt.Errorf("unable to init account frontend: %w", err)
+	}
+
+	for path, c := range map[string]httpserver.Component{
+		"/social":  socialFrontend,
+		"/profile": profilesFrontend,
+		"/watch":   watchFrontend,
+		"/import":  importFrontend,
+		"/account": accountFrontend,
+		"/public":  frontend.NewStatic(web.Static),
+		"/":        frontend.NewRedirect(http.StatusTemporaryRedirect, "/show/"),
+	} {
+		components[path] = c
+	}
+
+	s := httpserver.NewServer(components, httpserver.Logger(log))
 
 	return s.Run(cfg.Port)
 }
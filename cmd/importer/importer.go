@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"tracker/database"
+	sqldb "tracker/internal/database/sql"
+	"tracker/internal/importer"
+	"tracker/internal/profile"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Printf("%+v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var (
+		file      = flag.String("file", "", "exported watch history to import")
+		format    = flag.String("format", "", "format of the export, detected if empty (trakt, tvtime, imdb)")
+		email     = flag.String("user", "", "email of the account to import into")
+		profileID = flag.Int64("profile", 0, "profile to import into, defaults to the first profile")
+	)
+	flag.Parse()
+
+	if *file == "" || *email == "" {
+		flag.Usage()
+		return fmt.Errorf("-file and -user are required")
+	}
+
+	data, err := ioutil.ReadFile(*file)
+	if err != nil {
+		return fmt.Errorf("unable to read export: %w", err)
+	}
+
+	f := importer.Format(*format)
+	if f == "" {
+		if f, err = importer.Detect(*file, data); err != nil {
+			return err
+		}
+	}
+
+	items, err := importer.Parse(f, data)
+	if err != nil {
+		return err
+	}
+
+	trackerDB, err := database.Open("tracker")
+	if err != nil {
+		return fmt.Errorf("unable to open tracker database: %w", err)
+	}
+	accountsDB, err := database.Open("accounts")
+	if err != nil {
+		return fmt.Errorf("unable to open accounts database: %w", err)
+	}
+	accounts := sqldb.NewDatabase(accountsDB)
+
+	ctx := context.Background()
+	p, _, err := profile.NewService(accounts.Profiles()).Active(ctx, *email, *profileID)
+	if err != nil {
+		return fmt.Errorf("unable to find profile: %w", err)
+	}
+
+	log.Printf("importing %d items from %s into profile %q of %s", len(items), f, p.Name, *email)
+	i := importer.New(importer.NewSQLCatalog(trackerDB), accounts.Watch(), accounts.ImportQueue())
+	res, err := i.Import(ctx, p.ID, items)
+	if err != nil {
+		return fmt.Errorf("import error: %w", err)
+	}
+
+	log.Printf("watched: %d, rated: %d, queued for review: %d", res.Watched, res.Rated, res.Queued)
+	return nil
+}
@@ -0,0 +1,340 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"tracker/database"
+	"tracker/internal/fetch"
+	"tracker/internal/scheduler"
+	"tracker/trackable"
+	"tracker/trackable/show"
+)
+
+// commands of the scraper, run with the arguments following their name.
+var commands = map[string]func(ctx context.Context, args []string) error{
+	"show":  runShow,
+	"list":  runList,
+	"retry": runRetry,
+}
+
+// usage documents the commands, after the flags of the scraper.
+const usage = `
+Commands:
+  show [-dry-run] [-format text|json] <id|article>
+        scrape a single show by its ID or Wikipedia article; -dry-run prints
+        what would change without writing it
+  list [-format text|json]
+        list the shows with the status of their last scrape
+  retry [-format text|json]
+        scrape again the shows whose last scrape failed
+
+Without a command, every trackable is scraped once, or continuously with
+-daemon.
+`
+
+// commandFlags returns the flags of the command, along with its -format
+// flag.
+func commandFlags(name string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	format := fs.String("format", "text", "output format, text or json")
+	return fs, format
+}
+
+// parseFlags parses the arguments of the command, checking the format before
+// anything is scraped.
+func parseFlags(fs *flag.FlagSet, format *string, args []string) error {
+	fs.Parse(args)
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	return nil
+}
+
+// output writes v as JSON, or as text using the function.
+func output(format string, v interface{}, text func(w io.Writer)) error {
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	text(w)
+	return w.Flush()
+}
+
+// openStore opens the store of the state and reports of the scheduler, and
+// returns how to close it.
+func openStore() (*scheduler.SQLStore, func(), error) {
+	db, err := database.Open("tracker")
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to open tracker database: %w", err)
+	}
+	return scheduler.NewSQLStore(db), func() { db.Close() }, nil
+}
+
+// scrapeResult is a show as scraped by the show and retry commands.
+type scrapeResult struct {
+	Show     *show.Show        `json:"show"`
+	Episodes []*show.Episode   `json:"episodes"`
+	Report   *trackable.Report `json:"report"`
+	// Diff is what was written, or would be when dry running.
+	Diff   *trackable.Diff `json:"diff"`
+	DryRun bool            `json:"dry_run"`
+}
+
+func runShow(ctx context.Context, args []string) error {
+	fs, format := commandFlags("show")
+	dryRun := fs.Bool("dry-run", false, "print what would change without writing it")
+	if err := parseFlags(fs, format, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("show takes a single show ID or article")
+	}
+
+	s, err := show.Find(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	res := &scrapeResult{Show: s, DryRun: *dryRun}
+	if *dryRun {
+		// Pages fetched by a dry run aren't cached, as they would be
+		// unchanged when the show is scraped for real.
+		fetch.SetDefault(fetch.New(uncachedOptions...))
+		res.Report, res.Diff, err = preview(ctx, s)
+	} else {
+		var reports []*trackable.Report
+		if reports, err = scrapeShows(ctx, s); len(reports) == 0 {
+			return err
+		}
+		res.Report, res.Diff = reports[0], reports[0].Diff
+	}
+	res.Episodes = s.Episodes
+
+	if outErr := output(*format, res, func(w io.Writer) { printResult(w, res, true) }); outErr != nil {
+		return outErr
+	}
+	return err
+}
+
+// preview scrapes the show and compares it with what is stored, without
+// writing anything.
+func preview(ctx context.Context, s *show.Show) (*trackable.Report, *trackable.Diff, error) {
+	report := &trackable.Report{Ref: s.Ref()}
+	err := s.Scrape(trackable.WithReport(ctx, report))
+	if errors.Is(err, trackable.ErrUnchanged) {
+		report.Unchanged, err = true, nil
+	}
+	if err != nil {
+		report.Error = err.Error()
+		return report, nil, err
+	}
+
+	diff, err := s.Preview(ctx)
+	return report, diff, err
+}
+
+// scrapeShows scrapes and writes the shows one after the other, updating
+// their state as the daemon would. The report of every show is returned,
+// with the error of the first one which failed.
+func scrapeShows(ctx context.Context, shows ...*show.Show) ([]*trackable.Report, error) {
+	store, closeStore, err := openStore()
+	if err != nil {
+		return nil, err
+	}
+	defer closeStore()
+
+	s := scheduler.New(store)
+	var (
+		reports = make([]*trackable.Report, 0, len(shows))
+		failed  error
+	)
+	for _, sh := range shows {
+		r, err := s.ScrapeNow(ctx, sh)
+		if r == nil {
+			return reports, err
+		}
+		reports = append(reports, r)
+		if err != nil && failed == nil {
+			failed = fmt.Errorf("%s: %w", sh.Ref(), err)
+		}
+	}
+	return reports, failed
+}
+
+// printResult prints the show and what changed. The episodes of the show are
+// only listed if requested.
+func printResult(w io.Writer, res *scrapeResult, episodes bool) {
+	r := res.Report
+	fmt.Fprintf(w, "%s\t%s\t%s\n", res.Show.Ref(), res.Show.Name, res.Show.EpisodeURL)
+	if episodes {
+		for _, e := range res.Episodes {
+			fmt.Fprintf(w, "  S%02dE%02d\t%s\t%s\n", e.Season, e.Episode, e.ReleaseDate.Text(), e.Title)
+		}
+	}
+
+	fmt.Fprintf(w, "  fetched %d page(s), parsed %d episode(s) in %d table(s)\n", len(r.Pages), r.Parsed, r.Tables)
+	for _, skip := range r.Skipped {
+		fmt.Fprintf(w, "  skipped\t%s\t%s\n", skip.Where, skip.Reason)
+	}
+	for _, warning := range r.Warnings {
+		fmt.Fprintf(w, "  warning\t%s\n", warning)
+	}
+
+	verb := "changed"
+	if res.DryRun {
+		verb = "would change"
+	}
+	switch {
+	case r.Error != "":
+		fmt.Fprintf(w, "  failed\t%s\n", r.Error)
+	case r.Unchanged:
+		fmt.Fprintln(w, "  unchanged since the last scrape")
+	case res.Diff.Empty():
+		fmt.Fprintln(w, "  nothing "+verb)
+	default:
+		fmt.Fprintf(w, "  %s: %d added, %d changed, %d removed\n", verb,
+			len(res.Diff.Added), len(res.Diff.Changed), len(res.Diff.Removed))
+		for _, a := range res.Diff.Added {
+			fmt.Fprintf(w, "  +\t%s\n", release(a))
+		}
+		for _, c := range res.Diff.Changed {
+			fmt.Fprintf(w, "  ~\t%s\t→ %s\n", release(c.Old), release(c.New))
+		}
+		for _, d := range res.Diff.Removed {
+			fmt.Fprintf(w, "  -\t%s\n", release(d))
+		}
+	}
+}
+
+// release formats an episode for the text output.
+func release(r *trackable.Release) string {
+	return fmt.Sprintf("S%02dE%02d %s %q", r.Season, r.Number, r.PartialDate().Text(), r.Title)
+}
+
+// showState is a show with the state of its scrapes, as listed by the list
+// command.
+type showState struct {
+	*scheduler.State
+	Name      string `json:"name"`
+	Wikipedia string `json:"wikipedia"`
+
+	show *show.Show
+}
+
+// showStates returns every show with the state of its scrapes, ordered by
+// ID. Shows which were never scraped have an empty state.
+func showStates(ctx context.Context) ([]*showState, error) {
+	store, closeStore, err := openStore()
+	if err != nil {
+		return nil, err
+	}
+	defer closeStore()
+
+	m, ok := trackable.Lookup(show.Kind)
+	if !ok {
+		return nil, fmt.Errorf("shows aren't registered")
+	}
+	items, err := m.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load shows: %w", err)
+	}
+	states, err := store.States(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byRef := map[trackable.Ref]*scheduler.State{}
+	for _, st := range states {
+		byRef[st.Ref] = st
+	}
+
+	list := make([]*showState, 0, len(items))
+	for _, item := range items {
+		s := item.(*show.Show)
+		st, ok := byRef[s.Ref()]
+		if !ok {
+			st = &scheduler.State{Ref: s.Ref()}
+		}
+		list = append(list, &showState{State: st, Name: s.Name, Wikipedia: s.WikipediaURL, show: s})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func runList(ctx context.Context, args []string) error {
+	fs, format := commandFlags("list")
+	if err := parseFlags(fs, format, args); err != nil {
+		return err
+	}
+
+	list, err := showStates(ctx)
+	if err != nil {
+		return err
+	}
+
+	return output(*format, list, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tNAME\tSTATUS\tLAST RUN\tNEXT RUN\tFAILURES\tLAST ERROR")
+		for _, s := range list {
+			lastRun, nextRun := "never", "-"
+			if !s.LastRun.IsZero() {
+				lastRun = s.LastRun.Local().Format("2006-01-02 15:04")
+			}
+			if !s.NextRun.IsZero() {
+				nextRun = s.NextRun.Local().Format("2006-01-02 15:04")
+			}
+			status := string(s.Status)
+			if status == "" {
+				status = "-"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%d\t%s\n", s.ID, s.Name, status, lastRun, nextRun,
+				s.Failures, strings.SplitN(s.LastError, "\n", 2)[0])
+		}
+	})
+}
+
+func runRetry(ctx context.Context, args []string) error {
+	fs, format := commandFlags("retry")
+	if err := parseFlags(fs, format, args); err != nil {
+		return err
+	}
+
+	list, err := showStates(ctx)
+	if err != nil {
+		return err
+	}
+
+	var shows []*show.Show
+	for _, st := range list {
+		if st.Failures > 0 {
+			shows = append(shows, st.show)
+		}
+	}
+
+	reports, failed := scrapeShows(ctx, shows...)
+	results := make([]*scrapeResult, len(reports))
+	for i, r := range reports {
+		results[i] = &scrapeResult{Show: shows[i], Episodes: shows[i].Episodes, Report: r, Diff: r.Diff}
+	}
+
+	if err := output(*format, results, func(w io.Writer) {
+		if len(results) == 0 {
+			fmt.Fprintln(w, "no failed shows")
+		}
+		for _, res := range results {
+			printResult(w, res, false)
+		}
+	}); err != nil {
+		return err
+	}
+	return failed
+}
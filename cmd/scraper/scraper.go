@@ -1,13 +1,30 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 
+	"go.uber.org/zap"
+
+	"tracker/database"
+	"tracker/internal/fetch"
+	"tracker/internal/mediawiki"
+	"tracker/internal/scheduler"
+	_ "tracker/trackable/all"
 	"tracker/trackable/show"
 )
 
+// uncachedOptions are the options of the default client, but for its cache.
+var uncachedOptions []fetch.Option
+
 func main() {
 	if err := run(); err != nil {
 		log.Printf("%+v\n", err)
@@ -16,10 +33,152 @@ func main() {
 }
 
 func run() error {
-	log.Printf("starting scraper")
-	if err := show.ScrapeAll(); err != nil {
-		return fmt.Errorf("scrape error: %w", err)
+	var (
+		daemon     = flag.Bool("daemon", false, "keep running, scraping each trackable when it is due")
+		statusAddr = flag.String("status-addr", ":8090", "address serving the status of the daemon")
+		workers    = flag.Int("workers", 4, "number of trackables scraped at once")
+		userAgent  = flag.String("user-agent", fetch.DefaultUserAgent, "User-Agent identifying the scraper, ideally with contact details")
+		rate       = flag.Float64("rate", 1, "requests per second allowed to each host")
+		cacheDir   = flag.String("cache-dir", defaultCacheDir(), "directory caching fetched pages, empty to disable")
+		cacheAge   = flag.Duration("cache-max-age", fetch.DefaultMaxAge, "time after which cached pages are fetched unconditionally")
+		record     = flag.String("record", "", "directory saving every fetched page, to replay the scrape later")
+		replay     = flag.String("replay", "", "directory of recorded pages served instead of fetching them")
+		labels     = flag.String("infobox-labels", "", "JSON file mapping labels of infoboxes to fields of shows, by language")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [command]\n\nFlags:\n", os.Args[0])
+		flag.PrintDefaults()
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+	}
+	flag.Parse()
+
+	opts := []fetch.Option{
+		fetch.UserAgent(*userAgent),
+		fetch.RateLimit(*rate, 1),
+		fetch.APIPaths(mediawiki.APIPath),
+	}
+	switch {
+	case *record != "" && *replay != "":
+		return fmt.Errorf("unable to record and replay at once")
+	case *record != "":
+		recordings, err := fetch.NewRecordings(*record)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, fetch.Record(recordings))
+	case *replay != "":
+		recordings, err := fetch.NewRecordings(*replay)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, fetch.Replay(recordings))
 	}
+	uncachedOptions = opts
+	if *cacheDir != "" && *replay == "" {
+		cache, err := fetch.NewDiskCache(*cacheDir, *cacheAge)
+		if err != nil {
+			return fmt.Errorf("unable to create cache: %w", err)
+		}
+		opts = append(opts[:len(opts):len(opts)], fetch.Cache(cache))
+	}
+	fetch.SetDefault(fetch.New(opts...))
+
+	if *labels != "" {
+		if err := loadInfoLabels(*labels); err != nil {
+			return err
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
+	if flag.NArg() > 0 {
+		cmd, ok := commands[flag.Arg(0)]
+		if !ok {
+			flag.Usage()
+			return fmt.Errorf("unknown command %q", flag.Arg(0))
+		}
+		return cmd(ctx, flag.Args()[1:])
+	}
+	if *daemon {
+		return runDaemon(ctx, *statusAddr, *workers)
+	}
+
+	return runOnce(ctx, *workers)
+}
+
+// runOnce scrapes every trackable once, keeping its state and report as the
+// daemon would, so that failures can be listed and retried.
+func runOnce(ctx context.Context, workers int) error {
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return fmt.Errorf("unable to create logger: %w", err)
+	}
+	defer logger.Sync()
+
+	db, err := database.Open("tracker")
+	if err != nil {
+		return fmt.Errorf("unable to open tracker database: %w", err)
+	}
+	defer db.Close()
+
+	s := scheduler.New(scheduler.NewSQLStore(db), scheduler.Logger(logger), scheduler.Workers(workers))
+	logger.Info("starting scraper")
+	return s.ScrapeAll(ctx)
+}
+
+// loadInfoLabels maps the labels of infoboxes of the file, in addition to
+// the default ones.
+func loadInfoLabels(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to open infobox labels: %w", err)
+	}
+	defer f.Close()
+	return show.LoadInfoLabels(f)
+}
+
+// defaultCacheDir is within the cache directory of the user, if there is one.
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "tracker-scraper")
+}
+
+// runDaemon scrapes trackables as they are due until interrupted, serving
+// its status at /status and the reports of its scrapes at /reports.
+func runDaemon(ctx context.Context, statusAddr string, workers int) error {
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return fmt.Errorf("unable to create logger: %w", err)
+	}
+	defer logger.Sync()
+
+	db, err := database.Open("tracker")
+	if err != nil {
+		return fmt.Errorf("unable to open tracker database: %w", err)
+	}
+	defer db.Close()
+
+	store := scheduler.NewSQLStore(db)
+	s := scheduler.New(store, scheduler.Logger(logger), scheduler.Workers(workers))
+
+	mux := http.NewServeMux()
+	mux.Handle("/status", s)
+	mux.Handle("/reports", &scheduler.ReportsHandler{Store: store, Limit: 100})
+	srv := &http.Server{Addr: statusAddr, Handler: mux}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("unable to serve status", zap.Error(err))
+		}
+	}()
+	defer srv.Close()
+
+	logger.Info("starting scrape daemon", zap.String("status_addr", statusAddr))
+	if err := s.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
 	return nil
 }
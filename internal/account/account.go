@@ -0,0 +1,171 @@
+// Package account allows users to export all of their data, and to delete
+// their account along with everything belonging to it.
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"tracker/internal/database"
+	"tracker/internal/types/social"
+	"tracker/internal/types/user"
+	"tracker/internal/types/watch"
+)
+
+// exportLimit is the maximum amount of activity included in an export.
+const exportLimit = 1 << 20
+
+// Stores holds every store which contains data of a user.
+type Stores struct {
+	Users       database.UsersDatabase
+	Profiles    database.ProfilesDatabase
+	Follows     database.FollowsDatabase
+	Activity    database.ActivityDatabase
+	Watch       database.WatchDatabase
+	Reading     database.ReadingDatabase
+	Backlog     database.BacklogDatabase
+	Listening   database.ListeningDatabase
+	ImportQueue database.ImportQueueDatabase
+	Sessions    database.SessionsDatabase
+
+	// Mirrors are other stores holding a copy of the users, such as consul.
+	Mirrors []database.UsersDatabase
+}
+
+// Service exports and deletes accounts.
+type Service struct {
+	stores Stores
+}
+
+// NewService creates a new account service.
+func NewService(stores Stores) *Service {
+	return &Service{stores: stores}
+}
+
+// Archive contains all data of a single user.
+type Archive struct {
+	Exported  time.Time          `json:"exported"`
+	User      *user.User         `json:"user"`
+	Profiles  []*ProfileArchive  `json:"profiles"`
+	Followers []*social.Follow   `json:"followers"`
+	Activity  []*social.Activity `json:"activity"`
+	Sessions  []*user.Session    `json:"sessions"`
+}
+
+// ProfileArchive contains all data of a single profile.
+type ProfileArchive struct {
+	*user.Profile
+
+	Following   []*social.Follow    `json:"following"`
+	Watched     []*watch.Episode    `json:"watched"`
+	Ratings     []*watch.Rating     `json:"ratings"`
+	Reading     []*watch.Progress   `json:"reading"`
+	Backlog     []*watch.Play       `json:"backlog"`
+	Listened    []*watch.Listen     `json:"listened"`
+	ImportQueue []*watch.ImportItem `json:"import_queue"`
+}
+
+// Export all data of the user.
+func (s *Service) Export(ctx context.Context, email string) (*Archive, error) {
+	u, err := s.stores.Users.Details(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get user: %w", err)
+	}
+
+	a := &Archive{
+		Exported: time.Now(),
+		User:     u,
+	}
+
+	profiles, err := s.stores.Profiles.List(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list profiles: %w", err)
+	}
+	for _, p := range profiles {
+		pa := &ProfileArchive{Profile: p}
+		if pa.Following, err = s.stores.Follows.Following(ctx, email, p.ID); err != nil {
+			return nil, fmt.Errorf("unable to list follows: %w", err)
+		}
+		if pa.Watched, err = s.stores.Watch.Watched(ctx, p.ID); err != nil {
+			return nil, fmt.Errorf("unable to list watched episodes: %w", err)
+		}
+		if pa.Ratings, err = s.stores.Watch.Ratings(ctx, p.ID); err != nil {
+			return nil, fmt.Errorf("unable to list ratings: %w", err)
+		}
+		if pa.Reading, err = s.stores.Reading.Progress(ctx, p.ID); err != nil {
+			return nil, fmt.Errorf("unable to list reading progress: %w", err)
+		}
+		if pa.Backlog, err = s.stores.Backlog.List(ctx, p.ID); err != nil {
+			return nil, fmt.Errorf("unable to list backlog: %w", err)
+		}
+		if pa.Listened, err = s.stores.Listening.Listened(ctx, p.ID); err != nil {
+			return nil, fmt.Errorf("unable to list listened episodes: %w", err)
+		}
+		if pa.ImportQueue, err = s.stores.ImportQueue.List(ctx, p.ID); err != nil {
+			return nil, fmt.Errorf("unable to list import queue: %w", err)
+		}
+		a.Profiles = append(a.Profiles, pa)
+	}
+
+	if a.Followers, err = s.stores.Follows.Followers(ctx, email); err != nil {
+		return nil, fmt.Errorf("unable to list followers: %w", err)
+	}
+	if a.Activity, err = s.stores.Activity.List(ctx, email, exportLimit); err != nil {
+		return nil, fmt.Errorf("unable to list activity: %w", err)
+	}
+	if a.Sessions, err = s.stores.Sessions.List(ctx, email); err != nil {
+		return nil, fmt.Errorf("unable to list sessions: %w", err)
+	}
+
+	return a, nil
+}
+
+// Delete the user along with all of their data. Activity of other users
+// which refers to the user is anonymised. The user itself is removed last, so
+// a failed deletion can be retried.
+func (s *Service) Delete(ctx context.Context, email string) error {
+	profiles, err := s.stores.Profiles.List(ctx, email)
+	if err != nil {
+		return fmt.Errorf("unable to list profiles: %w", err)
+	}
+	for _, p := range profiles {
+		if err := s.stores.Watch.RemoveProfile(ctx, p.ID); err != nil {
+			return err
+		}
+		if err := s.stores.Reading.RemoveProfile(ctx, p.ID); err != nil {
+			return err
+		}
+		if err := s.stores.Backlog.RemoveProfile(ctx, p.ID); err != nil {
+			return err
+		}
+		if err := s.stores.Listening.RemoveProfile(ctx, p.ID); err != nil {
+			return err
+		}
+		if err := s.stores.ImportQueue.RemoveProfile(ctx, p.ID); err != nil {
+			return err
+		}
+		if err := s.stores.Profiles.Delete(ctx, p.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.stores.Follows.RemoveUser(ctx, email); err != nil {
+		return err
+	}
+	if err := s.stores.Activity.RemoveUser(ctx, email); err != nil {
+		return err
+	}
+	if err := s.stores.Sessions.RemoveUser(ctx, email); err != nil {
+		return err
+	}
+
+	for _, mirror := range s.stores.Mirrors {
+		if err := mirror.Delete(ctx, email); err != nil && !errors.Is(err, database.ErrNotFound) {
+			return fmt.Errorf("unable to delete user from mirror: %w", err)
+		}
+	}
+
+	return s.stores.Users.Delete(ctx, email)
+}
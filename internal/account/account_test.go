@@ -0,0 +1,242 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"tracker/internal/database"
+	"tracker/internal/types/user"
+	"tracker/internal/types/watch"
+)
+
+func TestWriteArchive(t *testing.T) {
+	watched := time.Date(2021, time.March, 6, 20, 0, 0, 0, time.UTC)
+	a := &Archive{
+		Exported: watched,
+		User:     &user.User{Email: "user@example.com", Name: "User"},
+		Profiles: []*ProfileArchive{{
+			Profile: &user.Profile{ID: 1, Owner: "user@example.com", Name: "Default"},
+			Watched: []*watch.Episode{
+				{Profile: 1, ShowID: 3, Season: 1, Episode: 2, Watched: watched},
+			},
+		}, {
+			Profile: &user.Profile{ID: 2, Owner: "user@example.com", Name: "Kid"},
+			Watched: []*watch.Episode{
+				{Profile: 2, ShowID: 4, Season: 2, Episode: 1, Watched: watched},
+			},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteArchive(&buf, a); err != nil {
+		t.Fatalf("WriteArchive() err = %v, want %v", err, nil)
+	}
+
+	z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("unable to read zip: %v", err)
+	}
+
+	files := map[string][]byte{}
+	for _, f := range z.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatalf("unable to open %s: %v", f.Name, err)
+		}
+		files[f.Name], _ = ioutil.ReadAll(r)
+		r.Close()
+	}
+
+	wantHistory := "profile,show_id,season,episode,watched\n" +
+		"Default,3,1,2,2021-03-06T20:00:00Z\n" +
+		"Kid,4,2,1,2021-03-06T20:00:00Z\n"
+	if got := string(files["history.csv"]); got != wantHistory {
+		t.Errorf("history.csv = %q, want %q", got, wantHistory)
+	}
+
+	var got Archive
+	if err := json.Unmarshal(files["account.json"], &got); err != nil {
+		t.Fatalf("unable to decode account.json: %v", err)
+	}
+	if diff := deep.Equal(&got, a); diff != nil {
+		t.Errorf("account.json diff = %v", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	const email, other = "user@example.com", "other@example.com"
+	users := func() map[string]bool { return map[string]bool{email: true, other: true} }
+	profiles := func() map[int64]bool { return map[int64]bool{1: true, 2: true, 3: true} }
+
+	stores := Stores{
+		Users: &testUsers{users: users()},
+		Profiles: &testProfiles{m: map[int64]*user.Profile{
+			1: {ID: 1, Owner: email, Name: "Default"},
+			2: {ID: 2, Owner: email, Name: "Kid"},
+			3: {ID: 3, Owner: other, Name: "Default"},
+		}},
+		Follows:     &testFollows{users: users()},
+		Activity:    &testActivity{users: users()},
+		Watch:       &testWatch{profiles: profiles()},
+		Reading:     &testReading{profiles: profiles()},
+		Backlog:     &testBacklog{profiles: profiles()},
+		Listening:   &testListening{profiles: profiles()},
+		ImportQueue: &testImportQueue{profiles: profiles()},
+		Sessions:    &testSessions{users: users()},
+		Mirrors:     []database.UsersDatabase{&testUsers{users: users()}},
+	}
+	if err := NewService(stores).Delete(context.Background(), email); err != nil {
+		t.Fatalf("Delete() err = %v, want %v", err, nil)
+	}
+
+	// Only the data of the other user is left in every store.
+	wantUsers := map[string]bool{other: true}
+	wantProfiles := map[int64]bool{3: true}
+	got := map[string]interface{}{
+		"users":        stores.Users.(*testUsers).users,
+		"mirror":       stores.Mirrors[0].(*testUsers).users,
+		"follows":      stores.Follows.(*testFollows).users,
+		"activity":     stores.Activity.(*testActivity).users,
+		"sessions":     stores.Sessions.(*testSessions).users,
+		"watch":        stores.Watch.(*testWatch).profiles,
+		"reading":      stores.Reading.(*testReading).profiles,
+		"backlog":      stores.Backlog.(*testBacklog).profiles,
+		"listening":    stores.Listening.(*testListening).profiles,
+		"import queue": stores.ImportQueue.(*testImportQueue).profiles,
+	}
+	for name, data := range got {
+		want := interface{}(wantUsers)
+		if _, ok := data.(map[int64]bool); ok {
+			want = wantProfiles
+		}
+		if diff := deep.Equal(data, want); diff != nil {
+			t.Errorf("Delete() %s diff = %v", name, diff)
+		}
+	}
+	if p := stores.Profiles.(*testProfiles).m; len(p) != 1 || p[3] == nil {
+		t.Errorf("Delete() profiles = %v, want only profile 3", p)
+	}
+}
+
+// The test stores only implement what Delete uses, keeping the users or the
+// profiles they hold data of.
+
+type testUsers struct {
+	database.UsersDatabase
+	users map[string]bool
+}
+
+func (db *testUsers) Delete(_ context.Context, email string) error {
+	if !db.users[email] {
+		return database.ErrNotFound
+	}
+	delete(db.users, email)
+	return nil
+}
+
+type testProfiles struct {
+	database.ProfilesDatabase
+	m map[int64]*user.Profile
+}
+
+func (db *testProfiles) List(_ context.Context, owner string) ([]*user.Profile, error) {
+	profiles := make([]*user.Profile, 0)
+	for _, p := range db.m {
+		if p.Owner == owner {
+			profiles = append(profiles, p)
+		}
+	}
+	return profiles, nil
+}
+
+func (db *testProfiles) Delete(_ context.Context, id int64) error {
+	delete(db.m, id)
+	return nil
+}
+
+type testFollows struct {
+	database.FollowsDatabase
+	users map[string]bool
+}
+
+func (db *testFollows) RemoveUser(_ context.Context, email string) error {
+	delete(db.users, email)
+	return nil
+}
+
+type testActivity struct {
+	database.ActivityDatabase
+	users map[string]bool
+}
+
+func (db *testActivity) RemoveUser(_ context.Context, email string) error {
+	delete(db.users, email)
+	return nil
+}
+
+type testSessions struct {
+	database.SessionsDatabase
+	users map[string]bool
+}
+
+func (db *testSessions) RemoveUser(_ context.Context, email string) error {
+	delete(db.users, email)
+	return nil
+}
+
+type testWatch struct {
+	database.WatchDatabase
+	profiles map[int64]bool
+}
+
+func (db *testWatch) RemoveProfile(_ context.Context, profile int64) error {
+	delete(db.profiles, profile)
+	return nil
+}
+
+type testReading struct {
+	database.ReadingDatabase
+	profiles map[int64]bool
+}
+
+func (db *testReading) RemoveProfile(_ context.Context, profile int64) error {
+	delete(db.profiles, profile)
+	return nil
+}
+
+type testBacklog struct {
+	database.BacklogDatabase
+	profiles map[int64]bool
+}
+
+func (db *testBacklog) RemoveProfile(_ context.Context, profile int64) error {
+	delete(db.profiles, profile)
+	return nil
+}
+
+type testListening struct {
+	database.ListeningDatabase
+	profiles map[int64]bool
+}
+
+func (db *testListening) RemoveProfile(_ context.Context, profile int64) error {
+	delete(db.profiles, profile)
+	return nil
+}
+
+type testImportQueue struct {
+	database.ImportQueueDatabase
+	profiles map[int64]bool
+}
+
+func (db *testImportQueue) RemoveProfile(_ context.Context, profile int64) error {
+	delete(db.profiles, profile)
+	return nil
+}
@@ -0,0 +1,64 @@
+package account
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// WriteArchive writes the archive as a zip file, containing the full archive
+// as JSON and the watch history of every profile as CSV.
+func WriteArchive(w io.Writer, a *Archive) error {
+	z := zip.NewWriter(w)
+
+	f, err := z.Create("account.json")
+	if err != nil {
+		return fmt.Errorf("unable to create account.json: %w", err)
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(a); err != nil {
+		return fmt.Errorf("unable to encode archive: %w", err)
+	}
+
+	f, err = z.Create("history.csv")
+	if err != nil {
+		return fmt.Errorf("unable to create history.csv: %w", err)
+	}
+	if err := writeHistory(f, a); err != nil {
+		return fmt.Errorf("unable to write history: %w", err)
+	}
+
+	return z.Close()
+}
+
+var historyHeader = []string{"profile", "show_id", "season", "episode", "watched"}
+
+// writeHistory writes the watched episodes of all profiles as CSV.
+func writeHistory(w io.Writer, a *Archive) error {
+	c := csv.NewWriter(w)
+	if err := c.Write(historyHeader); err != nil {
+		return err
+	}
+
+	for _, p := range a.Profiles {
+		for _, e := range p.Watched {
+			if err := c.Write([]string{
+				p.Name,
+				strconv.Itoa(e.ShowID),
+				strconv.Itoa(e.Season),
+				strconv.Itoa(e.Episode),
+				e.Watched.UTC().Format(time.RFC3339),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	c.Flush()
+	return c.Error()
+}
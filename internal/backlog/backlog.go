@@ -0,0 +1,53 @@
+// Package backlog keeps track of the games profiles want to play, are
+// playing or have played.
+package backlog
+
+import (
+	"context"
+
+	"tracker/internal/database"
+	"tracker/internal/types/watch"
+)
+
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+const (
+	ErrInvalidState = Error("backlog: unknown play state")
+	ErrInvalidGame  = Error("backlog: game must be positive")
+)
+
+// Service updates the backlog of profiles.
+type Service struct {
+	backlog database.BacklogDatabase
+}
+
+// NewService creates a new backlog service.
+func NewService(backlog database.BacklogDatabase) *Service {
+	return &Service{backlog: backlog}
+}
+
+// SetState updates the state of the game for the profile.
+func (s *Service) SetState(ctx context.Context, p *watch.Play) error {
+	if !p.State.Valid() {
+		return ErrInvalidState
+	}
+	if p.GameID <= 0 {
+		return ErrInvalidGame
+	}
+
+	return s.backlog.SetState(ctx, p)
+}
+
+// Remove the game from the backlog of the profile.
+func (s *Service) Remove(ctx context.Context, profile int64, game int) error {
+	return s.backlog.Remove(ctx, profile, game)
+}
+
+// List the state of all games of the profile.
+func (s *Service) List(ctx context.Context, profile int64) ([]*watch.Play, error) {
+	return s.backlog.List(ctx, profile)
+}
@@ -0,0 +1,77 @@
+package backlog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	"tracker/internal/types/watch"
+)
+
+func TestSetState(t *testing.T) {
+	testCases := map[string]struct {
+		play *watch.Play
+		want *watch.Play
+		err  error
+	}{
+		"backlog": {
+			play: &watch.Play{Profile: 1, GameID: 2, State: watch.Backlog},
+			want: &watch.Play{Profile: 1, GameID: 2, State: watch.Backlog},
+		},
+		"played": {
+			play: &watch.Play{Profile: 1, GameID: 2, State: watch.Played},
+			want: &watch.Play{Profile: 1, GameID: 2, State: watch.Played},
+		},
+		"unknown state": {
+			play: &watch.Play{GameID: 1, State: "abandoned"},
+			err:  ErrInvalidState,
+		},
+		"no game": {
+			play: &watch.Play{State: watch.Playing},
+			err:  ErrInvalidGame,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db := &testBacklog{}
+			s := NewService(db)
+
+			err := s.SetState(context.Background(), tc.play)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("SetState() err = %v, want %v", err, tc.err)
+			}
+			if diff := deep.Equal(db.play, tc.want); diff != nil {
+				t.Errorf("SetState() diff = %v", diff)
+			}
+		})
+	}
+}
+
+type testBacklog struct {
+	play *watch.Play
+}
+
+func (db *testBacklog) SetState(_ context.Context, p *watch.Play) error {
+	db.play = p
+	return nil
+}
+
+func (db *testBacklog) Remove(context.Context, int64, int) error {
+	db.play = nil
+	return nil
+}
+
+func (db *testBacklog) List(context.Context, int64) ([]*watch.Play, error) {
+	if db.play == nil {
+		return nil, nil
+	}
+	return []*watch.Play{db.play}, nil
+}
+
+func (db *testBacklog) RemoveProfile(context.Context, int64) error {
+	db.play = nil
+	return nil
+}
@@ -3,6 +3,7 @@ package consul
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"tracker/internal/database"
@@ -55,6 +56,23 @@ func TestE2E(t *testing.T) {
 	if diff := deep.Equal(got, want); diff != nil {
 		t.Fatalf("Get() = %v, got %v, diff = %v", got, want, diff)
 	}
+
+	all, err := usersDB.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() err = %v, want %v", err, nil)
+	}
+	if diff := deep.Equal(all, []*user.User{want}); diff != nil {
+		t.Fatalf("List() = %v, want %v, diff = %v", all, []*user.User{want}, diff)
+	}
+
+	if err := usersDB.Delete(context.Background(), "user@example.com"); err != nil {
+		t.Fatalf("Delete() err = %v, want %v", err, nil)
+	}
+
+	_, err = usersDB.Details(context.Background(), "user@example.com")
+	if !errors.Is(err, errNotFound) {
+		t.Fatalf("(Deleted) Get() err = %v, want %v", err, errNotFound)
+	}
 }
 
 type testKV struct {
@@ -66,6 +84,21 @@ func (kv *testKV) Put(pair *api.KVPair, _ *api.WriteOptions) (*api.WriteMeta, er
 	return nil, nil
 }
 
+func (kv *testKV) List(prefix string, _ *api.QueryOptions) (api.KVPairs, *api.QueryMeta, error) {
+	pairs := make(api.KVPairs, 0)
+	for k, v := range kv.m {
+		if strings.HasPrefix(k, prefix) {
+			pairs = append(pairs, &api.KVPair{Key: k, Value: v})
+		}
+	}
+	return pairs, nil, nil
+}
+
+func (kv *testKV) Delete(key string, _ *api.WriteOptions) (*api.WriteMeta, error) {
+	delete(kv.m, key)
+	return nil, nil
+}
+
 func (kv *testKV) Get(key string, _ *api.QueryOptions) (*api.KVPair, *api.QueryMeta, error) {
 	if v, exists := kv.m[key]; exists {
 		return &api.KVPair{Key: key, Value: v}, nil, nil
@@ -78,6 +78,40 @@ func (db *Database) put(ctx context.Context, key string, value interface{}) erro
 	return nil
 }
 
+// list all values under the prefix, calling fn with the raw value of each key.
+func (db *Database) list(ctx context.Context, prefix string, fn func([]byte) error) error {
+	opt := &api.QueryOptions{}
+	opt = opt.WithContext(ctx)
+
+	p := path.Join(db.prefix, prefix)
+
+	pairs, _, err := db.kv.List(p, opt)
+	if err != nil {
+		return fmt.Errorf("unable to list %s: %w", p, err)
+	}
+
+	for _, pair := range pairs {
+		if err := fn(pair.Value); err != nil {
+			return fmt.Errorf("unable to read %s: %w", pair.Key, err)
+		}
+	}
+
+	return nil
+}
+
+func (db *Database) delete(ctx context.Context, key string) error {
+	opt := &api.WriteOptions{}
+	opt = opt.WithContext(ctx)
+
+	p := path.Join(db.prefix, key)
+
+	if _, err := db.kv.Delete(p, opt); err != nil {
+		return fmt.Errorf("unable to delete %s: %w", p, err)
+	}
+
+	return nil
+}
+
 // Option allows to set options for the Consul database.type Option func(*Database)
 type Option func(*Database)
 
@@ -91,4 +125,6 @@ func KVClient(kv KV) Option {
 type KV interface {
 	Put(p *api.KVPair, q *api.WriteOptions) (*api.WriteMeta, error)
 	Get(key string, q *api.QueryOptions) (*api.KVPair, *api.QueryMeta, error)
+	List(prefix string, q *api.QueryOptions) (api.KVPairs, *api.QueryMeta, error)
+	Delete(key string, w *api.WriteOptions) (*api.WriteMeta, error)
 }
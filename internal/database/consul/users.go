@@ -2,6 +2,7 @@ package consul
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"path"
 
@@ -39,6 +40,40 @@ func (db *UsersDatabase) Details(ctx context.Context, email string) (*user.User,
 	return &u, nil
 }
 
+// SetPrivacy of the user
+func (db *UsersDatabase) SetPrivacy(ctx context.Context, email string, privacy user.Privacy) error {
+	u, err := db.Details(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	u.Privacy = privacy
+	return db.Create(ctx, u)
+}
+
+// List all users
+func (db *UsersDatabase) List(ctx context.Context) ([]*user.User, error) {
+	users := make([]*user.User, 0)
+	err := db.db.list(ctx, db.prefix, func(value []byte) error {
+		u := &user.User{}
+		if err := json.Unmarshal(value, u); err != nil {
+			return err
+		}
+		users = append(users, u)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// Delete the user
+func (db *UsersDatabase) Delete(ctx context.Context, email string) error {
+	return db.db.delete(ctx, path.Join(db.prefix, email))
+}
+
 func (db *UsersDatabase) get(ctx context.Context, key string, value interface{}) error {
 	return db.db.get(ctx, path.Join(db.prefix, key), value)
 }
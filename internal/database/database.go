@@ -3,7 +3,9 @@ package database
 import (
 	"context"
 
+	"tracker/internal/types/social"
 	"tracker/internal/types/user"
+	"tracker/internal/types/watch"
 )
 
 type Error string
@@ -19,6 +21,15 @@ const (
 // Database
 type Database interface {
 	Users() UsersDatabase
+	Profiles() ProfilesDatabase
+	Follows() FollowsDatabase
+	Activity() ActivityDatabase
+	Watch() WatchDatabase
+	Reading() ReadingDatabase
+	Backlog() BacklogDatabase
+	Listening() ListeningDatabase
+	ImportQueue() ImportQueueDatabase
+	Sessions() SessionsDatabase
 }
 
 // UserDatabase abstracts the user interaction with the database.
@@ -27,4 +38,131 @@ type UsersDatabase interface {
 	Create(context.Context, *user.User) error
 	// Details the user based on the email address.
 	Details(ctx context.Context, email string) (*user.User, error)
+	// SetPrivacy updates the privacy of the user.
+	SetPrivacy(ctx context.Context, email string, privacy user.Privacy) error
+	// List all users.
+	List(ctx context.Context) ([]*user.User, error)
+	// Delete the user.
+	Delete(ctx context.Context, email string) error
+}
+
+// ProfilesDatabase stores the profiles belonging to an account.
+type ProfilesDatabase interface {
+	// Create the profile, setting the ID of the profile.
+	Create(ctx context.Context, p *user.Profile) error
+	// Ensure the account has a profile with the name of the profile,
+	// creating it unless it exists already. The ID of the profile isn't set.
+	Ensure(ctx context.Context, p *user.Profile) error
+	// Get the profile by its ID.
+	Get(ctx context.Context, id int64) (*user.Profile, error)
+	// List all profiles of the account, oldest first.
+	List(ctx context.Context, owner string) ([]*user.Profile, error)
+	// SetPreferences of the profile.
+	SetPreferences(ctx context.Context, id int64, prefs user.Preferences) error
+	// Delete the profile.
+	Delete(ctx context.Context, id int64) error
+}
+
+// FollowsDatabase stores the relationships between users.
+type FollowsDatabase interface {
+	// Follow creates a relationship from a profile of the follower to the
+	// followee, returning true if it didn't exist yet. Following someone
+	// twice is not an error.
+	Follow(ctx context.Context, follower string, profile int64, followee string) (bool, error)
+	// Unfollow removes the relationship from the follower to the followee.
+	Unfollow(ctx context.Context, follower string, profile int64, followee string) error
+	// Following lists everyone the profile of the user follows.
+	Following(ctx context.Context, email string, profile int64) ([]*social.Follow, error)
+	// Followers lists everyone who follows the user, from any profile.
+	Followers(ctx context.Context, email string) ([]*social.Follow, error)
+	// RemoveUser removes everything followed by, or following the user.
+	RemoveUser(ctx context.Context, email string) error
+}
+
+// ActivityDatabase stores the activity of users.
+type ActivityDatabase interface {
+	// Record the activity, setting the ID of the activity.
+	Record(ctx context.Context, a *social.Activity) error
+	// List the most recent activity of the user, newest first.
+	List(ctx context.Context, email string, limit int) ([]*social.Activity, error)
+	// RemoveUser removes all activity of the user, and anonymises the
+	// activity of others which refers to the user.
+	RemoveUser(ctx context.Context, email string) error
+}
+
+// WatchDatabase stores the watch state of profiles.
+type WatchDatabase interface {
+	// MarkWatched marks the episode as watched. Marking an episode twice
+	// updates the time it was watched.
+	MarkWatched(ctx context.Context, e *watch.Episode) error
+	// UnmarkWatched removes the episode from the watched episodes.
+	UnmarkWatched(ctx context.Context, e *watch.Episode) error
+	// Watched lists all episodes watched by the profile.
+	Watched(ctx context.Context, profile int64) ([]*watch.Episode, error)
+	// Rate the show, replacing any previous rating.
+	Rate(ctx context.Context, r *watch.Rating) error
+	// Ratings lists all ratings of the profile.
+	Ratings(ctx context.Context, profile int64) ([]*watch.Rating, error)
+	// RemoveProfile removes all watched episodes and ratings of the profile.
+	RemoveProfile(ctx context.Context, profile int64) error
+}
+
+// ReadingDatabase stores the progress of profiles through book series.
+type ReadingDatabase interface {
+	// SetProgress replaces the progress of the profile through the volume.
+	SetProgress(ctx context.Context, p *watch.Progress) error
+	// RemoveProgress removes the progress of the profile through the volume.
+	RemoveProgress(ctx context.Context, profile int64, series, volume int) error
+	// Progress lists the progress of the profile through all volumes.
+	Progress(ctx context.Context, profile int64) ([]*watch.Progress, error)
+	// RemoveProfile removes all progress of the profile.
+	RemoveProfile(ctx context.Context, profile int64) error
+}
+
+// BacklogDatabase stores the games profiles want to play, or have played.
+type BacklogDatabase interface {
+	// SetState replaces the state of the game for the profile.
+	SetState(ctx context.Context, p *watch.Play) error
+	// Remove the game from the backlog of the profile.
+	Remove(ctx context.Context, profile int64, game int) error
+	// List the state of all games of the profile.
+	List(ctx context.Context, profile int64) ([]*watch.Play, error)
+	// RemoveProfile removes the whole backlog of the profile.
+	RemoveProfile(ctx context.Context, profile int64) error
+}
+
+// ListeningDatabase stores the podcast episodes profiles have listened to.
+type ListeningDatabase interface {
+	// SetListened marks the episode as listened to by the profile.
+	SetListened(ctx context.Context, l *watch.Listen) error
+	// RemoveListened marks the episode as not listened to by the profile.
+	RemoveListened(ctx context.Context, profile int64, podcast int, guid string) error
+	// Listened lists all episodes the profile listened to.
+	Listened(ctx context.Context, profile int64) ([]*watch.Listen, error)
+	// RemoveProfile removes all listened episodes of the profile.
+	RemoveProfile(ctx context.Context, profile int64) error
+}
+
+// ImportQueueDatabase stores imported items which need to be reviewed.
+type ImportQueueDatabase interface {
+	// Add the item to the queue, setting the ID of the item.
+	Add(ctx context.Context, item *watch.ImportItem) error
+	// Get a single item of the queue.
+	Get(ctx context.Context, id int64) (*watch.ImportItem, error)
+	// List the items waiting for review by the profile.
+	List(ctx context.Context, profile int64) ([]*watch.ImportItem, error)
+	// Remove the item from the queue.
+	Remove(ctx context.Context, id int64) error
+	// RemoveProfile removes all queued items of the profile.
+	RemoveProfile(ctx context.Context, profile int64) error
+}
+
+// SessionsDatabase keeps a record of the logins of users.
+type SessionsDatabase interface {
+	// Record a new session.
+	Record(ctx context.Context, s *user.Session) error
+	// List all sessions of the user, newest first.
+	List(ctx context.Context, email string) ([]*user.Session, error)
+	// RemoveUser removes all sessions of the user.
+	RemoveUser(ctx context.Context, email string) error
 }
@@ -0,0 +1,142 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"tracker/internal/types/social"
+)
+
+type ActivityDatabase struct {
+	db *Database
+
+	recordStmt *sql.Stmt
+	listStmt   *sql.Stmt
+	removeStmt *sql.Stmt
+	anonStmt   *sql.Stmt
+}
+
+func (db *Database) Activity() *ActivityDatabase {
+	return &ActivityDatabase{
+		db: db,
+
+		recordStmt: db.mustPrepare(recordActivityQuery),
+		listStmt:   db.mustPrepare(listActivityQuery),
+		removeStmt: db.mustPrepare(removeUserActivityQuery),
+		anonStmt:   db.mustPrepare(anonymiseActivityQuery),
+	}
+}
+
+func (db *ActivityDatabase) Record(ctx context.Context, a *social.Activity) error {
+	if a.Created.IsZero() {
+		a.Created = time.Now()
+	}
+
+	res, err := db.recordStmt.ExecContext(ctx, a.User, a.Profile, a.Kind, a.Created,
+		a.ShowID, a.Season, a.Episode, a.Rating, a.Target)
+	if err != nil {
+		return fmt.Errorf("unable to record activity: %w", err)
+	}
+
+	if a.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("unable to get activity id: %w", err)
+	}
+
+	return nil
+}
+
+func (db *ActivityDatabase) List(ctx context.Context, email string, limit int) ([]*social.Activity, error) {
+	rows, err := db.listStmt.QueryContext(ctx, email, limit)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query activity: %w", err)
+	}
+	defer rows.Close()
+
+	activities := make([]*social.Activity, 0)
+	for rows.Next() {
+		a := &social.Activity{}
+		if err := rows.Scan(&a.ID, &a.User, &a.Profile, &a.Kind, &a.Created,
+			&a.ShowID, &a.Season, &a.Episode, &a.Rating, &a.Target); err != nil {
+			return nil, fmt.Errorf("unable to scan activity: %w", err)
+		}
+		activities = append(activities, a)
+	}
+
+	return activities, rows.Err()
+}
+
+func (db *ActivityDatabase) RemoveUser(ctx context.Context, email string) error {
+	tx, err := db.db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.StmtContext(ctx, db.removeStmt).ExecContext(ctx, email); err != nil {
+		return fmt.Errorf("unable to remove activity: %w", err)
+	}
+	if _, err := tx.StmtContext(ctx, db.anonStmt).ExecContext(ctx, email); err != nil {
+		return fmt.Errorf("unable to anonymise activity: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+const recordActivityQuery = `
+INSERT INTO activity (
+	user,
+	profile_id,
+	kind,
+	created,
+	show_id,
+	season,
+	episode,
+	rating,
+	target
+) VALUES (
+	?,
+	?,
+	?,
+	?,
+	?,
+	?,
+	?,
+	?,
+	?
+);
+`
+
+const listActivityQuery = `
+SELECT
+	id,
+	user,
+	profile_id,
+	kind,
+	created,
+	show_id,
+	season,
+	episode,
+	rating,
+	target
+FROM activity
+WHERE
+	user=?
+ORDER BY created DESC
+LIMIT ?;
+`
+
+const removeUserActivityQuery = `
+DELETE FROM activity
+WHERE
+	user=?;
+`
+
+const anonymiseActivityQuery = `
+UPDATE activity
+SET
+	target=''
+WHERE
+	target=?;
+`
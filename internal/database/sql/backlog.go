@@ -0,0 +1,112 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"tracker/internal/types/watch"
+)
+
+type BacklogDatabase struct {
+	setStmt     *sql.Stmt
+	removeStmt  *sql.Stmt
+	listStmt    *sql.Stmt
+	profileStmt *sql.Stmt
+}
+
+func (db *Database) Backlog() *BacklogDatabase {
+	return &BacklogDatabase{
+		setStmt:     db.mustPrepare(setPlayStateQuery),
+		removeStmt:  db.mustPrepare(removePlayStateQuery),
+		listStmt:    db.mustPrepare(listBacklogQuery),
+		profileStmt: db.mustPrepare(removeProfileBacklogQuery),
+	}
+}
+
+func (db *BacklogDatabase) SetState(ctx context.Context, p *watch.Play) error {
+	if p.Updated.IsZero() {
+		p.Updated = time.Now()
+	}
+
+	if _, err := db.setStmt.ExecContext(ctx, p.Profile, p.GameID, p.State,
+		p.Updated); err != nil {
+		return fmt.Errorf("unable to set play state: %w", err)
+	}
+
+	return nil
+}
+
+func (db *BacklogDatabase) Remove(ctx context.Context, profile int64, game int) error {
+	if _, err := db.removeStmt.ExecContext(ctx, profile, game); err != nil {
+		return fmt.Errorf("unable to remove game from backlog: %w", err)
+	}
+
+	return nil
+}
+
+func (db *BacklogDatabase) List(ctx context.Context, profile int64) ([]*watch.Play, error) {
+	rows, err := db.listStmt.QueryContext(ctx, profile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query backlog: %w", err)
+	}
+	defer rows.Close()
+
+	plays := make([]*watch.Play, 0)
+	for rows.Next() {
+		p := &watch.Play{}
+		if err := rows.Scan(&p.Profile, &p.GameID, &p.State, &p.Updated); err != nil {
+			return nil, fmt.Errorf("unable to scan play state: %w", err)
+		}
+		plays = append(plays, p)
+	}
+
+	return plays, rows.Err()
+}
+
+func (db *BacklogDatabase) RemoveProfile(ctx context.Context, profile int64) error {
+	if _, err := db.profileStmt.ExecContext(ctx, profile); err != nil {
+		return fmt.Errorf("unable to remove backlog: %w", err)
+	}
+
+	return nil
+}
+
+const setPlayStateQuery = `
+REPLACE INTO backlog (
+	profile_id,
+	game_id,
+	state,
+	updated
+) VALUES (
+	?,
+	?,
+	?,
+	?
+);
+`
+
+const removePlayStateQuery = `
+DELETE FROM backlog
+WHERE
+	profile_id=? AND game_id=?;
+`
+
+const listBacklogQuery = `
+SELECT
+	profile_id,
+	game_id,
+	state,
+	updated
+FROM backlog
+WHERE
+	profile_id=?
+ORDER BY updated DESC;
+`
+
+const removeProfileBacklogQuery = `
+DELETE FROM backlog
+WHERE
+	profile_id=?;
+`
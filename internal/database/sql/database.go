@@ -1,6 +1,9 @@
 package sql
 
-import "database/sql"
+import (
+	"database/sql"
+	"fmt"
+)
 
 type Database struct {
 	db *sql.DB
@@ -9,3 +12,12 @@ type Database struct {
 func NewDatabase(db *sql.DB) *Database {
 	return &Database{db: db}
 }
+
+// mustPrepare prepares the query, panicking if the query is invalid.
+func (db *Database) mustPrepare(query string) *sql.Stmt {
+	stmt, err := db.db.Prepare(query)
+	if err != nil {
+		panic(fmt.Sprintf("unable to prepare query %q: %v", query, err))
+	}
+	return stmt
+}
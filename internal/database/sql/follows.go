@@ -0,0 +1,135 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"tracker/internal/types/social"
+)
+
+type FollowsDatabase struct {
+	db *Database
+
+	followStmt    *sql.Stmt
+	unfollowStmt  *sql.Stmt
+	followingStmt *sql.Stmt
+	followersStmt *sql.Stmt
+	removeStmt    *sql.Stmt
+}
+
+func (db *Database) Follows() *FollowsDatabase {
+	return &FollowsDatabase{
+		db: db,
+
+		followStmt:    db.mustPrepare(followQuery),
+		unfollowStmt:  db.mustPrepare(unfollowQuery),
+		followingStmt: db.mustPrepare(followingQuery),
+		followersStmt: db.mustPrepare(followersQuery),
+		removeStmt:    db.mustPrepare(removeUserFollowsQuery),
+	}
+}
+
+func (db *FollowsDatabase) Follow(ctx context.Context, follower string, profile int64, followee string) (bool, error) {
+	res, err := db.followStmt.ExecContext(ctx, follower, profile, followee)
+	if err != nil {
+		return false, fmt.Errorf("unable to follow: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("unable to follow: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (db *FollowsDatabase) Unfollow(ctx context.Context, follower string, profile int64, followee string) error {
+	if _, err := db.unfollowStmt.ExecContext(ctx, follower, profile, followee); err != nil {
+		return fmt.Errorf("unable to unfollow: %w", err)
+	}
+
+	return nil
+}
+
+func (db *FollowsDatabase) Following(ctx context.Context, email string, profile int64) ([]*social.Follow, error) {
+	return queryFollows(ctx, db.followingStmt, email, profile)
+}
+
+func (db *FollowsDatabase) Followers(ctx context.Context, email string) ([]*social.Follow, error) {
+	return queryFollows(ctx, db.followersStmt, email)
+}
+
+func (db *FollowsDatabase) RemoveUser(ctx context.Context, email string) error {
+	if _, err := db.removeStmt.ExecContext(ctx, email, email); err != nil {
+		return fmt.Errorf("unable to remove follows: %w", err)
+	}
+
+	return nil
+}
+
+func queryFollows(ctx context.Context, stmt *sql.Stmt, args ...interface{}) ([]*social.Follow, error) {
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query follows: %w", err)
+	}
+	defer rows.Close()
+
+	follows := make([]*social.Follow, 0)
+	for rows.Next() {
+		f := &social.Follow{}
+		if err := rows.Scan(&f.Follower, &f.Profile, &f.Followee, &f.Created); err != nil {
+			return nil, fmt.Errorf("unable to scan follow: %w", err)
+		}
+		follows = append(follows, f)
+	}
+
+	return follows, rows.Err()
+}
+
+const followQuery = `
+INSERT IGNORE INTO follows (
+	follower,
+	profile_id,
+	followee
+) VALUES (
+	?,
+	?,
+	?
+);
+`
+
+const unfollowQuery = `
+DELETE FROM follows
+WHERE
+	follower=? AND profile_id=? AND followee=?;
+`
+
+const followingQuery = `
+SELECT
+	follower,
+	profile_id,
+	followee,
+	created
+FROM follows
+WHERE
+	follower=? AND profile_id=?
+ORDER BY created DESC;
+`
+
+const followersQuery = `
+SELECT
+	follower,
+	profile_id,
+	followee,
+	created
+FROM follows
+WHERE
+	followee=?
+ORDER BY created DESC;
+`
+
+const removeUserFollowsQuery = `
+DELETE FROM follows
+WHERE
+	follower=? OR followee=?;
+`
@@ -0,0 +1,177 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"tracker/internal/database"
+	"tracker/internal/types/watch"
+)
+
+type ImportQueueDatabase struct {
+	db *Database
+
+	addStmt    *sql.Stmt
+	getStmt    *sql.Stmt
+	listStmt   *sql.Stmt
+	removeStmt *sql.Stmt
+	clearStmt  *sql.Stmt
+}
+
+func (db *Database) ImportQueue() *ImportQueueDatabase {
+	return &ImportQueueDatabase{
+		db: db,
+
+		addStmt:    db.mustPrepare(addImportItemQuery),
+		getStmt:    db.mustPrepare(getImportItemQuery),
+		listStmt:   db.mustPrepare(listImportItemsQuery),
+		removeStmt: db.mustPrepare(removeImportItemQuery),
+		clearStmt:  db.mustPrepare(removeProfileImportItemsQuery),
+	}
+}
+
+func (db *ImportQueueDatabase) Add(ctx context.Context, item *watch.ImportItem) error {
+	res, err := db.addStmt.ExecContext(ctx, item.Profile, item.Source, item.Title,
+		item.Year, item.IMDbID, item.TVDBID, item.TMDBID, item.Season,
+		item.Episode, item.Rating, item.Watched, item.Reason)
+	if err != nil {
+		return fmt.Errorf("unable to queue import item: %w", err)
+	}
+
+	if item.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("unable to get import item id: %w", err)
+	}
+
+	return nil
+}
+
+func (db *ImportQueueDatabase) Get(ctx context.Context, id int64) (*watch.ImportItem, error) {
+	item, err := scanImportItem(db.getStmt.QueryRowContext(ctx, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("unable to get import item: %w", err)
+	}
+
+	return item, nil
+}
+
+func (db *ImportQueueDatabase) List(ctx context.Context, profile int64) ([]*watch.ImportItem, error) {
+	rows, err := db.listStmt.QueryContext(ctx, profile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query import queue: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]*watch.ImportItem, 0)
+	for rows.Next() {
+		item, err := scanImportItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("unable to scan import item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func (db *ImportQueueDatabase) Remove(ctx context.Context, id int64) error {
+	if _, err := db.removeStmt.ExecContext(ctx, id); err != nil {
+		return fmt.Errorf("unable to remove import item: %w", err)
+	}
+
+	return nil
+}
+
+func (db *ImportQueueDatabase) RemoveProfile(ctx context.Context, profile int64) error {
+	if _, err := db.clearStmt.ExecContext(ctx, profile); err != nil {
+		return fmt.Errorf("unable to remove import items: %w", err)
+	}
+
+	return nil
+}
+
+func scanImportItem(s scanner) (*watch.ImportItem, error) {
+	item := &watch.ImportItem{}
+	if err := s.Scan(&item.ID, &item.Profile, &item.Source, &item.Title,
+		&item.Year, &item.IMDbID, &item.TVDBID, &item.TMDBID, &item.Season,
+		&item.Episode, &item.Rating, &item.Watched, &item.Reason); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+const addImportItemQuery = `
+INSERT INTO import_queue (
+	profile_id,
+	source,
+	title,
+	year,
+	imdb_id,
+	tvdb_id,
+	tmdb_id,
+	season,
+	episode,
+	rating,
+	watched,
+	reason
+) VALUES (
+	?,
+	?,
+	?,
+	?,
+	?,
+	?,
+	?,
+	?,
+	?,
+	?,
+	?,
+	?
+);
+`
+
+const selectImportItem = `
+SELECT
+	id,
+	profile_id,
+	source,
+	title,
+	year,
+	imdb_id,
+	tvdb_id,
+	tmdb_id,
+	season,
+	episode,
+	rating,
+	watched,
+	reason
+FROM import_queue
+`
+
+const getImportItemQuery = selectImportItem + `
+WHERE
+	id=?
+LIMIT 1;
+`
+
+const listImportItemsQuery = selectImportItem + `
+WHERE
+	profile_id=?
+ORDER BY title, season, episode;
+`
+
+const removeImportItemQuery = `
+DELETE FROM import_queue
+WHERE
+	id=?;
+`
+
+const removeProfileImportItemsQuery = `
+DELETE FROM import_queue
+WHERE
+	profile_id=?;
+`
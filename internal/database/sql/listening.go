@@ -0,0 +1,112 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"tracker/internal/types/watch"
+)
+
+type ListeningDatabase struct {
+	setStmt     *sql.Stmt
+	removeStmt  *sql.Stmt
+	listStmt    *sql.Stmt
+	profileStmt *sql.Stmt
+}
+
+func (db *Database) Listening() *ListeningDatabase {
+	return &ListeningDatabase{
+		setStmt:     db.mustPrepare(setListenedQuery),
+		removeStmt:  db.mustPrepare(removeListenedQuery),
+		listStmt:    db.mustPrepare(listListenedQuery),
+		profileStmt: db.mustPrepare(removeProfileListenedQuery),
+	}
+}
+
+func (db *ListeningDatabase) SetListened(ctx context.Context, l *watch.Listen) error {
+	if l.Listened.IsZero() {
+		l.Listened = time.Now()
+	}
+
+	if _, err := db.setStmt.ExecContext(ctx, l.Profile, l.PodcastID, l.GUID,
+		l.Listened); err != nil {
+		return fmt.Errorf("unable to set listened: %w", err)
+	}
+
+	return nil
+}
+
+func (db *ListeningDatabase) RemoveListened(ctx context.Context, profile int64, podcast int, guid string) error {
+	if _, err := db.removeStmt.ExecContext(ctx, profile, podcast, guid); err != nil {
+		return fmt.Errorf("unable to remove listened: %w", err)
+	}
+
+	return nil
+}
+
+func (db *ListeningDatabase) Listened(ctx context.Context, profile int64) ([]*watch.Listen, error) {
+	rows, err := db.listStmt.QueryContext(ctx, profile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query listened: %w", err)
+	}
+	defer rows.Close()
+
+	listened := make([]*watch.Listen, 0)
+	for rows.Next() {
+		l := &watch.Listen{}
+		if err := rows.Scan(&l.Profile, &l.PodcastID, &l.GUID, &l.Listened); err != nil {
+			return nil, fmt.Errorf("unable to scan listened: %w", err)
+		}
+		listened = append(listened, l)
+	}
+
+	return listened, rows.Err()
+}
+
+func (db *ListeningDatabase) RemoveProfile(ctx context.Context, profile int64) error {
+	if _, err := db.profileStmt.ExecContext(ctx, profile); err != nil {
+		return fmt.Errorf("unable to remove listened: %w", err)
+	}
+
+	return nil
+}
+
+const setListenedQuery = `
+REPLACE INTO listened (
+	profile_id,
+	podcast_id,
+	guid,
+	listened
+) VALUES (
+	?,
+	?,
+	?,
+	?
+);
+`
+
+const removeListenedQuery = `
+DELETE FROM listened
+WHERE
+	profile_id=? AND podcast_id=? AND guid=?;
+`
+
+const listListenedQuery = `
+SELECT
+	profile_id,
+	podcast_id,
+	guid,
+	listened
+FROM listened
+WHERE
+	profile_id=?
+ORDER BY listened DESC;
+`
+
+const removeProfileListenedQuery = `
+DELETE FROM listened
+WHERE
+	profile_id=?;
+`
@@ -0,0 +1,204 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"tracker/internal/database"
+	"tracker/internal/types/user"
+)
+
+type ProfilesDatabase struct {
+	db *Database
+
+	createStmt      *sql.Stmt
+	ensureStmt      *sql.Stmt
+	getStmt         *sql.Stmt
+	listStmt        *sql.Stmt
+	preferencesStmt *sql.Stmt
+	deleteStmt      *sql.Stmt
+}
+
+func (db *Database) Profiles() *ProfilesDatabase {
+	return &ProfilesDatabase{
+		db: db,
+
+		createStmt:      db.mustPrepare(createProfileQuery),
+		ensureStmt:      db.mustPrepare(ensureProfileQuery),
+		getStmt:         db.mustPrepare(getProfileQuery),
+		listStmt:        db.mustPrepare(listProfilesQuery),
+		preferencesStmt: db.mustPrepare(updatePreferencesQuery),
+		deleteStmt:      db.mustPrepare(deleteProfileQuery),
+	}
+}
+
+func (db *ProfilesDatabase) Create(ctx context.Context, p *user.Profile) error {
+	prefs, err := json.Marshal(p.Preferences)
+	if err != nil {
+		return fmt.Errorf("unable to encode preferences: %w", err)
+	}
+
+	res, err := db.createStmt.ExecContext(ctx, p.Owner, p.Name, prefs)
+	if err != nil {
+		return fmt.Errorf("unable to insert profile: %w", err)
+	}
+
+	if p.ID, err = res.LastInsertId(); err != nil {
+		return fmt.Errorf("unable to get profile id: %w", err)
+	}
+
+	return nil
+}
+
+func (db *ProfilesDatabase) Ensure(ctx context.Context, p *user.Profile) error {
+	prefs, err := json.Marshal(p.Preferences)
+	if err != nil {
+		return fmt.Errorf("unable to encode preferences: %w", err)
+	}
+
+	if _, err := db.ensureStmt.ExecContext(ctx, p.Owner, p.Name, prefs); err != nil {
+		return fmt.Errorf("unable to insert profile: %w", err)
+	}
+
+	return nil
+}
+
+func (db *ProfilesDatabase) Get(ctx context.Context, id int64) (*user.Profile, error) {
+	p, err := scanProfile(db.getStmt.QueryRowContext(ctx, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("unable to get profile: %w", err)
+	}
+
+	return p, nil
+}
+
+func (db *ProfilesDatabase) List(ctx context.Context, owner string) ([]*user.Profile, error) {
+	rows, err := db.listStmt.QueryContext(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query profiles: %w", err)
+	}
+	defer rows.Close()
+
+	profiles := make([]*user.Profile, 0)
+	for rows.Next() {
+		p, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("unable to scan profile: %w", err)
+		}
+		profiles = append(profiles, p)
+	}
+
+	return profiles, rows.Err()
+}
+
+func (db *ProfilesDatabase) SetPreferences(ctx context.Context, id int64, prefs user.Preferences) error {
+	b, err := json.Marshal(prefs)
+	if err != nil {
+		return fmt.Errorf("unable to encode preferences: %w", err)
+	}
+
+	if _, err := db.preferencesStmt.ExecContext(ctx, b, id); err != nil {
+		return fmt.Errorf("unable to update preferences: %w", err)
+	}
+
+	return nil
+}
+
+func (db *ProfilesDatabase) Delete(ctx context.Context, id int64) error {
+	if _, err := db.deleteStmt.ExecContext(ctx, id); err != nil {
+		return fmt.Errorf("unable to delete profile: %w", err)
+	}
+
+	return nil
+}
+
+// scanner is implemented by both sql.Row and sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProfile(s scanner) (*user.Profile, error) {
+	p := &user.Profile{}
+	var prefs []byte
+	if err := s.Scan(&p.ID, &p.Owner, &p.Name, &prefs); err != nil {
+		return nil, err
+	}
+
+	if len(prefs) > 0 {
+		if err := json.Unmarshal(prefs, &p.Preferences); err != nil {
+			return nil, fmt.Errorf("unable to decode preferences: %w", err)
+		}
+	}
+
+	return p, nil
+}
+
+const createProfileQuery = `
+INSERT INTO profiles (
+	owner,
+	name,
+	preferences
+) VALUES (
+	?,
+	?,
+	?
+);
+`
+
+// ensureProfileQuery relies on the unique key of the owner and the name of
+// profiles.
+const ensureProfileQuery = `
+INSERT IGNORE INTO profiles (
+	owner,
+	name,
+	preferences
+) VALUES (
+	?,
+	?,
+	?
+);
+`
+
+const getProfileQuery = `
+SELECT
+	id,
+	owner,
+	name,
+	preferences
+FROM profiles
+WHERE
+	id=?
+LIMIT 1;
+`
+
+const listProfilesQuery = `
+SELECT
+	id,
+	owner,
+	name,
+	preferences
+FROM profiles
+WHERE
+	owner=?
+ORDER BY id;
+`
+
+const updatePreferencesQuery = `
+UPDATE profiles
+SET
+	preferences=?
+WHERE
+	id=?;
+`
+
+const deleteProfileQuery = `
+DELETE FROM profiles
+WHERE
+	id=?;
+`
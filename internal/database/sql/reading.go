@@ -0,0 +1,119 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"tracker/internal/types/watch"
+)
+
+type ReadingDatabase struct {
+	setStmt     *sql.Stmt
+	removeStmt  *sql.Stmt
+	listStmt    *sql.Stmt
+	profileStmt *sql.Stmt
+}
+
+func (db *Database) Reading() *ReadingDatabase {
+	return &ReadingDatabase{
+		setStmt:     db.mustPrepare(setProgressQuery),
+		removeStmt:  db.mustPrepare(removeProgressQuery),
+		listStmt:    db.mustPrepare(listProgressQuery),
+		profileStmt: db.mustPrepare(removeProfileProgressQuery),
+	}
+}
+
+func (db *ReadingDatabase) SetProgress(ctx context.Context, p *watch.Progress) error {
+	if p.Updated.IsZero() {
+		p.Updated = time.Now()
+	}
+
+	if _, err := db.setStmt.ExecContext(ctx, p.Profile, p.SeriesID, p.Volume,
+		p.State, p.Page, p.Updated); err != nil {
+		return fmt.Errorf("unable to set reading progress: %w", err)
+	}
+
+	return nil
+}
+
+func (db *ReadingDatabase) RemoveProgress(ctx context.Context, profile int64, series, volume int) error {
+	if _, err := db.removeStmt.ExecContext(ctx, profile, series, volume); err != nil {
+		return fmt.Errorf("unable to remove reading progress: %w", err)
+	}
+
+	return nil
+}
+
+func (db *ReadingDatabase) Progress(ctx context.Context, profile int64) ([]*watch.Progress, error) {
+	rows, err := db.listStmt.QueryContext(ctx, profile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query reading progress: %w", err)
+	}
+	defer rows.Close()
+
+	progress := make([]*watch.Progress, 0)
+	for rows.Next() {
+		p := &watch.Progress{}
+		if err := rows.Scan(&p.Profile, &p.SeriesID, &p.Volume, &p.State, &p.Page,
+			&p.Updated); err != nil {
+			return nil, fmt.Errorf("unable to scan reading progress: %w", err)
+		}
+		progress = append(progress, p)
+	}
+
+	return progress, rows.Err()
+}
+
+func (db *ReadingDatabase) RemoveProfile(ctx context.Context, profile int64) error {
+	if _, err := db.profileStmt.ExecContext(ctx, profile); err != nil {
+		return fmt.Errorf("unable to remove reading progress: %w", err)
+	}
+
+	return nil
+}
+
+const setProgressQuery = `
+REPLACE INTO reading (
+	profile_id,
+	series_id,
+	volume,
+	state,
+	page,
+	updated
+) VALUES (
+	?,
+	?,
+	?,
+	?,
+	?,
+	?
+);
+`
+
+const removeProgressQuery = `
+DELETE FROM reading
+WHERE
+	profile_id=? AND series_id=? AND volume=?;
+`
+
+const listProgressQuery = `
+SELECT
+	profile_id,
+	series_id,
+	volume,
+	state,
+	page,
+	updated
+FROM reading
+WHERE
+	profile_id=?
+ORDER BY series_id, volume;
+`
+
+const removeProfileProgressQuery = `
+DELETE FROM reading
+WHERE
+	profile_id=?;
+`
@@ -0,0 +1,104 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"tracker/internal/types/user"
+)
+
+type SessionsDatabase struct {
+	db *Database
+
+	recordStmt *sql.Stmt
+	listStmt   *sql.Stmt
+	removeStmt *sql.Stmt
+}
+
+func (db *Database) Sessions() *SessionsDatabase {
+	return &SessionsDatabase{
+		db: db,
+
+		recordStmt: db.mustPrepare(recordSessionQuery),
+		listStmt:   db.mustPrepare(listSessionsQuery),
+		removeStmt: db.mustPrepare(removeUserSessionsQuery),
+	}
+}
+
+func (db *SessionsDatabase) Record(ctx context.Context, s *user.Session) error {
+	if s.Created.IsZero() {
+		s.Created = time.Now()
+	}
+
+	if _, err := db.recordStmt.ExecContext(ctx, s.ID, s.Email, s.Created,
+		s.UserAgent, s.Address); err != nil {
+		return fmt.Errorf("unable to record session: %w", err)
+	}
+
+	return nil
+}
+
+func (db *SessionsDatabase) List(ctx context.Context, email string) ([]*user.Session, error) {
+	rows, err := db.listStmt.QueryContext(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := make([]*user.Session, 0)
+	for rows.Next() {
+		s := &user.Session{}
+		if err := rows.Scan(&s.ID, &s.Email, &s.Created, &s.UserAgent,
+			&s.Address); err != nil {
+			return nil, fmt.Errorf("unable to scan session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, rows.Err()
+}
+
+func (db *SessionsDatabase) RemoveUser(ctx context.Context, email string) error {
+	if _, err := db.removeStmt.ExecContext(ctx, email); err != nil {
+		return fmt.Errorf("unable to remove sessions: %w", err)
+	}
+
+	return nil
+}
+
+const recordSessionQuery = `
+INSERT INTO sessions (
+	id,
+	email,
+	created,
+	user_agent,
+	address
+) VALUES (
+	?,
+	?,
+	?,
+	?,
+	?
+);
+`
+
+const listSessionsQuery = `
+SELECT
+	id,
+	email,
+	created,
+	user_agent,
+	address
+FROM sessions
+WHERE
+	email=?
+ORDER BY created DESC;
+`
+
+const removeUserSessionsQuery = `
+DELETE FROM sessions
+WHERE
+	email=?;
+`
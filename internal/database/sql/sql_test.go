@@ -11,4 +11,49 @@ func TestImplements(t *testing.T) {
 	if _, ok := i.(database.UsersDatabase); !ok {
 		t.Errorf("UserDatabase doesn't implement database.UserDatabase")
 	}
+
+	i = &FollowsDatabase{}
+	if _, ok := i.(database.FollowsDatabase); !ok {
+		t.Errorf("FollowsDatabase doesn't implement database.FollowsDatabase")
+	}
+
+	i = &ActivityDatabase{}
+	if _, ok := i.(database.ActivityDatabase); !ok {
+		t.Errorf("ActivityDatabase doesn't implement database.ActivityDatabase")
+	}
+
+	i = &ProfilesDatabase{}
+	if _, ok := i.(database.ProfilesDatabase); !ok {
+		t.Errorf("ProfilesDatabase doesn't implement database.ProfilesDatabase")
+	}
+
+	i = &WatchDatabase{}
+	if _, ok := i.(database.WatchDatabase); !ok {
+		t.Errorf("WatchDatabase doesn't implement database.WatchDatabase")
+	}
+
+	i = &ReadingDatabase{}
+	if _, ok := i.(database.ReadingDatabase); !ok {
+		t.Errorf("ReadingDatabase doesn't implement database.ReadingDatabase")
+	}
+
+	i = &BacklogDatabase{}
+	if _, ok := i.(database.BacklogDatabase); !ok {
+		t.Errorf("BacklogDatabase doesn't implement database.BacklogDatabase")
+	}
+
+	i = &ListeningDatabase{}
+	if _, ok := i.(database.ListeningDatabase); !ok {
+		t.Errorf("ListeningDatabase doesn't implement database.ListeningDatabase")
+	}
+
+	i = &ImportQueueDatabase{}
+	if _, ok := i.(database.ImportQueueDatabase); !ok {
+		t.Errorf("ImportQueueDatabase doesn't implement database.ImportQueueDatabase")
+	}
+
+	i = &SessionsDatabase{}
+	if _, ok := i.(database.SessionsDatabase); !ok {
+		t.Errorf("SessionsDatabase doesn't implement database.SessionsDatabase")
+	}
 }
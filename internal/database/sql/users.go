@@ -16,6 +16,9 @@ type UsersDatabase struct {
 
 	getUserStmt    *sql.Stmt
 	insertUserStmt *sql.Stmt
+	privacyStmt    *sql.Stmt
+	listStmt       *sql.Stmt
+	deleteStmt     *sql.Stmt
 }
 
 func (db *Database) Users() *UsersDatabase {
@@ -27,17 +30,28 @@ func (db *Database) Users() *UsersDatabase {
 	if err != nil {
 		panic(fmt.Sprintf("unable to prepare query to insert user: %v", err))
 	}
+	privacyStmt, err := db.db.Prepare(updatePrivacyQuery)
+	if err != nil {
+		panic(fmt.Sprintf("unable to prepare query to update privacy: %v", err))
+	}
 
 	return &UsersDatabase{
 		db: db,
 
 		getUserStmt:    getUserStmt,
 		insertUserStmt: insertUserStmt,
+		privacyStmt:    privacyStmt,
+		listStmt:       db.mustPrepare(listUsersQuery),
+		deleteStmt:     db.mustPrepare(deleteUserQuery),
 	}
 }
 
 func (db *UsersDatabase) Create(ctx context.Context, u *user.User) error {
-	if _, err := db.insertUserStmt.ExecContext(ctx, u.Name, u.Email); err != nil {
+	privacy := u.Privacy
+	if privacy == "" {
+		privacy = user.PrivacyFriends
+	}
+	if _, err := db.insertUserStmt.ExecContext(ctx, u.Name, u.Email, privacy); err != nil {
 		return fmt.Errorf("unable to insert user: %w", err)
 	}
 
@@ -49,6 +63,7 @@ func (db *UsersDatabase) Details(ctx context.Context, email string) (*user.User,
 	if err := db.getUserStmt.QueryRowContext(ctx, email).Scan(
 		&u.Name,
 		&u.Email,
+		&u.Privacy,
 	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, database.ErrNotFound
@@ -59,12 +74,52 @@ func (db *UsersDatabase) Details(ctx context.Context, email string) (*user.User,
 	return u, nil
 }
 
+func (db *UsersDatabase) SetPrivacy(ctx context.Context, email string, privacy user.Privacy) error {
+	res, err := db.privacyStmt.ExecContext(ctx, privacy, email)
+	if err != nil {
+		return fmt.Errorf("unable to update privacy: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
+
+func (db *UsersDatabase) List(ctx context.Context) ([]*user.User, error) {
+	rows, err := db.listStmt.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]*user.User, 0)
+	for rows.Next() {
+		u := &user.User{}
+		if err := rows.Scan(&u.Name, &u.Email, &u.Privacy); err != nil {
+			return nil, fmt.Errorf("unable to scan user: %w", err)
+		}
+		users = append(users, u)
+	}
+
+	return users, rows.Err()
+}
+
+func (db *UsersDatabase) Delete(ctx context.Context, email string) error {
+	if _, err := db.deleteStmt.ExecContext(ctx, email); err != nil {
+		return fmt.Errorf("unable to delete user: %w", err)
+	}
+
+	return nil
+}
+
 // Username is actually user's name.
 // TODO: Update the schema to reflect that.
 const getUserQuery = `
 SELECT
 	username,
 	email,
+	privacy
 FROM users
 WHERE
 	email=?
@@ -75,8 +130,33 @@ const insertUserQuery = `
 INSERT INTO users (
 	username,
 	email,
+	privacy
 ) VALUES (
+	?,
 	?,
 	?
 );
 `
+
+const updatePrivacyQuery = `
+UPDATE users
+SET
+	privacy=?
+WHERE
+	email=?;
+`
+
+const listUsersQuery = `
+SELECT
+	username,
+	email,
+	privacy
+FROM users
+ORDER BY id;
+`
+
+const deleteUserQuery = `
+DELETE FROM users
+WHERE
+	email=?;
+`
@@ -0,0 +1,202 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"tracker/internal/types/watch"
+)
+
+type WatchDatabase struct {
+	db *Database
+
+	markStmt    *sql.Stmt
+	unmarkStmt  *sql.Stmt
+	watchedStmt *sql.Stmt
+	rateStmt    *sql.Stmt
+	ratingsStmt *sql.Stmt
+
+	removeWatchedStmt *sql.Stmt
+	removeRatingsStmt *sql.Stmt
+}
+
+func (db *Database) Watch() *WatchDatabase {
+	return &WatchDatabase{
+		db: db,
+
+		markStmt:    db.mustPrepare(markWatchedQuery),
+		unmarkStmt:  db.mustPrepare(unmarkWatchedQuery),
+		watchedStmt: db.mustPrepare(listWatchedQuery),
+		rateStmt:    db.mustPrepare(rateQuery),
+		ratingsStmt: db.mustPrepare(listRatingsQuery),
+
+		removeWatchedStmt: db.mustPrepare(removeProfileWatchedQuery),
+		removeRatingsStmt: db.mustPrepare(removeProfileRatingsQuery),
+	}
+}
+
+func (db *WatchDatabase) MarkWatched(ctx context.Context, e *watch.Episode) error {
+	if e.Watched.IsZero() {
+		e.Watched = time.Now()
+	}
+
+	if _, err := db.markStmt.ExecContext(ctx, e.Profile, e.ShowID, e.Season,
+		e.Episode, e.Watched); err != nil {
+		return fmt.Errorf("unable to mark episode as watched: %w", err)
+	}
+
+	return nil
+}
+
+func (db *WatchDatabase) UnmarkWatched(ctx context.Context, e *watch.Episode) error {
+	if _, err := db.unmarkStmt.ExecContext(ctx, e.Profile, e.ShowID, e.Season,
+		e.Episode); err != nil {
+		return fmt.Errorf("unable to unmark episode: %w", err)
+	}
+
+	return nil
+}
+
+func (db *WatchDatabase) Watched(ctx context.Context, profile int64) ([]*watch.Episode, error) {
+	rows, err := db.watchedStmt.QueryContext(ctx, profile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query watched episodes: %w", err)
+	}
+	defer rows.Close()
+
+	episodes := make([]*watch.Episode, 0)
+	for rows.Next() {
+		e := &watch.Episode{}
+		if err := rows.Scan(&e.Profile, &e.ShowID, &e.Season, &e.Episode,
+			&e.Watched); err != nil {
+			return nil, fmt.Errorf("unable to scan watched episode: %w", err)
+		}
+		episodes = append(episodes, e)
+	}
+
+	return episodes, rows.Err()
+}
+
+func (db *WatchDatabase) Rate(ctx context.Context, r *watch.Rating) error {
+	if r.Rated.IsZero() {
+		r.Rated = time.Now()
+	}
+
+	if _, err := db.rateStmt.ExecContext(ctx, r.Profile, r.ShowID, r.Rating,
+		r.Rated); err != nil {
+		return fmt.Errorf("unable to rate show: %w", err)
+	}
+
+	return nil
+}
+
+func (db *WatchDatabase) Ratings(ctx context.Context, profile int64) ([]*watch.Rating, error) {
+	rows, err := db.ratingsStmt.QueryContext(ctx, profile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query ratings: %w", err)
+	}
+	defer rows.Close()
+
+	ratings := make([]*watch.Rating, 0)
+	for rows.Next() {
+		r := &watch.Rating{}
+		if err := rows.Scan(&r.Profile, &r.ShowID, &r.Rating, &r.Rated); err != nil {
+			return nil, fmt.Errorf("unable to scan rating: %w", err)
+		}
+		ratings = append(ratings, r)
+	}
+
+	return ratings, rows.Err()
+}
+
+func (db *WatchDatabase) RemoveProfile(ctx context.Context, profile int64) error {
+	tx, err := db.db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.StmtContext(ctx, db.removeWatchedStmt).ExecContext(ctx, profile); err != nil {
+		return fmt.Errorf("unable to remove watched episodes: %w", err)
+	}
+	if _, err := tx.StmtContext(ctx, db.removeRatingsStmt).ExecContext(ctx, profile); err != nil {
+		return fmt.Errorf("unable to remove ratings: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+const markWatchedQuery = `
+REPLACE INTO watched (
+	profile_id,
+	show_id,
+	season,
+	episode,
+	watched
+) VALUES (
+	?,
+	?,
+	?,
+	?,
+	?
+);
+`
+
+const unmarkWatchedQuery = `
+DELETE FROM watched
+WHERE
+	profile_id=? AND show_id=? AND season=? AND episode=?;
+`
+
+const listWatchedQuery = `
+SELECT
+	profile_id,
+	show_id,
+	season,
+	episode,
+	watched
+FROM watched
+WHERE
+	profile_id=?
+ORDER BY show_id, season, episode;
+`
+
+const rateQuery = `
+REPLACE INTO ratings (
+	profile_id,
+	show_id,
+	rating,
+	rated
+) VALUES (
+	?,
+	?,
+	?,
+	?
+);
+`
+
+const listRatingsQuery = `
+SELECT
+	profile_id,
+	show_id,
+	rating,
+	rated
+FROM ratings
+WHERE
+	profile_id=?
+ORDER BY show_id;
+`
+
+const removeProfileWatchedQuery = `
+DELETE FROM watched
+WHERE
+	profile_id=?;
+`
+
+const removeProfileRatingsQuery = `
+DELETE FROM ratings
+WHERE
+	profile_id=?;
+`
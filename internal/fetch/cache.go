@@ -0,0 +1,120 @@
+package fetch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultMaxAge is how long cached pages are revalidated before they are
+// fetched in full again. Fetching in full now and then means a page is parsed
+// again even if a previous parse of it was lost.
+const DefaultMaxAge = 7 * 24 * time.Hour
+
+// DiskCache stores fetched pages on disk, along with the validators used to
+// revalidate them with conditional requests.
+type DiskCache struct {
+	dir    string
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+// entry is the metadata of a cached page. Its body is stored next to it.
+type entry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Stored       time.Time `json:"stored"`
+}
+
+// NewDiskCache creates a cache in the directory, creating it if needed.
+// Entries older than maxAge are ignored, or never if maxAge is zero.
+func NewDiskCache(dir string, maxAge time.Duration) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("fetch: unable to create cache: %w", err)
+	}
+	return &DiskCache{dir: dir, maxAge: maxAge, now: time.Now}, nil
+}
+
+func (c *DiskCache) path(url, ext string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+ext)
+}
+
+// get returns the cached page of the url, if it is cached and fresh enough.
+func (c *DiskCache) get(url string) (*entry, []byte, bool) {
+	data, err := ioutil.ReadFile(c.path(url, ".json"))
+	if err != nil {
+		return nil, nil, false
+	}
+	e := &entry{}
+	if err := json.Unmarshal(data, e); err != nil || e.URL != url {
+		return nil, nil, false
+	}
+	if c.maxAge > 0 && c.now().Sub(e.Stored) > c.maxAge {
+		return nil, nil, false
+	}
+	if e.ETag == "" && e.LastModified == "" {
+		return nil, nil, false
+	}
+
+	body, err := ioutil.ReadFile(c.path(url, ".body"))
+	if err != nil {
+		return nil, nil, false
+	}
+	return e, body, true
+}
+
+// put stores the page. Pages without validators aren't stored, as they can't
+// be revalidated.
+func (c *DiskCache) put(e *entry, body []byte) error {
+	if e.ETag == "" && e.LastModified == "" {
+		return c.remove(e.URL)
+	}
+
+	e.Stored = c.now()
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	// The body is written first, so the metadata never points at a body
+	// which is incomplete.
+	if err := writeFile(c.path(e.URL, ".body"), body); err != nil {
+		return err
+	}
+	return writeFile(c.path(e.URL, ".json"), data)
+}
+
+// remove forgets the page of the url.
+func (c *DiskCache) remove(url string) error {
+	for _, ext := range []string{".json", ".body"} {
+		if err := os.Remove(c.path(url, ext)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFile replaces the file atomically.
+func writeFile(name string, data []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
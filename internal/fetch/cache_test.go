@@ -0,0 +1,93 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetchCached(t *testing.T) {
+	var version, full, notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := fmt.Sprintf(`"v%d"`, version)
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full++
+		w.Header().Set("ETag", etag)
+		fmt.Fprintf(w, "version %d", version)
+	}))
+	defer srv.Close()
+
+	cache, err := NewDiskCache(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("NewDiskCache() err = %v, want %v", err, nil)
+	}
+	now := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+	c := New(Cache(cache), IgnoreRobots(), RateLimit(0, 0))
+
+	fetch := func(wantBody string, wantUnchanged bool) {
+		t.Helper()
+		p, err := c.Fetch(context.Background(), srv.URL)
+		if err != nil {
+			t.Fatalf("Fetch() err = %v, want %v", err, nil)
+		}
+		if string(p.Body) != wantBody || p.Unchanged != wantUnchanged {
+			t.Errorf("Fetch() = %q, unchanged %t, want %q, unchanged %t",
+				p.Body, p.Unchanged, wantBody, wantUnchanged)
+		}
+	}
+
+	fetch("version 0", false)
+	fetch("version 0", true)
+
+	version = 1
+	fetch("version 1", false)
+	fetch("version 1", true)
+
+	// Invalidated pages are fetched in full again.
+	if err := c.Invalidate(srv.URL); err != nil {
+		t.Fatalf("Invalidate() err = %v, want %v", err, nil)
+	}
+	fetch("version 1", false)
+
+	// So are pages which have been cached for too long.
+	now = now.Add(2 * time.Hour)
+	fetch("version 1", false)
+
+	if full != 4 || notModified != 2 {
+		t.Errorf("full = %d, not modified = %d, want %d and %d", full, notModified, 4, 2)
+	}
+}
+
+func TestFetchLastModified(t *testing.T) {
+	modified := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "page.html", modified, strings.NewReader("page"))
+	}))
+	defer srv.Close()
+
+	cache, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewDiskCache() err = %v, want %v", err, nil)
+	}
+	c := New(Cache(cache), IgnoreRobots(), RateLimit(0, 0))
+
+	for i, want := range []bool{false, true} {
+		p, err := c.Fetch(context.Background(), srv.URL)
+		if err != nil {
+			t.Fatalf("Fetch() #%d err = %v, want %v", i, err, nil)
+		}
+		if p.Unchanged != want || string(p.Body) != "page" {
+			t.Errorf("Fetch() #%d = %q, unchanged %t, want %q, unchanged %t",
+				i, p.Body, p.Unchanged, "page", want)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+// Package fetch downloads the pages trackables are scraped from. Requests
+// identify themselves with a User-Agent, respect robots.txt and are rate
+// limited per host, so that scraping in parallel stays polite.
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// ErrDisallowed is returned for pages robots.txt doesn't allow us to fetch.
+const ErrDisallowed = Error("fetch: disallowed by robots.txt")
+
+// StatusError is returned when a page is answered with an unexpected status.
+type StatusError struct {
+	URL  string
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("fetch: %s returned %d %s", e.URL, e.Code, http.StatusText(e.Code))
+}
+
+// DefaultUserAgent identifies the scraper unless configured otherwise.
+const DefaultUserAgent = "tracker-scraper/1.0"
+
+// Client fetches pages politely.
+type Client struct {
+	httpClient *http.Client
+	userAgent  string
+	rate       float64
+	burst      int
+	robots     bool
+	apiPaths   []string
+	cache      *DiskCache
+	record     *Recordings
+	replay     *Recordings
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	rules   map[string]*robotsRules
+}
+
+// New creates a client with default values, which can be overridden using
+// the options.
+func New(opts ...Option) *Client {
+	c := &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		userAgent:  DefaultUserAgent,
+		rate:       1,
+		burst:      1,
+		robots:     true,
+		buckets:    map[string]*bucket{},
+		rules:      map[string]*robotsRules{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Page is a fetched page.
+type Page struct {
+	URL  string
+	Body []byte
+
+	// Unchanged is true if the page is the same as when it was last fetched,
+	// according to the server.
+	Unchanged bool
+}
+
+// Get fetches the page at the url, waiting for the rate limit of its host.
+func (c *Client) Get(ctx context.Context, rawURL string) ([]byte, error) {
+	p, err := c.Fetch(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return p.Body, nil
+}
+
+// Fetch fetches the page at the url, waiting for the rate limit of its host.
+// Pages in the cache are revalidated with a conditional request, and only
+// downloaded again if they changed. Clients replaying recordings serve the
+// recorded pages instead, without any request.
+func (c *Client) Fetch(ctx context.Context, rawURL string) (*Page, error) {
+	if c.replay != nil {
+		return c.replay.Load(rawURL)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetch: invalid url %q: %w", rawURL, err)
+	}
+
+	if c.robots && !c.isAPI(u.Path) {
+		rules, err := c.robotsRules(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+		if !rules.Allowed(u.RequestURI()) {
+			return nil, fmt.Errorf("%w: %s", ErrDisallowed, rawURL)
+		}
+	}
+
+	var (
+		cached *entry
+		body   []byte
+		header = http.Header{}
+	)
+	if c.cache != nil {
+		var ok bool
+		if cached, body, ok = c.cache.get(rawURL); ok {
+			if cached.ETag != "" {
+				header.Set("If-None-Match", cached.ETag)
+			}
+			if cached.LastModified != "" {
+				header.Set("If-Modified-Since", cached.LastModified)
+			}
+		}
+	}
+
+	resp, err := c.do(ctx, u, header)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		if err := c.recordPage(rawURL, http.StatusOK, resp.Header, body); err != nil {
+			return nil, err
+		}
+		return &Page{URL: rawURL, Body: body, Unchanged: true}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		if err := c.recordPage(rawURL, resp.StatusCode, resp.Header, nil); err != nil {
+			return nil, err
+		}
+		return nil, &StatusError{URL: rawURL, Code: resp.StatusCode}
+	}
+
+	if body, err = ioutil.ReadAll(resp.Body); err != nil {
+		return nil, err
+	}
+	if err := c.recordPage(rawURL, resp.StatusCode, resp.Header, body); err != nil {
+		return nil, err
+	}
+	if c.cache != nil {
+		e := &entry{
+			URL:          rawURL,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		}
+		if err := c.cache.put(e, body); err != nil {
+			return nil, fmt.Errorf("fetch: unable to cache %s: %w", rawURL, err)
+		}
+	}
+	return &Page{URL: rawURL, Body: body}, nil
+}
+
+// Invalidate forgets the cached page of the url, so that it is considered
+// changed the next time it is fetched. It is used when a page couldn't be
+// parsed or persisted.
+func (c *Client) Invalidate(url string) error {
+	if c.cache == nil {
+		return nil
+	}
+	return c.cache.remove(url)
+}
+
+// recordPage saves the page if the client records them.
+func (c *Client) recordPage(rawURL string, status int, header http.Header, body []byte) error {
+	if c.record == nil {
+		return nil
+	}
+	if err := c.record.Save(rawURL, status, header.Get("Content-Type"), body); err != nil {
+		return fmt.Errorf("fetch: unable to record %s: %w", rawURL, err)
+	}
+	return nil
+}
+
+// isAPI returns true if the path is below one of the API paths.
+func (c *Client) isAPI(path string) bool {
+	for _, prefix := range c.apiPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// do sends a GET request for the url once its host allows it.
+func (c *Client) do(ctx context.Context, u *url.URL, header http.Header) (*http.Response, error) {
+	if err := c.bucket(u.Host).Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	return c.httpClient.Do(req)
+}
+
+// bucket returns the rate limit of the host.
+func (c *Client) bucket(host string) *bucket {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.buckets[host]
+	if !ok {
+		b = newBucket(c.rate, c.burst)
+		c.buckets[host] = b
+	}
+	return b
+}
+
+var (
+	defaultMu sync.RWMutex
+	// defaultClient is used by Get, and shared by every kind of trackable
+	// so that the rate limits apply across kinds.
+	defaultClient = New()
+)
+
+// SetDefault replaces the client used by Get.
+func SetDefault(c *Client) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultClient = c
+}
+
+// Default returns the client used by Get.
+func Default() *Client {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultClient
+}
+
+// Get fetches the page at the url using the default client.
+func Get(ctx context.Context, url string) ([]byte, error) {
+	return Default().Get(ctx, url)
+}
+
+// Fetch fetches the page at the url using the default client.
+func Fetch(ctx context.Context, url string) (*Page, error) {
+	return Default().Fetch(ctx, url)
+}
+
+// Invalidate forgets the cached page of the url in the default client.
+func Invalidate(url string) error {
+	return Default().Invalidate(url)
+}
+
+// Option allows to modify the client.
+type Option func(*Client)
+
+// UserAgent sets the User-Agent sent with every request. It is also the name
+// matched against the groups of robots.txt.
+func UserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// RateLimit allows rate requests per second to each host, with bursts of up
+// to burst requests. A rate of zero disables the limit.
+func RateLimit(rate float64, burst int) Option {
+	return func(c *Client) {
+		c.rate = rate
+		c.burst = burst
+	}
+}
+
+// Timeout sets the time limit of each request.
+func Timeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = d
+	}
+}
+
+// Cache stores fetched pages in the cache, and revalidates them with
+// conditional requests.
+func Cache(cache *DiskCache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// APIPaths exempts the paths starting with any of the prefixes from
+// robots.txt. Sites such as Wikipedia keep crawlers out of their API, while
+// inviting programs to use it instead of the rendered pages. Requests to
+// them are still rate limited.
+func APIPaths(prefixes ...string) Option {
+	return func(c *Client) {
+		c.apiPaths = append(c.apiPaths, prefixes...)
+	}
+}
+
+// IgnoreRobots disables checking robots.txt, for hosts we own.
+func IgnoreRobots() Option {
+	return func(c *Client) {
+		c.robots = false
+	}
+}
+
+// Record saves every page fetched to the recordings, along with pages
+// answered with an error status, so that the scrape can be replayed.
+func Record(r *Recordings) Option {
+	return func(c *Client) {
+		c.record = r
+	}
+}
+
+// Replay serves the pages from the recordings instead of fetching them.
+// Pages which weren't recorded fail with ErrNotRecorded.
+func Replay(r *Recordings) Option {
+	return func(c *Client) {
+		c.replay = r
+	}
+}
@@ -0,0 +1,90 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func testServer(t *testing.T) (*httptest.Server, *[]string) {
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, fmt.Sprintf("%s %s", r.URL.Path, r.UserAgent()))
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/robots.txt":
+			fmt.Fprint(w, "User-agent: *\nDisallow: /private\n")
+		case "/missing":
+			http.NotFound(w, r)
+		default:
+			fmt.Fprintf(w, "page %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestGet(t *testing.T) {
+	srv, requests := testServer(t)
+	c := New(UserAgent("test-agent/1.0"), RateLimit(0, 0))
+
+	body, err := c.Get(context.Background(), srv.URL+"/wiki/Page")
+	if err != nil {
+		t.Fatalf("Get() err = %v, want %v", err, nil)
+	}
+	if got, want := string(body), "page /wiki/Page"; got != want {
+		t.Errorf("Get() = %q, want %q", got, want)
+	}
+
+	if _, err := c.Get(context.Background(), srv.URL+"/private/page"); !errors.Is(err, ErrDisallowed) {
+		t.Errorf("Get(disallowed) err = %v, want %v", err, ErrDisallowed)
+	}
+
+	var statusErr *StatusError
+	if _, err := c.Get(context.Background(), srv.URL+"/missing"); !errors.As(err, &statusErr) ||
+		statusErr.Code != http.StatusNotFound {
+		t.Errorf("Get(missing) err = %v, want a %d status", err, http.StatusNotFound)
+	}
+
+	// robots.txt is only fetched once, and every request identifies itself.
+	want := []string{
+		"/robots.txt test-agent/1.0",
+		"/wiki/Page test-agent/1.0",
+		"/missing test-agent/1.0",
+	}
+	if got := *requests; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("requests = %v, want %v", got, want)
+	}
+}
+
+func TestGetCancelled(t *testing.T) {
+	srv, _ := testServer(t)
+	c := New(IgnoreRobots())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Get(ctx, srv.URL+"/wiki/Page"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Get() err = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestGetAPIPaths(t *testing.T) {
+	srv, _ := testServer(t)
+	c := New(RateLimit(0, 0), APIPaths("/private/api"))
+
+	if _, err := c.Get(context.Background(), srv.URL+"/private/api?action=query"); err != nil {
+		t.Errorf("Get(api) err = %v, want %v", err, nil)
+	}
+	if _, err := c.Get(context.Background(), srv.URL+"/private/page"); !errors.Is(err, ErrDisallowed) {
+		t.Errorf("Get(disallowed) err = %v, want %v", err, ErrDisallowed)
+	}
+}
@@ -0,0 +1,42 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBucketReserve(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	b := newBucket(2, 2)
+	b.now = func() time.Time { return now }
+
+	// The bucket starts full, allowing a burst.
+	for i := 0; i < 2; i++ {
+		if wait := b.reserve(); wait != 0 {
+			t.Fatalf("reserve() #%d = %v, want %v", i, wait, 0)
+		}
+	}
+	if wait := b.reserve(); wait != 500*time.Millisecond {
+		t.Errorf("reserve() on empty bucket = %v, want %v", wait, 500*time.Millisecond)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if wait := b.reserve(); wait != 0 {
+		t.Errorf("reserve() after refill = %v, want %v", wait, 0)
+	}
+}
+
+func TestBucketWaitCancelled(t *testing.T) {
+	b := newBucket(0.001, 1)
+	if err := b.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() err = %v, want %v", err, nil)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := b.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() err = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
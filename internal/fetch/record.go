@@ -0,0 +1,108 @@
+package fetch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrNotRecorded is returned when replaying a page which wasn't recorded.
+const ErrNotRecorded = Error("fetch: page not recorded")
+
+// Recordings are pages saved with their metadata, so that scrapes can be
+// replayed offline. Each page is stored as a JSON file of its metadata and a
+// file of its body, named after its URL so that they can be told apart.
+type Recordings struct {
+	dir string
+	now func() time.Time
+}
+
+// recording is the metadata of a recorded page. Its body is stored next to
+// it.
+type recording struct {
+	URL         string    `json:"url"`
+	Status      int       `json:"status"`
+	ContentType string    `json:"content_type,omitempty"`
+	Recorded    time.Time `json:"recorded"`
+}
+
+// NewRecordings uses the directory for recordings, creating it if needed.
+func NewRecordings(dir string) (*Recordings, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("fetch: unable to create recordings: %w", err)
+	}
+	return &Recordings{dir: dir, now: time.Now}, nil
+}
+
+// unsafeRegexp matches the runs of characters kept out of file names.
+var unsafeRegexp = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// path returns the file of the recording of the url. The name is the url for
+// people, keeping both ends of long ones where pages usually differ, and ends
+// with its hash so that long or similar urls don't collide.
+func (r *Recordings) path(url, ext string) string {
+	name := url
+	if i := strings.Index(name, "://"); i >= 0 {
+		name = name[i+3:]
+	}
+	name = strings.Trim(unsafeRegexp.ReplaceAllString(name, "_"), "_.")
+	if len(name) > 80 {
+		name = name[:40] + "_" + name[len(name)-40:]
+	}
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(r.dir, name+"-"+hex.EncodeToString(sum[:4])+ext)
+}
+
+// Save records the page of the url, as answered with the status.
+func (r *Recordings) Save(url string, status int, contentType string, body []byte) error {
+	data, err := json.MarshalIndent(&recording{
+		URL:         url,
+		Status:      status,
+		ContentType: contentType,
+		Recorded:    r.now().UTC().Truncate(time.Second),
+	}, "", " ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeFile(r.path(url, ".body"), body); err != nil {
+		return err
+	}
+	return writeFile(r.path(url, ".json"), append(data, '\n'))
+}
+
+// Load returns the recorded page of the url. Pages recorded with a status
+// other than 200 OK are returned as a StatusError, as when they were
+// recorded.
+func (r *Recordings) Load(url string) (*Page, error) {
+	data, err := ioutil.ReadFile(r.path(url, ".json"))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrNotRecorded, url)
+	} else if err != nil {
+		return nil, err
+	}
+	rec := &recording{}
+	if err := json.Unmarshal(data, rec); err != nil {
+		return nil, fmt.Errorf("fetch: invalid recording of %s: %w", url, err)
+	}
+	if rec.URL != url {
+		return nil, fmt.Errorf("%w: %s", ErrNotRecorded, url)
+	}
+	if rec.Status != http.StatusOK {
+		return nil, &StatusError{URL: url, Code: rec.Status}
+	}
+
+	body, err := ioutil.ReadFile(r.path(url, ".body"))
+	if err != nil {
+		return nil, fmt.Errorf("fetch: incomplete recording of %s: %w", url, err)
+	}
+	return &Page{URL: url, Body: body}, nil
+}
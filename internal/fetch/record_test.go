@@ -0,0 +1,66 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("page " + r.URL.RequestURI()))
+	}))
+
+	recordings, err := NewRecordings(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewRecordings() err = %v, want %v", err, nil)
+	}
+	recorder := New(Record(recordings), IgnoreRobots(), RateLimit(0, 0))
+	ctx := context.Background()
+	for _, path := range []string{"/a", "/a?b=c", "/missing"} {
+		recorder.Fetch(ctx, srv.URL+path)
+	}
+	// Nothing is fetched from the server while replaying.
+	srv.Close()
+
+	c := New(Replay(recordings))
+	testCases := map[string]struct {
+		path     string
+		wantBody string
+		wantErr  error
+	}{
+		"page":         {path: "/a", wantBody: "page /a"},
+		"query":        {path: "/a?b=c", wantBody: "page /a?b=c"},
+		"status":       {path: "/missing", wantErr: &StatusError{URL: srv.URL + "/missing", Code: http.StatusNotFound}},
+		"not recorded": {path: "/b", wantErr: ErrNotRecorded},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p, err := c.Fetch(ctx, srv.URL+tc.path)
+			var status *StatusError
+			switch {
+			case errors.As(tc.wantErr, &status):
+				var got *StatusError
+				if !errors.As(err, &got) || *got != *status {
+					t.Fatalf("Fetch() err = %v, want %v", err, tc.wantErr)
+				}
+				return
+			case !errors.Is(err, tc.wantErr):
+				t.Fatalf("Fetch() err = %v, want %v", err, tc.wantErr)
+			case err != nil:
+				return
+			}
+			if string(p.Body) != tc.wantBody {
+				t.Errorf("Fetch() = %q, want %q", p.Body, tc.wantBody)
+			}
+		})
+	}
+}
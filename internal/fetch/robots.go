@@ -0,0 +1,156 @@
+package fetch
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// robotsRules are the rules of robots.txt which apply to our User-Agent.
+type robotsRules struct {
+	rules []robotsRule
+}
+
+type robotsRule struct {
+	allow   bool
+	length  int
+	pattern *regexp.Regexp
+}
+
+// Allowed returns true if the path may be fetched. The longest matching rule
+// wins, and allow wins ties.
+func (r *robotsRules) Allowed(path string) bool {
+	allowed, length := true, -1
+	for _, rule := range r.rules {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		if rule.length > length || (rule.length == length && rule.allow) {
+			allowed, length = rule.allow, rule.length
+		}
+	}
+	return allowed
+}
+
+// robotsRules returns the rules of the host of the url, fetching robots.txt
+// the first time the host is seen.
+func (c *Client) robotsRules(ctx context.Context, u *url.URL) (*robotsRules, error) {
+	key := u.Scheme + "://" + u.Host
+
+	c.mu.Lock()
+	rules, ok := c.rules[key]
+	c.mu.Unlock()
+	if ok {
+		return rules, nil
+	}
+
+	robotsURL := &url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/robots.txt"}
+	resp, err := c.do(ctx, robotsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch: unable to get robots.txt of %s: %w", u.Host, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		rules = parseRobots(resp.Body, c.userAgent)
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		// No robots.txt means everything is allowed.
+		rules = &robotsRules{}
+	default:
+		return nil, fmt.Errorf("fetch: unable to get robots.txt of %s: %w", u.Host,
+			&StatusError{URL: robotsURL.String(), Code: resp.StatusCode})
+	}
+
+	c.mu.Lock()
+	c.rules[key] = rules
+	c.mu.Unlock()
+	return rules, nil
+}
+
+// parseRobots parses the rules of the group matching the User-Agent, or of
+// the * group if none does.
+func parseRobots(r io.Reader, userAgent string) *robotsRules {
+	name := strings.ToLower(userAgent)
+	if i := strings.IndexAny(name, "/ "); i >= 0 {
+		name = name[:i]
+	}
+
+	var (
+		matched, wildcard []robotsRule
+		agents            []string
+		inRules           bool
+		foundAgent        bool
+	)
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := s.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// A user-agent after rules starts a new group.
+			if inRules {
+				agents, inRules = nil, false
+			}
+			agent := strings.ToLower(value)
+			agents = append(agents, agent)
+			if agent != "*" && name != "" && strings.Contains(name, agent) {
+				foundAgent = true
+			}
+		case "allow", "disallow":
+			inRules = true
+			if value == "" {
+				continue
+			}
+			rule := robotsRule{
+				allow:   key == "allow",
+				length:  len(value),
+				pattern: robotsPattern(value),
+			}
+			for _, agent := range agents {
+				switch {
+				case agent == "*":
+					wildcard = append(wildcard, rule)
+				case name != "" && strings.Contains(name, agent):
+					matched = append(matched, rule)
+				}
+			}
+		}
+	}
+
+	if foundAgent {
+		return &robotsRules{rules: matched}
+	}
+	return &robotsRules{rules: wildcard}
+}
+
+// robotsPattern converts the path of a rule into a regexp, where * matches
+// anything and a trailing $ anchors the end of the path.
+func robotsPattern(path string) *regexp.Regexp {
+	anchored := strings.HasSuffix(path, "$")
+	path = strings.TrimSuffix(path, "$")
+
+	parts := strings.Split(path, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	pattern := "^" + strings.Join(parts, ".*")
+	if anchored {
+		pattern += "$"
+	}
+	return regexp.MustCompile(pattern)
+}
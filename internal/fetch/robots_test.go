@@ -0,0 +1,60 @@
+package fetch
+
+import (
+	"strings"
+	"testing"
+)
+
+const robotsTxt = `
+# Comments are ignored
+User-agent: *
+Disallow: /w/
+Disallow: /wiki/Special:
+Allow: /w/api.php$
+
+User-agent: BadBot
+User-agent: tracker-scraper
+Disallow: /private
+Allow: /private/open*.html
+
+User-agent: other
+Disallow: /
+`
+
+func TestParseRobots(t *testing.T) {
+	testCases := map[string]struct {
+		userAgent string
+		allowed   map[string]bool
+	}{
+		"wildcard group": {
+			userAgent: "SomeBrowser/1.0",
+			allowed: map[string]bool{
+				"/wiki/Main_Page":         true,
+				"/wiki/Special:Random":    false,
+				"/w/index.php":            false,
+				"/w/api.php":              true,
+				"/w/api.php?action=parse": false,
+				"/private":                true,
+			},
+		},
+		"own group": {
+			userAgent: "tracker-scraper/1.0 (admin@example.com)",
+			allowed: map[string]bool{
+				"/w/index.php":            true,
+				"/private/data":           false,
+				"/private/open-data.html": true,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rules := parseRobots(strings.NewReader(robotsTxt), tc.userAgent)
+			for path, want := range tc.allowed {
+				if got := rules.Allowed(path); got != want {
+					t.Errorf("Allowed(%q) = %t, want %t", path, got, want)
+				}
+			}
+		})
+	}
+}
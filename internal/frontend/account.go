@@ -0,0 +1,116 @@
+package frontend
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"tracker/internal/account"
+	"tracker/internal/httpserver"
+	"tracker/server/auth"
+	"tracker/web"
+)
+
+// AccountFrontend allows users to export their data and delete their account.
+type AccountFrontend struct {
+	templates *template.Template
+	log       *zap.Logger
+
+	accounts *account.Service
+}
+
+// NewAccount creates the frontend for managing the account, logging what
+// fails once the response has started to the logger.
+func NewAccount(a *account.Service, log *zap.Logger) (*AccountFrontend, error) {
+	t, err := parseTemplates(defaultFuncs(), web.Templates, defaultPattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply default template: %w", err)
+	}
+
+	return &AccountFrontend{
+		templates: t,
+		log:       log,
+		accounts:  a,
+	}, nil
+}
+
+func (f *AccountFrontend) RegisterHandlers(r *mux.Router) {
+	r.Path("/export").
+		Methods(http.MethodGet).
+		HandlerFunc(f.exportRequest)
+	r.Path("/delete").
+		Methods(http.MethodPost).
+		HandlerFunc(f.deleteRequest)
+	r.Path("/").
+		Methods(http.MethodGet).
+		HandlerFunc(f.accountRequest)
+}
+
+type AccountRequestData struct {
+	Title string
+
+	User auth.User
+}
+
+func (f *AccountFrontend) accountRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	data := AccountRequestData{
+		Title: "Show Tracker - Account",
+		User:  u,
+	}
+
+	if err := f.templates.ExecuteTemplate(w, "account.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+func (f *AccountFrontend) exportRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	archive, err := f.accounts.Export(r.Context(), u.Email)
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=\"tracker-%s.zip\"", archive.Exported.Format("2006-01-02")))
+	if err := account.WriteArchive(w, archive); err != nil {
+		f.log.Error("unable to write archive", zap.String("email", u.Email), zap.Error(err))
+	}
+}
+
+func (f *AccountFrontend) deleteRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	// Deleting is permanent, so the user has to confirm by typing their email.
+	if r.FormValue("confirm") != u.Email {
+		httpserver.ServeError(fmt.Errorf("confirmation does not match your email"), w)
+		return
+	}
+
+	if err := f.accounts.Delete(r.Context(), u.Email); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	if err := auth.EndSession(w, r); err != nil {
+		f.log.Error("unable to end session of deleted user", zap.Error(err))
+	}
+
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
@@ -0,0 +1,35 @@
+package frontend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// getJSON requests the given URL from the backend and unmarshals the
+// response into v.
+func getJSON(ctx context.Context, c *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+
+	res, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to request: %w", err)
+	}
+	defer res.Body.Close()
+
+	// accept 200 and 300s, ignore 100s
+	if c := res.StatusCode / 100; c > 3 {
+		return errors.New("bad response")
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return fmt.Errorf("unable to decode response: %w", err)
+	}
+
+	return nil
+}
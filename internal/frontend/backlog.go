@@ -0,0 +1,113 @@
+package frontend
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	service "tracker/internal/backlog"
+	sqldb "tracker/internal/database/sql"
+	"tracker/internal/httpserver"
+	"tracker/internal/types/watch"
+	"tracker/server/auth"
+	"tracker/trackable/game"
+	"tracker/web"
+)
+
+func init() {
+	RegisterUserState(game.Kind, "/backlog", func(accounts *sqldb.Database) (httpserver.Component, error) {
+		return NewBacklog(service.NewService(accounts.Backlog()))
+	})
+}
+
+// BacklogFrontend allows the active profile to keep track of the games they
+// want to play, are playing or have played.
+type BacklogFrontend struct {
+	templates *template.Template
+
+	backlog *service.Service
+}
+
+// NewBacklog creates the frontend for the backlog of profiles.
+func NewBacklog(s *service.Service) (*BacklogFrontend, error) {
+	t, err := parseTemplates(defaultFuncs(), web.Templates, defaultPattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply default template: %w", err)
+	}
+
+	return &BacklogFrontend{
+		templates: t,
+		backlog:   s,
+	}, nil
+}
+
+func (f *BacklogFrontend) RegisterHandlers(r *mux.Router) {
+	r.Path("/state").
+		Methods(http.MethodPost).
+		HandlerFunc(f.stateRequest)
+	r.Path("/").
+		Methods(http.MethodGet).
+		HandlerFunc(f.backlogRequest)
+}
+
+type BacklogRequestData struct {
+	Title string
+
+	Games []*watch.Play
+	User  auth.User
+}
+
+func (f *BacklogFrontend) backlogRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	games, err := f.backlog.List(r.Context(), u.ProfileID())
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	data := BacklogRequestData{
+		Title: "Show Tracker - Backlog",
+		Games: games,
+		User:  u,
+	}
+
+	if err := f.templates.ExecuteTemplate(w, "backlog.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+func (f *BacklogFrontend) stateRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	values, err := formInts(r, "game")
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	state := watch.PlayState(r.FormValue("state"))
+	if state == "" {
+		err = f.backlog.Remove(r.Context(), u.ProfileID(), values[0])
+	} else {
+		err = f.backlog.SetState(r.Context(), &watch.Play{
+			Profile: u.ProfileID(),
+			GameID:  values[0],
+			State:   state,
+		})
+	}
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	http.Redirect(w, r, redirectTarget(r, "/backlog/"), http.StatusSeeOther)
+}
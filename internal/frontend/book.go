@@ -0,0 +1,125 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"tracker/internal/httpserver"
+	"tracker/server/auth"
+	"tracker/trackable/book"
+	"tracker/web"
+)
+
+func init() {
+	RegisterTrackable(book.Kind, func(apiAddr string) (httpserver.Component, error) {
+		return NewBook(apiAddr)
+	})
+}
+
+// BookFrontend allows browsing book series and their volumes.
+type BookFrontend struct {
+	templates *template.Template
+
+	apiAddr    string
+	httpClient *http.Client
+}
+
+// NewBook creates the frontend for books, using the backend at apiAddr.
+func NewBook(apiAddr string) (*BookFrontend, error) {
+	t, err := parseTemplates(defaultFuncs(), web.Templates, defaultPattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply default template: %w", err)
+	}
+
+	return &BookFrontend{
+		templates:  t,
+		apiAddr:    apiAddr,
+		httpClient: http.DefaultClient,
+	}, nil
+}
+
+func (f *BookFrontend) RegisterHandlers(r *mux.Router) {
+	r.Path("/{id:[0-9]+}").
+		HandlerFunc(f.detailRequest)
+	r.Path("/{type:[a-z-]+}").
+		HandlerFunc(f.listRequest)
+	r.Path("/").
+		HandlerFunc(f.listRequest)
+	r.Path("").
+		HandlerFunc(f.listRequest)
+}
+
+type SeriesListRequestData struct {
+	Title string
+
+	book.SeriesList
+	User auth.User
+}
+
+func (f *BookFrontend) listRequest(w http.ResponseWriter, r *http.Request) {
+	listType, ok := mux.Vars(r)["type"]
+	if !ok {
+		listType = "all"
+	}
+
+	var list book.SeriesList
+	if err := f.get(r.Context(), fmt.Sprintf("/api/book/get/list/%s", listType), &list); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	user, err := auth.CurrentUser(r)
+	if err != nil {
+		fmt.Printf("Error getting current user: %v\n", err)
+	}
+
+	data := SeriesListRequestData{
+		Title:      fmt.Sprintf("Show Tracker - %s Books", strings.Title(listType)),
+		SeriesList: list,
+		User:       user,
+	}
+
+	if err = f.templates.ExecuteTemplate(w, "books.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+type SeriesRequestData struct {
+	Title string
+
+	book.SeriesFull
+	User auth.User
+}
+
+func (f *BookFrontend) detailRequest(w http.ResponseWriter, r *http.Request) {
+	var series book.SeriesFull
+	if err := f.get(r.Context(), fmt.Sprintf("/api/book/get/%s", mux.Vars(r)["id"]), &series); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	user, err := auth.CurrentUser(r)
+	if err != nil {
+		fmt.Printf("Error getting current user: %v\n", err)
+	}
+
+	data := SeriesRequestData{
+		Title:      fmt.Sprintf("Show Tracker - %s", series.Name),
+		SeriesFull: series,
+		User:       user,
+	}
+
+	if err = f.templates.ExecuteTemplate(w, "book.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+// get the given URL from the backend. The url must be prefixed with a /
+func (f *BookFrontend) get(ctx context.Context, url string, v interface{}) error {
+	return getJSON(ctx, f.httpClient, fmt.Sprintf("%s%s", f.apiAddr, url), v)
+}
@@ -0,0 +1,132 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"tracker/internal/httpserver"
+	"tracker/internal/timeutil"
+	"tracker/server/auth"
+	"tracker/trackable/calendar"
+	"tracker/web"
+)
+
+// CalendarFrontend shows the releases of every kind of trackable, both as a
+// page and as an iCalendar feed.
+type CalendarFrontend struct {
+	templates *template.Template
+
+	apiAddr    string
+	httpClient *http.Client
+}
+
+// NewCalendar creates the frontend for the calendar, using the backend at
+// apiAddr.
+func NewCalendar(apiAddr string) (*CalendarFrontend, error) {
+	t, err := parseTemplates(defaultFuncs(), web.Templates, defaultPattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply default template: %w", err)
+	}
+
+	return &CalendarFrontend{
+		templates:  t,
+		apiAddr:    apiAddr,
+		httpClient: http.DefaultClient,
+	}, nil
+}
+
+func (f *CalendarFrontend) RegisterHandlers(r *mux.Router) {
+	r.Path("/feed.ics").
+		HandlerFunc(f.feedRequest)
+	r.Path("/").
+		HandlerFunc(f.scheduleRequest)
+	r.Path("").
+		HandlerFunc(f.scheduleRequest)
+}
+
+type ScheduleRequestData struct {
+	Title string
+
+	calendar.Calendar
+	// Query selects the same kinds in links to the feed.
+	Query template.URL
+	User  auth.User
+}
+
+func (f *CalendarFrontend) scheduleRequest(w http.ResponseWriter, r *http.Request) {
+	// The schedule starts on the Sunday of last week, and shows 7 weeks.
+	today := time.Now().Truncate(timeutil.Day)
+	start := today.AddDate(0, 0, -7-int(today.Weekday()))
+	end := start.AddDate(0, 0, 7*7-1)
+
+	var c calendar.Calendar
+	if err := f.get(r.Context(), calendarURL(start, end, r), &c); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	user, err := auth.CurrentUser(r)
+	if err != nil {
+		fmt.Printf("Error getting current user: %v\n", err)
+	}
+
+	data := ScheduleRequestData{
+		Title:    "Show Tracker - Schedule",
+		Calendar: c,
+		Query:    template.URL(kindQuery(r).Encode()),
+		User:     user,
+	}
+
+	if err = f.templates.ExecuteTemplate(w, "schedule.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+// feedRequest serves the releases of the last month and the next six months
+// as an iCalendar feed.
+func (f *CalendarFrontend) feedRequest(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	start := now.AddDate(0, -1, 0)
+	end := now.AddDate(0, 6, 0)
+
+	var c calendar.Calendar
+	if err := f.get(r.Context(), calendarURL(start, end, r), &c); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	if err := calendar.WriteICal(w, &c, now); err != nil {
+		fmt.Printf("Error writing calendar feed: %v\n", err)
+	}
+}
+
+// calendarURL is the path of the calendar on the backend, keeping the kinds
+// selected in the request.
+func calendarURL(start, end time.Time, r *http.Request) string {
+	u := fmt.Sprintf("/api/calendar/get/%s/%s", timeutil.String(start), timeutil.String(end))
+	if q := kindQuery(r); len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+	return u
+}
+
+// kindQuery returns the kinds selected in the request as a query.
+func kindQuery(r *http.Request) url.Values {
+	q := url.Values{}
+	for _, kind := range calendar.Kinds(r) {
+		q.Add("kind", string(kind))
+	}
+	return q
+}
+
+// get the given URL from the backend. The url must be prefixed with a /
+func (f *CalendarFrontend) get(ctx context.Context, url string, v interface{}) error {
+	return getJSON(ctx, f.httpClient, fmt.Sprintf("%s%s", f.apiAddr, url), v)
+}
@@ -0,0 +1,149 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"tracker/internal/httpserver"
+	"tracker/server/auth"
+	"tracker/trackable/game"
+	"tracker/web"
+)
+
+func init() {
+	RegisterTrackable(game.Kind, func(apiAddr string) (httpserver.Component, error) {
+		return NewGame(apiAddr)
+	})
+}
+
+// GameFrontend allows browsing video games and their releases.
+type GameFrontend struct {
+	templates *template.Template
+
+	apiAddr    string
+	httpClient *http.Client
+}
+
+// NewGame creates the frontend for games, using the backend at apiAddr.
+func NewGame(apiAddr string) (*GameFrontend, error) {
+	t, err := parseTemplates(defaultFuncs(), web.Templates, defaultPattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply default template: %w", err)
+	}
+
+	return &GameFrontend{
+		templates:  t,
+		apiAddr:    apiAddr,
+		httpClient: http.DefaultClient,
+	}, nil
+}
+
+func (f *GameFrontend) RegisterHandlers(r *mux.Router) {
+	r.Path("/{id:[0-9]+}").
+		HandlerFunc(f.detailRequest)
+	r.Path("/platform/{platform:[a-z0-9-]+}").
+		HandlerFunc(f.platformRequest)
+	r.Path("/{type:[a-z-]+}").
+		HandlerFunc(f.listRequest)
+	r.Path("/").
+		HandlerFunc(f.listRequest)
+	r.Path("").
+		HandlerFunc(f.listRequest)
+}
+
+type GameListRequestData struct {
+	Title string
+
+	game.GameList
+	Platforms []*game.Platform
+	User      auth.User
+}
+
+func (f *GameFrontend) listRequest(w http.ResponseWriter, r *http.Request) {
+	listType, ok := mux.Vars(r)["type"]
+	if !ok {
+		listType = "all"
+	}
+
+	f.serveList(w, r, fmt.Sprintf("/api/game/get/list/%s", listType),
+		fmt.Sprintf("Show Tracker - %s Games", strings.Title(listType)))
+}
+
+func (f *GameFrontend) platformRequest(w http.ResponseWriter, r *http.Request) {
+	platform := mux.Vars(r)["platform"]
+
+	f.serveList(w, r, fmt.Sprintf("/api/game/get/list/platform/%s", platform),
+		fmt.Sprintf("Show Tracker - %s Games", strings.ToUpper(platform)))
+}
+
+// serveList renders the list of games at the url, along with all platforms
+// to filter on.
+func (f *GameFrontend) serveList(w http.ResponseWriter, r *http.Request, url, title string) {
+	var list game.GameList
+	if err := f.get(r.Context(), url, &list); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	var platforms []*game.Platform
+	if err := f.get(r.Context(), "/api/game/get/platforms", &platforms); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	user, err := auth.CurrentUser(r)
+	if err != nil {
+		fmt.Printf("Error getting current user: %v\n", err)
+	}
+
+	data := GameListRequestData{
+		Title:     title,
+		GameList:  list,
+		Platforms: platforms,
+		User:      user,
+	}
+
+	if err = f.templates.ExecuteTemplate(w, "games.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+type GameRequestData struct {
+	Title string
+
+	game.GameFull
+	User auth.User
+}
+
+func (f *GameFrontend) detailRequest(w http.ResponseWriter, r *http.Request) {
+	var g game.GameFull
+	if err := f.get(r.Context(), fmt.Sprintf("/api/game/get/%s", mux.Vars(r)["id"]), &g); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	user, err := auth.CurrentUser(r)
+	if err != nil {
+		fmt.Printf("Error getting current user: %v\n", err)
+	}
+
+	data := GameRequestData{
+		Title:    fmt.Sprintf("Show Tracker - %s", g.Name),
+		GameFull: g,
+		User:     user,
+	}
+
+	if err = f.templates.ExecuteTemplate(w, "game.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+// get the given URL from the backend. The url must be prefixed with a /
+func (f *GameFrontend) get(ctx context.Context, url string, v interface{}) error {
+	return getJSON(ctx, f.httpClient, fmt.Sprintf("%s%s", f.apiAddr, url), v)
+}
@@ -0,0 +1,181 @@
+package frontend
+
+import (
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"tracker/internal/httpserver"
+	"tracker/internal/importer"
+	"tracker/internal/types/watch"
+	"tracker/server/auth"
+	"tracker/web"
+)
+
+// maxUploadSize is the largest export which can be uploaded.
+const maxUploadSize = 32 << 20
+
+// ImportFrontend allows to upload the watch history exported from other
+// trackers, and to review the items which could not be matched.
+type ImportFrontend struct {
+	templates *template.Template
+
+	importer *importer.Importer
+}
+
+// NewImport creates the frontend for importing watch history.
+func NewImport(i *importer.Importer) (*ImportFrontend, error) {
+	t, err := parseTemplates(defaultFuncs(), web.Templates, defaultPattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply default template: %w", err)
+	}
+
+	return &ImportFrontend{
+		templates: t,
+		importer:  i,
+	}, nil
+}
+
+func (f *ImportFrontend) RegisterHandlers(r *mux.Router) {
+	r.Path("/upload").
+		Methods(http.MethodPost).
+		HandlerFunc(f.uploadRequest)
+	r.Path("/resolve").
+		Methods(http.MethodPost).
+		HandlerFunc(f.resolveRequest)
+	r.Path("/dismiss").
+		Methods(http.MethodPost).
+		HandlerFunc(f.dismissRequest)
+	r.Path("/").
+		Methods(http.MethodGet).
+		HandlerFunc(f.queueRequest)
+}
+
+type ImportRequestData struct {
+	Title string
+
+	Formats []importer.Format
+	Result  *importer.Result
+	Queue   []*watch.ImportItem
+	User    auth.User
+}
+
+func (f *ImportFrontend) queueRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+	f.render(w, r, u, nil)
+}
+
+func (f *ImportFrontend) uploadRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	file, header, err := r.FormFile("export")
+	if err != nil {
+		httpserver.ServeError(fmt.Errorf("unable to read upload: %w", err), w)
+		return
+	}
+	defer file.Close()
+
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		httpserver.ServeError(fmt.Errorf("unable to read upload: %w", err), w)
+		return
+	}
+
+	format := importer.Format(r.FormValue("format"))
+	if format == "" {
+		if format, err = importer.Detect(header.Filename, data); err != nil {
+			httpserver.ServeError(err, w)
+			return
+		}
+	}
+
+	items, err := importer.Parse(format, data)
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	res, err := f.importer.Import(r.Context(), u.ProfileID(), items)
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	f.render(w, r, u, res)
+}
+
+func (f *ImportFrontend) resolveRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	if err != nil {
+		httpserver.ServeError(fmt.Errorf("invalid id: %w", err), w)
+		return
+	}
+	show, err := strconv.Atoi(r.FormValue("show"))
+	if err != nil {
+		httpserver.ServeError(fmt.Errorf("invalid show: %w", err), w)
+		return
+	}
+
+	if err := f.importer.Resolve(r.Context(), u.ProfileID(), id, show); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	http.Redirect(w, r, "/import/", http.StatusSeeOther)
+}
+
+func (f *ImportFrontend) dismissRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	if err != nil {
+		httpserver.ServeError(fmt.Errorf("invalid id: %w", err), w)
+		return
+	}
+
+	if err := f.importer.Dismiss(r.Context(), u.ProfileID(), id); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	http.Redirect(w, r, "/import/", http.StatusSeeOther)
+}
+
+func (f *ImportFrontend) render(w http.ResponseWriter, r *http.Request, u auth.User, res *importer.Result) {
+	queue, err := f.importer.Queue(r.Context(), u.ProfileID())
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	data := ImportRequestData{
+		Title:   "Show Tracker - Import",
+		Formats: importer.Formats,
+		Result:  res,
+		Queue:   queue,
+		User:    u,
+	}
+
+	if err := f.templates.ExecuteTemplate(w, "import.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
@@ -0,0 +1,113 @@
+package frontend
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	sqldb "tracker/internal/database/sql"
+	"tracker/internal/httpserver"
+	service "tracker/internal/listening"
+	"tracker/internal/types/watch"
+	"tracker/server/auth"
+	"tracker/trackable/podcast"
+	"tracker/web"
+)
+
+func init() {
+	RegisterUserState(podcast.Kind, "/listening", func(accounts *sqldb.Database) (httpserver.Component, error) {
+		return NewListening(service.NewService(accounts.Listening()))
+	})
+}
+
+// ListeningFrontend allows the active profile to keep track of the podcast
+// episodes they listened to.
+type ListeningFrontend struct {
+	templates *template.Template
+
+	listening *service.Service
+}
+
+// NewListening creates the frontend for the podcast episodes of profiles.
+func NewListening(s *service.Service) (*ListeningFrontend, error) {
+	t, err := parseTemplates(defaultFuncs(), web.Templates, defaultPattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply default template: %w", err)
+	}
+
+	return &ListeningFrontend{
+		templates: t,
+		listening: s,
+	}, nil
+}
+
+func (f *ListeningFrontend) RegisterHandlers(r *mux.Router) {
+	r.Path("/episode").
+		Methods(http.MethodPost).
+		HandlerFunc(f.episodeRequest)
+	r.Path("/").
+		Methods(http.MethodGet).
+		HandlerFunc(f.listenedRequest)
+}
+
+type ListenedRequestData struct {
+	Title string
+
+	Listened []*watch.Listen
+	User     auth.User
+}
+
+func (f *ListeningFrontend) listenedRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	listened, err := f.listening.Listened(r.Context(), u.ProfileID())
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	data := ListenedRequestData{
+		Title:    "Show Tracker - Listened",
+		Listened: listened,
+		User:     u,
+	}
+
+	if err := f.templates.ExecuteTemplate(w, "listened.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+func (f *ListeningFrontend) episodeRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	values, err := formInts(r, "podcast")
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	guid := r.FormValue("guid")
+	if r.FormValue("listened") == "" {
+		err = f.listening.RemoveListened(r.Context(), u.ProfileID(), values[0], guid)
+	} else {
+		err = f.listening.SetListened(r.Context(), &watch.Listen{
+			Profile:   u.ProfileID(),
+			PodcastID: values[0],
+			GUID:      guid,
+		})
+	}
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	http.Redirect(w, r, redirectTarget(r, "/listening/"), http.StatusSeeOther)
+}
@@ -0,0 +1,125 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"tracker/internal/httpserver"
+	"tracker/server/auth"
+	"tracker/trackable/movie"
+	"tracker/web"
+)
+
+func init() {
+	RegisterTrackable(movie.Kind, func(apiAddr string) (httpserver.Component, error) {
+		return NewMovie(apiAddr)
+	})
+}
+
+// MovieFrontend allows browsing movies by how they can be watched.
+type MovieFrontend struct {
+	templates *template.Template
+
+	apiAddr    string
+	httpClient *http.Client
+}
+
+// NewMovie creates the frontend for movies, using the backend at apiAddr.
+func NewMovie(apiAddr string) (*MovieFrontend, error) {
+	t, err := parseTemplates(defaultFuncs(), web.Templates, defaultPattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply default template: %w", err)
+	}
+
+	return &MovieFrontend{
+		templates:  t,
+		apiAddr:    apiAddr,
+		httpClient: http.DefaultClient,
+	}, nil
+}
+
+func (f *MovieFrontend) RegisterHandlers(r *mux.Router) {
+	r.Path("/{id:[0-9]+}").
+		HandlerFunc(f.detailRequest)
+	r.Path("/{type:[a-z-]+}").
+		HandlerFunc(f.listRequest)
+	r.Path("/").
+		HandlerFunc(f.listRequest)
+	r.Path("").
+		HandlerFunc(f.listRequest)
+}
+
+type MovieListRequestData struct {
+	Title string
+
+	movie.MovieList
+	User auth.User
+}
+
+func (f *MovieFrontend) listRequest(w http.ResponseWriter, r *http.Request) {
+	listType, ok := mux.Vars(r)["type"]
+	if !ok {
+		listType = "all"
+	}
+
+	var list movie.MovieList
+	if err := f.get(r.Context(), fmt.Sprintf("/api/movie/get/list/%s", listType), &list); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	user, err := auth.CurrentUser(r)
+	if err != nil {
+		fmt.Printf("Error getting current user: %v\n", err)
+	}
+
+	data := MovieListRequestData{
+		Title:     fmt.Sprintf("Show Tracker - %s", strings.Title(strings.ReplaceAll(listType, "-", " "))),
+		MovieList: list,
+		User:      user,
+	}
+
+	if err = f.templates.ExecuteTemplate(w, "movies.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+type MovieRequestData struct {
+	Title string
+
+	movie.MovieFull
+	User auth.User
+}
+
+func (f *MovieFrontend) detailRequest(w http.ResponseWriter, r *http.Request) {
+	var details movie.MovieFull
+	if err := f.get(r.Context(), fmt.Sprintf("/api/movie/get/%s", mux.Vars(r)["id"]), &details); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	user, err := auth.CurrentUser(r)
+	if err != nil {
+		fmt.Printf("Error getting current user: %v\n", err)
+	}
+
+	data := MovieRequestData{
+		Title:     fmt.Sprintf("Show Tracker - %s", details.Name),
+		MovieFull: details,
+		User:      user,
+	}
+
+	if err = f.templates.ExecuteTemplate(w, "movie.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+// get the given URL from the backend. The url must be prefixed with a /
+func (f *MovieFrontend) get(ctx context.Context, url string, v interface{}) error {
+	return getJSON(ctx, f.httpClient, fmt.Sprintf("%s%s", f.apiAddr, url), v)
+}
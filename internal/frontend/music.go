@@ -0,0 +1,161 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"tracker/internal/httpserver"
+	"tracker/internal/timeutil"
+	"tracker/server/auth"
+	"tracker/trackable/music"
+	"tracker/web"
+)
+
+// upcomingPeriod is how far ahead upcoming album releases are shown.
+const upcomingPeriod = 365 * timeutil.Day
+
+func init() {
+	RegisterTrackable(music.Kind, func(apiAddr string) (httpserver.Component, error) {
+		return NewMusic(apiAddr)
+	})
+}
+
+// MusicFrontend allows browsing artists and their upcoming albums.
+type MusicFrontend struct {
+	templates *template.Template
+
+	apiAddr    string
+	httpClient *http.Client
+}
+
+// NewMusic creates the frontend for music, using the backend at apiAddr.
+func NewMusic(apiAddr string) (*MusicFrontend, error) {
+	t, err := parseTemplates(defaultFuncs(), web.Templates, defaultPattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply default template: %w", err)
+	}
+
+	return &MusicFrontend{
+		templates:  t,
+		apiAddr:    apiAddr,
+		httpClient: http.DefaultClient,
+	}, nil
+}
+
+func (f *MusicFrontend) RegisterHandlers(r *mux.Router) {
+	r.Path("/upcoming").
+		HandlerFunc(f.upcomingRequest)
+	r.Path("/{id:[0-9]+}").
+		HandlerFunc(f.artistRequest)
+	r.Path("/").
+		HandlerFunc(f.listRequest)
+	r.Path("").
+		HandlerFunc(f.listRequest)
+}
+
+type ArtistListRequestData struct {
+	Title string
+
+	music.ArtistList
+	User auth.User
+}
+
+func (f *MusicFrontend) listRequest(w http.ResponseWriter, r *http.Request) {
+	var list music.ArtistList
+	if err := f.get(r.Context(), "/api/music/get/list/all", &list); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	user, err := auth.CurrentUser(r)
+	if err != nil {
+		fmt.Printf("Error getting current user: %v\n", err)
+	}
+
+	data := ArtistListRequestData{
+		Title:      "Show Tracker - Artists",
+		ArtistList: list,
+		User:       user,
+	}
+
+	if err = f.templates.ExecuteTemplate(w, "artists.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+type ArtistRequestData struct {
+	Title string
+
+	music.ArtistFull
+	User auth.User
+}
+
+func (f *MusicFrontend) artistRequest(w http.ResponseWriter, r *http.Request) {
+	u := fmt.Sprintf("/api/music/get/%s", mux.Vars(r)["id"])
+
+	var artist music.ArtistFull
+	if err := f.get(r.Context(), u, &artist); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	user, err := auth.CurrentUser(r)
+	if err != nil {
+		fmt.Printf("Error getting current user: %v\n", err)
+	}
+
+	data := ArtistRequestData{
+		Title:      fmt.Sprintf("Show Tracker - %s", artist.Name),
+		ArtistFull: artist,
+		User:       user,
+	}
+
+	if err = f.templates.ExecuteTemplate(w, "artist.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+type UpcomingRequestData struct {
+	Title string
+
+	music.Upcoming
+	User auth.User
+}
+
+func (f *MusicFrontend) upcomingRequest(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	u := fmt.Sprintf("/api/music/get/upcoming/%s/%s",
+		timeutil.String(now),
+		timeutil.String(now.Add(upcomingPeriod)))
+
+	var upcoming music.Upcoming
+	if err := f.get(r.Context(), u, &upcoming); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	user, err := auth.CurrentUser(r)
+	if err != nil {
+		fmt.Printf("Error getting current user: %v\n", err)
+	}
+
+	data := UpcomingRequestData{
+		Title:    "Show Tracker - Upcoming Albums",
+		Upcoming: upcoming,
+		User:     user,
+	}
+
+	if err = f.templates.ExecuteTemplate(w, "upcoming.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+// get the given URL from the backend. The url must be prefixed with a /
+func (f *MusicFrontend) get(ctx context.Context, url string, v interface{}) error {
+	return getJSON(ctx, f.httpClient, fmt.Sprintf("%s%s", f.apiAddr, url), v)
+}
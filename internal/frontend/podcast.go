@@ -0,0 +1,125 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"tracker/internal/httpserver"
+	"tracker/server/auth"
+	"tracker/trackable/podcast"
+	"tracker/web"
+)
+
+func init() {
+	RegisterTrackable(podcast.Kind, func(apiAddr string) (httpserver.Component, error) {
+		return NewPodcast(apiAddr)
+	})
+}
+
+// PodcastFrontend allows browsing podcasts and their episodes.
+type PodcastFrontend struct {
+	templates *template.Template
+
+	apiAddr    string
+	httpClient *http.Client
+}
+
+// NewPodcast creates the frontend for podcasts, using the backend at apiAddr.
+func NewPodcast(apiAddr string) (*PodcastFrontend, error) {
+	t, err := parseTemplates(defaultFuncs(), web.Templates, defaultPattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply default template: %w", err)
+	}
+
+	return &PodcastFrontend{
+		templates:  t,
+		apiAddr:    apiAddr,
+		httpClient: http.DefaultClient,
+	}, nil
+}
+
+func (f *PodcastFrontend) RegisterHandlers(r *mux.Router) {
+	r.Path("/{id:[0-9]+}").
+		HandlerFunc(f.detailRequest)
+	r.Path("/{type:[a-z-]+}").
+		HandlerFunc(f.listRequest)
+	r.Path("/").
+		HandlerFunc(f.listRequest)
+	r.Path("").
+		HandlerFunc(f.listRequest)
+}
+
+type PodcastListRequestData struct {
+	Title string
+
+	podcast.PodcastList
+	User auth.User
+}
+
+func (f *PodcastFrontend) listRequest(w http.ResponseWriter, r *http.Request) {
+	listType, ok := mux.Vars(r)["type"]
+	if !ok {
+		listType = "all"
+	}
+
+	var list podcast.PodcastList
+	if err := f.get(r.Context(), fmt.Sprintf("/api/podcast/get/list/%s", listType), &list); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	user, err := auth.CurrentUser(r)
+	if err != nil {
+		fmt.Printf("Error getting current user: %v\n", err)
+	}
+
+	data := PodcastListRequestData{
+		Title:       fmt.Sprintf("Show Tracker - %s Podcasts", strings.Title(listType)),
+		PodcastList: list,
+		User:        user,
+	}
+
+	if err = f.templates.ExecuteTemplate(w, "podcasts.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+type PodcastRequestData struct {
+	Title string
+
+	podcast.PodcastFull
+	User auth.User
+}
+
+func (f *PodcastFrontend) detailRequest(w http.ResponseWriter, r *http.Request) {
+	var p podcast.PodcastFull
+	if err := f.get(r.Context(), fmt.Sprintf("/api/podcast/get/%s", mux.Vars(r)["id"]), &p); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	user, err := auth.CurrentUser(r)
+	if err != nil {
+		fmt.Printf("Error getting current user: %v\n", err)
+	}
+
+	data := PodcastRequestData{
+		Title:       fmt.Sprintf("Show Tracker - %s", p.Name),
+		PodcastFull: p,
+		User:        user,
+	}
+
+	if err = f.templates.ExecuteTemplate(w, "podcast.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+// get the given URL from the backend. The url must be prefixed with a /
+func (f *PodcastFrontend) get(ctx context.Context, url string, v interface{}) error {
+	return getJSON(ctx, f.httpClient, fmt.Sprintf("%s%s", f.apiAddr, url), v)
+}
@@ -0,0 +1,159 @@
+package frontend
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"tracker/internal/httpserver"
+	"tracker/internal/profile"
+	"tracker/internal/types/user"
+	"tracker/server/auth"
+	"tracker/web"
+)
+
+// ProfilesFrontend allows to manage and switch between the profiles of an
+// account.
+type ProfilesFrontend struct {
+	templates *template.Template
+
+	profiles *profile.Service
+}
+
+// NewProfiles creates the frontend for managing profiles.
+func NewProfiles(p *profile.Service) (*ProfilesFrontend, error) {
+	t, err := parseTemplates(defaultFuncs(), web.Templates, defaultPattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply default template: %w", err)
+	}
+
+	return &ProfilesFrontend{
+		templates: t,
+		profiles:  p,
+	}, nil
+}
+
+func (f *ProfilesFrontend) RegisterHandlers(r *mux.Router) {
+	r.Path("/switch").
+		Methods(http.MethodPost).
+		HandlerFunc(f.switchRequest)
+	r.Path("/create").
+		Methods(http.MethodPost).
+		HandlerFunc(f.createRequest)
+	r.Path("/preferences").
+		Methods(http.MethodPost).
+		HandlerFunc(f.preferencesRequest)
+	r.Path("/").
+		Methods(http.MethodGet).
+		HandlerFunc(f.listRequest)
+}
+
+type ProfilesRequestData struct {
+	Title string
+
+	User auth.User
+}
+
+func (f *ProfilesFrontend) listRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	data := ProfilesRequestData{
+		Title: "Show Tracker - Profiles",
+		User:  u,
+	}
+
+	if err := f.templates.ExecuteTemplate(w, "profiles.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+func (f *ProfilesFrontend) switchRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(r.FormValue("id"), 10, 64)
+	if err != nil {
+		httpserver.ServeError(fmt.Errorf("invalid profile id: %w", err), w)
+		return
+	}
+	if _, err := f.profiles.Get(r.Context(), u.Email, id); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	if err := auth.SetActiveProfile(w, r, id); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	http.Redirect(w, r, redirectTarget(r, "/show/"), http.StatusSeeOther)
+}
+
+func (f *ProfilesFrontend) createRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	p, err := f.profiles.Create(r.Context(), u.Email, r.FormValue("name"))
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	if err := auth.SetActiveProfile(w, r, p.ID); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	http.Redirect(w, r, "/profile/", http.StatusSeeOther)
+}
+
+func (f *ProfilesFrontend) preferencesRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	prefs := user.Preferences{
+		DefaultList:  r.FormValue("default_list"),
+		HideSpoilers: r.FormValue("hide_spoilers") != "",
+	}
+	if err := f.profiles.SetPreferences(r.Context(), u.Email, u.ProfileID(), prefs); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	http.Redirect(w, r, "/profile/", http.StatusSeeOther)
+}
+
+// loggedInUser returns the logged in user, redirecting to the login page if
+// there is none.
+func loggedInUser(w http.ResponseWriter, r *http.Request) (auth.User, bool) {
+	u, err := auth.CurrentUser(r)
+	if err != nil {
+		fmt.Printf("Error getting current user: %v\n", err)
+	}
+	if u.Email == "" {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return u, false
+	}
+	return u, true
+}
+
+// redirectTarget returns the local page the request came from, or the
+// fallback if there is none.
+func redirectTarget(r *http.Request, fallback string) string {
+	if next := r.FormValue("next"); len(next) > 1 && next[0] == '/' && next[1] != '/' {
+		return next
+	}
+	return fallback
+}
@@ -0,0 +1,126 @@
+package frontend
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	sqldb "tracker/internal/database/sql"
+	"tracker/internal/httpserver"
+	service "tracker/internal/reading"
+	"tracker/internal/types/watch"
+	"tracker/server/auth"
+	"tracker/trackable/book"
+	"tracker/web"
+)
+
+func init() {
+	RegisterUserState(book.Kind, "/reading", func(accounts *sqldb.Database) (httpserver.Component, error) {
+		return NewReading(service.NewService(accounts.Reading()))
+	})
+}
+
+// ReadingFrontend allows the active profile to keep track of the volumes of
+// book series they read.
+type ReadingFrontend struct {
+	templates *template.Template
+
+	reading *service.Service
+}
+
+// NewReading creates the frontend for the reading progress of profiles.
+func NewReading(s *service.Service) (*ReadingFrontend, error) {
+	t, err := parseTemplates(defaultFuncs(), web.Templates, defaultPattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply default template: %w", err)
+	}
+
+	return &ReadingFrontend{
+		templates: t,
+		reading:   s,
+	}, nil
+}
+
+func (f *ReadingFrontend) RegisterHandlers(r *mux.Router) {
+	r.Path("/progress").
+		Methods(http.MethodPost).
+		HandlerFunc(f.progressRequest)
+	r.Path("/").
+		Methods(http.MethodGet).
+		HandlerFunc(f.readingRequest)
+}
+
+type ReadingRequestData struct {
+	Title string
+
+	Progress []*watch.Progress
+	User     auth.User
+}
+
+func (f *ReadingFrontend) readingRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	progress, err := f.reading.Progress(r.Context(), u.ProfileID())
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	data := ReadingRequestData{
+		Title:    "Show Tracker - Reading",
+		Progress: progress,
+		User:     u,
+	}
+
+	if err := f.templates.ExecuteTemplate(w, "reading.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+func (f *ReadingFrontend) progressRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	values, err := formInts(r, "series", "volume")
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	state := watch.ReadingState(r.FormValue("state"))
+	if state == "" {
+		err = f.reading.RemoveProgress(r.Context(), u.ProfileID(), values[0], values[1])
+	} else {
+		var page []int
+		if r.FormValue("page") != "" {
+			if page, err = formInts(r, "page"); err != nil {
+				httpserver.ServeError(err, w)
+				return
+			}
+		}
+
+		p := &watch.Progress{
+			Profile:  u.ProfileID(),
+			SeriesID: values[0],
+			Volume:   values[1],
+			State:    state,
+		}
+		if len(page) > 0 {
+			p.Page = page[0]
+		}
+		err = f.reading.SetProgress(r.Context(), p)
+	}
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	http.Redirect(w, r, redirectTarget(r, "/reading/"), http.StatusSeeOther)
+}
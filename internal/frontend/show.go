@@ -2,25 +2,27 @@ package frontend
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"html/template"
 	"io/fs"
 	"net/http"
+	"net/url"
 	"strings"
-	"time"
 
 	"github.com/gorilla/mux"
 
 	"tracker/internal/httpserver"
-	"tracker/internal/timeutil"
 	"tracker/server/auth"
 	"tracker/trackable/show"
 	"tracker/web"
-	"tracker/web/templates"
 )
 
+func init() {
+	RegisterTrackable(show.Kind, func(apiAddr string) (httpserver.Component, error) {
+		return NewShow(apiAddr)
+	})
+}
+
 type ShowFrontend struct {
 	funcs     template.FuncMap
 	templates *template.Template
@@ -36,10 +38,7 @@ func NewShow(apiAddr string, opts ...ShowOption) (*ShowFrontend, error) {
 	f := &ShowFrontend{
 		apiAddr:    apiAddr,
 		httpClient: http.DefaultClient,
-		funcs: template.FuncMap{
-			"mod":          templates.Mod,
-			"doubleDigits": templates.DoubleDigits,
-		},
+		funcs:      defaultFuncs(),
 	}
 
 	for _, opt := range opts {
@@ -49,7 +48,7 @@ func NewShow(apiAddr string, opts ...ShowOption) (*ShowFrontend, error) {
 	}
 
 	if f.templates == nil {
-		if err := TemplateFS(web.Templates, "**/**.html")(f); err != nil {
+		if err := TemplateFS(web.Templates, defaultPattern)(f); err != nil {
 			return nil, fmt.Errorf("unable to apply default template: %w", err)
 		}
 	}
@@ -149,51 +148,11 @@ func (f *ShowFrontend) detailRequest(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-type ScheduleRequestData struct {
-	Title string
-
-	show.Schedule
-	User auth.User
-}
-
+// scheduleRequest redirects to the calendar, which used to only show the
+// schedule of shows.
 func (f *ShowFrontend) scheduleRequest(w http.ResponseWriter, r *http.Request) {
-	// curDate := date.CurrentDate()
-	now := time.Now()
-
-	// TODO: What even is this? What do we want to get here?
-	// startDate := curDate.Minus(7 + curDate.Weekday())
-	start := now.Add(-time.Duration(7 * now.Weekday()))
-
-	// TODO: What even is this, why 48 days????
-	// endDate := startDate.Plus((7 * 7) - 1)
-	end := start.Add(((7 * 7) - 1) * 24 * time.Hour)
-
-	u := fmt.Sprintf("/api/show/get/schedule/%s/%s",
-		timeutil.String(start),
-		timeutil.String(end))
-
-	var schedule show.Schedule
-	if err := f.get(r.Context(), u, &schedule); err != nil {
-		httpserver.ServeError(err, w)
-		return
-	}
-
-	user, err := auth.CurrentUser(r)
-	if err != nil {
-		fmt.Printf("Error getting current user: %v\n", err)
-	}
-
-	data := ScheduleRequestData{
-		Title:    "Show Tracker - Schedule",
-		Schedule: schedule,
-		User:     user,
-	}
-
-	fmt.Printf("\tTemplate: User=%v\n", user)
-
-	if err = f.templates.ExecuteTemplate(w, "schedule.html", data); err != nil {
-		httpserver.ServeError(err, w)
-	}
+	u := url.URL{Path: "/schedule/", RawQuery: r.URL.RawQuery}
+	http.Redirect(w, r, u.String(), http.StatusMovedPermanently)
 }
 
 func (f *ShowFrontend) loginRequest(w http.ResponseWriter, r *http.Request) {
@@ -229,31 +188,7 @@ func (f *ShowFrontend) addShowRequest(w http.ResponseWriter, r *http.Request) {
 // get the given URL and unmarshal data into res. The url specified must be
 // prefixed with a /
 func (f *ShowFrontend) get(ctx context.Context, url string, v interface{}) error {
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s%s", f.apiAddr, url),
-		nil,
-	)
-	if err != nil {
-		return fmt.Errorf("unable to create request: %w", err)
-	}
-
-	res, err := f.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("unable to request: %w", err)
-	}
-	// accept 200 and 300s, ignore 100s
-	if c := res.StatusCode / 100; c > 3 {
-		return errors.New("bad response")
-	}
-
-	defer res.Body.Close()
-	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
-		return fmt.Errorf("unable to decode response: %w", err)
-	}
-
-	return nil
+	return getJSON(ctx, f.httpClient, fmt.Sprintf("%s%s", f.apiAddr, url), v)
 }
 
 // ShowOption allows to modify the frontend.
@@ -270,13 +205,7 @@ func HTTPClient(c *http.Client) ShowOption {
 // TemplateFS allows to override the fs.FS used for loading templates
 func TemplateFS(tfs fs.FS, pattern string) ShowOption {
 	return func(f *ShowFrontend) (err error) {
-		f.templates, err = template.New("").
-			Funcs(f.funcs).
-			ParseFS(tfs, pattern)
-		if err != nil {
-			return fmt.Errorf("unable to parse templates from provided fs: %w", err)
-		}
-
-		return nil
+		f.templates, err = parseTemplates(f.funcs, tfs, pattern)
+		return err
 	}
 }
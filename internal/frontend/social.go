@@ -0,0 +1,193 @@
+package frontend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	"tracker/internal/httpserver"
+	"tracker/internal/social"
+	types "tracker/internal/types/social"
+	"tracker/internal/types/user"
+	"tracker/server/auth"
+	"tracker/web"
+)
+
+// SocialFrontend serves the activity feed and user profiles.
+type SocialFrontend struct {
+	templates *template.Template
+
+	social *social.Service
+}
+
+// NewSocial creates the frontend for the social features.
+func NewSocial(s *social.Service) (*SocialFrontend, error) {
+	t, err := parseTemplates(defaultFuncs(), web.Templates, defaultPattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply default template: %w", err)
+	}
+
+	return &SocialFrontend{
+		templates: t,
+		social:    s,
+	}, nil
+}
+
+func (f *SocialFrontend) RegisterHandlers(r *mux.Router) {
+	r.Path("/feed").
+		Methods(http.MethodGet).
+		HandlerFunc(f.feedRequest)
+	r.Path("/user/{email}").
+		Methods(http.MethodGet).
+		HandlerFunc(f.profileRequest)
+	r.Path("/follow/{email}").
+		Methods(http.MethodPost).
+		HandlerFunc(f.followRequest)
+	r.Path("/unfollow/{email}").
+		Methods(http.MethodPost).
+		HandlerFunc(f.unfollowRequest)
+	r.Path("/privacy").
+		Methods(http.MethodPost).
+		HandlerFunc(f.privacyRequest)
+}
+
+type FeedRequestData struct {
+	Title string
+
+	Activity  []*types.Activity
+	Following []*types.Follow
+	Followers []*types.Follow
+	User      auth.User
+}
+
+func (f *SocialFrontend) feedRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	activity, err := f.social.Feed(r.Context(), u.Email, u.ProfileID(), 0)
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+	following, err := f.social.Following(r.Context(), u.Email, u.ProfileID())
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+	followers, err := f.social.Followers(r.Context(), u.Email)
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	data := FeedRequestData{
+		Title:     "Show Tracker - Feed",
+		Activity:  activity,
+		Following: following,
+		Followers: followers,
+		User:      u,
+	}
+
+	if err := f.templates.ExecuteTemplate(w, "feed.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+type ProfileRequestData struct {
+	Title string
+
+	Owner     string
+	Visible   bool
+	Following bool
+	Activity  []*types.Activity
+	User      auth.User
+}
+
+func (f *SocialFrontend) profileRequest(w http.ResponseWriter, r *http.Request) {
+	owner := mux.Vars(r)["email"]
+
+	u, err := auth.CurrentUser(r)
+	if err != nil {
+		fmt.Printf("Error getting current user: %v\n", err)
+	}
+
+	data := ProfileRequestData{
+		Title:   fmt.Sprintf("Show Tracker - %s", owner),
+		Owner:   owner,
+		Visible: true,
+		User:    u,
+	}
+
+	data.Activity, err = f.social.Profile(r.Context(), u.Email, owner, 0)
+	switch {
+	case errors.Is(err, social.ErrForbidden):
+		data.Visible = false
+	case err != nil:
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	if u.Email != "" {
+		following, err := f.social.Following(r.Context(), u.Email, u.ProfileID())
+		if err != nil {
+			httpserver.ServeError(err, w)
+			return
+		}
+		for _, follow := range following {
+			if follow.Followee == owner {
+				data.Following = true
+			}
+		}
+	}
+
+	if err := f.templates.ExecuteTemplate(w, "profile.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+func (f *SocialFrontend) followRequest(w http.ResponseWriter, r *http.Request) {
+	f.relationshipRequest(w, r, f.social.Follow)
+}
+
+func (f *SocialFrontend) unfollowRequest(w http.ResponseWriter, r *http.Request) {
+	f.relationshipRequest(w, r, f.social.Unfollow)
+}
+
+func (f *SocialFrontend) relationshipRequest(w http.ResponseWriter, r *http.Request,
+	update func(ctx context.Context, follower string, profile int64, followee string) error) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	followee := mux.Vars(r)["email"]
+	if err := update(r.Context(), u.Email, u.ProfileID(), followee); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/social/user/%s", url.PathEscape(followee)),
+		http.StatusSeeOther)
+}
+
+func (f *SocialFrontend) privacyRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	privacy := user.Privacy(r.FormValue("privacy"))
+	if err := f.social.SetPrivacy(r.Context(), u.Email, privacy); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	http.Redirect(w, r, "/social/feed", http.StatusSeeOther)
+}
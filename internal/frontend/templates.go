@@ -0,0 +1,32 @@
+package frontend
+
+import (
+	"fmt"
+	"html/template"
+	"io/fs"
+
+	"tracker/web/templates"
+)
+
+// defaultPattern matches all templates embedded in web.Templates.
+const defaultPattern = "**/**.html"
+
+// defaultFuncs are the functions available to every template.
+func defaultFuncs() template.FuncMap {
+	return template.FuncMap{
+		"mod":          templates.Mod,
+		"doubleDigits": templates.DoubleDigits,
+	}
+}
+
+// parseTemplates parses all templates matching the pattern in the fs.
+func parseTemplates(funcs template.FuncMap, tfs fs.FS, pattern string) (*template.Template, error) {
+	t, err := template.New("").
+		Funcs(funcs).
+		ParseFS(tfs, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse templates from provided fs: %w", err)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,101 @@
+package frontend
+
+import (
+	"fmt"
+	"sync"
+
+	sqldb "tracker/internal/database/sql"
+	"tracker/internal/httpserver"
+	"tracker/trackable"
+)
+
+// TrackableFactory creates the frontend component of a kind of trackable,
+// using the backend at apiAddr.
+type TrackableFactory func(apiAddr string) (httpserver.Component, error)
+
+// UserStateFactory creates the frontend component keeping the state of a
+// kind of trackable for each profile, such as how far it has read, using the
+// stores of the accounts.
+type UserStateFactory func(accounts *sqldb.Database) (httpserver.Component, error)
+
+// userState is the registered component keeping the state of a kind for
+// each profile, and the path it is served at.
+type userState struct {
+	path    string
+	factory UserStateFactory
+}
+
+var (
+	trackablesMu sync.RWMutex
+	trackables   = map[trackable.Kind]TrackableFactory{}
+	userStates   = map[trackable.Kind]*userState{}
+)
+
+// RegisterTrackable makes the frontend component of the kind available. It
+// is served at /<kind> once the kind itself is registered with trackable.
+func RegisterTrackable(kind trackable.Kind, factory TrackableFactory) {
+	trackablesMu.Lock()
+	defer trackablesMu.Unlock()
+
+	if _, ok := trackables[kind]; ok {
+		panic(fmt.Sprintf("frontend: RegisterTrackable called twice for kind %s", kind))
+	}
+	trackables[kind] = factory
+}
+
+// Trackables creates the components of every registered kind of trackable,
+// keyed by the path they are served at.
+func Trackables(apiAddr string) (map[string]httpserver.Component, error) {
+	trackablesMu.RLock()
+	defer trackablesMu.RUnlock()
+
+	components := map[string]httpserver.Component{}
+	for _, m := range trackable.Modules() {
+		factory, ok := trackables[m.Kind]
+		if !ok {
+			continue
+		}
+
+		c, err := factory(apiAddr)
+		if err != nil {
+			return nil, fmt.Errorf("unable to init %s frontend: %w", m.Kind, err)
+		}
+		components[fmt.Sprintf("/%s", m.Kind)] = c
+	}
+	return components, nil
+}
+
+// RegisterUserState makes the component keeping the state of the kind for
+// each profile available. It is served at path once the kind itself is
+// registered with trackable.
+func RegisterUserState(kind trackable.Kind, path string, factory UserStateFactory) {
+	trackablesMu.Lock()
+	defer trackablesMu.Unlock()
+
+	if _, ok := userStates[kind]; ok {
+		panic(fmt.Sprintf("frontend: RegisterUserState called twice for kind %s", kind))
+	}
+	userStates[kind] = &userState{path: path, factory: factory}
+}
+
+// UserStates creates the components keeping the state of every registered
+// kind of trackable for each profile, keyed by the path they are served at.
+func UserStates(accounts *sqldb.Database) (map[string]httpserver.Component, error) {
+	trackablesMu.RLock()
+	defer trackablesMu.RUnlock()
+
+	components := map[string]httpserver.Component{}
+	for _, m := range trackable.Modules() {
+		state, ok := userStates[m.Kind]
+		if !ok {
+			continue
+		}
+
+		c, err := state.factory(accounts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to init %s state frontend: %w", m.Kind, err)
+		}
+		components[state.path] = c
+	}
+	return components, nil
+}
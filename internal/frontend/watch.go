@@ -0,0 +1,155 @@
+package frontend
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"tracker/internal/httpserver"
+	"tracker/internal/types/watch"
+	service "tracker/internal/watch"
+	"tracker/server/auth"
+	"tracker/web"
+)
+
+// WatchFrontend allows the active profile to mark episodes as watched and
+// rate shows.
+type WatchFrontend struct {
+	templates *template.Template
+
+	watch *service.Service
+}
+
+// NewWatch creates the frontend for the watch state of profiles.
+func NewWatch(s *service.Service) (*WatchFrontend, error) {
+	t, err := parseTemplates(defaultFuncs(), web.Templates, defaultPattern)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply default template: %w", err)
+	}
+
+	return &WatchFrontend{
+		templates: t,
+		watch:     s,
+	}, nil
+}
+
+func (f *WatchFrontend) RegisterHandlers(r *mux.Router) {
+	r.Path("/episode").
+		Methods(http.MethodPost).
+		HandlerFunc(f.episodeRequest)
+	r.Path("/rating").
+		Methods(http.MethodPost).
+		HandlerFunc(f.ratingRequest)
+	r.Path("/").
+		Methods(http.MethodGet).
+		HandlerFunc(f.historyRequest)
+}
+
+type HistoryRequestData struct {
+	Title string
+
+	Watched []*watch.Episode
+	Ratings []*watch.Rating
+	User    auth.User
+}
+
+func (f *WatchFrontend) historyRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	watched, err := f.watch.Watched(r.Context(), u.ProfileID())
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+	ratings, err := f.watch.Ratings(r.Context(), u.ProfileID())
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	data := HistoryRequestData{
+		Title:   "Show Tracker - History",
+		Watched: watched,
+		Ratings: ratings,
+		User:    u,
+	}
+
+	if err := f.templates.ExecuteTemplate(w, "history.html", data); err != nil {
+		httpserver.ServeError(err, w)
+	}
+}
+
+func (f *WatchFrontend) episodeRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	values, err := formInts(r, "show", "season", "episode")
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	e := &watch.Episode{
+		Profile: u.ProfileID(),
+		ShowID:  values[0],
+		Season:  values[1],
+		Episode: values[2],
+	}
+	if r.FormValue("unwatch") != "" {
+		err = f.watch.UnmarkWatched(r.Context(), e)
+	} else {
+		err = f.watch.MarkWatched(r.Context(), u.Email, e)
+	}
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	http.Redirect(w, r, redirectTarget(r, "/watch/"), http.StatusSeeOther)
+}
+
+func (f *WatchFrontend) ratingRequest(w http.ResponseWriter, r *http.Request) {
+	u, ok := loggedInUser(w, r)
+	if !ok {
+		return
+	}
+
+	values, err := formInts(r, "show", "rating")
+	if err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	rating := &watch.Rating{
+		Profile: u.ProfileID(),
+		ShowID:  values[0],
+		Rating:  values[1],
+	}
+	if err := f.watch.Rate(r.Context(), u.Email, rating); err != nil {
+		httpserver.ServeError(err, w)
+		return
+	}
+
+	http.Redirect(w, r, redirectTarget(r, "/watch/"), http.StatusSeeOther)
+}
+
+// formInts parses the given form values as integers.
+func formInts(r *http.Request, keys ...string) ([]int, error) {
+	values := make([]int, len(keys))
+	for i, key := range keys {
+		v, err := strconv.Atoi(r.FormValue(key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
@@ -0,0 +1,51 @@
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// SQLCatalog reads the catalog from the tracker database. The year of a show
+// is the year of its first episode.
+type SQLCatalog struct {
+	db *sql.DB
+}
+
+// NewSQLCatalog creates a catalog reading from the tracker database.
+func NewSQLCatalog(db *sql.DB) *SQLCatalog {
+	return &SQLCatalog{db: db}
+}
+
+func (c *SQLCatalog) Shows(ctx context.Context) ([]*Show, error) {
+	rows, err := c.db.QueryContext(ctx, catalogQuery)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query shows: %w", err)
+	}
+	defer rows.Close()
+
+	shows := make([]*Show, 0)
+	for rows.Next() {
+		s := &Show{}
+		if err := rows.Scan(&s.ID, &s.Title, &s.IMDbID, &s.TVDBID, &s.TMDBID,
+			&s.Year); err != nil {
+			return nil, fmt.Errorf("unable to scan show: %w", err)
+		}
+		shows = append(shows, s)
+	}
+
+	return shows, rows.Err()
+}
+
+const catalogQuery = `
+SELECT
+	s.id,
+	s.title,
+	COALESCE(s.imdb_id, ''),
+	COALESCE(s.tvdb_id, ''),
+	COALESCE(s.tmdb_id, ''),
+	COALESCE(YEAR(MIN(e.release_date)), 0)
+FROM shows s
+LEFT JOIN episodes e ON e.show_id=s.id
+GROUP BY s.id;
+`
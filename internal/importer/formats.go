@@ -0,0 +1,264 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"tracker/internal/types/watch"
+)
+
+// Format of an exported watch history.
+type Format string
+
+const (
+	// Trakt is the JSON history or ratings export of trakt.tv.
+	Trakt Format = "trakt"
+	// TVTime is the seen_episode.csv file of the TV Time data export.
+	TVTime Format = "tvtime"
+	// IMDb is the ratings CSV export of IMDb.
+	IMDb Format = "imdb"
+)
+
+// Formats lists all supported formats.
+var Formats = []Format{Trakt, TVTime, IMDb}
+
+var parsers = map[Format]func([]byte) ([]*watch.ImportItem, error){
+	Trakt:  parseTrakt,
+	TVTime: parseTVTime,
+	IMDb:   parseIMDb,
+}
+
+// Parse the exported data in the given format.
+func Parse(format Format, data []byte) ([]*watch.ImportItem, error) {
+	parse, ok := parsers[format]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+
+	items, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s export: %w", format, err)
+	}
+	for _, item := range items {
+		item.Source = string(format)
+	}
+	return items, nil
+}
+
+// Detect the format of the exported data, based on the file name and the
+// contents of the file.
+func Detect(name string, data []byte) (Format, error) {
+	if strings.EqualFold(path.Ext(name), ".json") {
+		return Trakt, nil
+	}
+
+	header, err := csv.NewReader(bytes.NewReader(data)).Read()
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnknownFormat, err)
+	}
+	columns := columnIndex(header)
+	if _, ok := columns["const"]; ok {
+		return IMDb, nil
+	}
+	if _, ok := columns["tv_show_name"]; ok {
+		return TVTime, nil
+	}
+
+	return "", ErrUnknownFormat
+}
+
+type traktIDs struct {
+	IMDb string      `json:"imdb"`
+	TVDB json.Number `json:"tvdb"`
+	TMDB json.Number `json:"tmdb"`
+}
+
+type traktEntry struct {
+	WatchedAt time.Time `json:"watched_at"`
+	RatedAt   time.Time `json:"rated_at"`
+	Type      string    `json:"type"`
+	Rating    int       `json:"rating"`
+	Episode   *struct {
+		Season int `json:"season"`
+		Number int `json:"number"`
+	} `json:"episode"`
+	Show *struct {
+		Title string   `json:"title"`
+		Year  int      `json:"year"`
+		IDs   traktIDs `json:"ids"`
+	} `json:"show"`
+}
+
+// parseTrakt parses both the history and the ratings exports of Trakt.
+// Ratings on Trakt are out of 10, so they are halved.
+func parseTrakt(data []byte) ([]*watch.ImportItem, error) {
+	var entries []*traktEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	items := make([]*watch.ImportItem, 0, len(entries))
+	for _, e := range entries {
+		if e.Show == nil {
+			continue
+		}
+
+		item := &watch.ImportItem{
+			Title:  e.Show.Title,
+			Year:   e.Show.Year,
+			IMDbID: e.Show.IDs.IMDb,
+			TVDBID: e.Show.IDs.TVDB.String(),
+			TMDBID: e.Show.IDs.TMDB.String(),
+		}
+
+		switch {
+		case e.Episode != nil:
+			item.Season = e.Episode.Season
+			item.Episode = e.Episode.Number
+			item.Watched = e.WatchedAt
+		case e.Type == "show" && e.Rating > 0:
+			item.Rating = (e.Rating + 1) / 2
+			item.Watched = e.RatedAt
+		default:
+			continue
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// parseTVTime parses the seen episodes of TV Time. The show IDs used by TV
+// Time are the ones from TheTVDB.
+func parseTVTime(data []byte) ([]*watch.ImportItem, error) {
+	return parseCSV(data, func(row csvRow) (*watch.ImportItem, error) {
+		season, err := row.int("episode_season_number", "season_number")
+		if err != nil {
+			return nil, err
+		}
+		episode, err := row.int("episode_number")
+		if err != nil {
+			return nil, err
+		}
+
+		return &watch.ImportItem{
+			Title:   row.get("tv_show_name"),
+			TVDBID:  row.get("tv_show_id"),
+			Season:  season,
+			Episode: episode,
+			Watched: row.time("created_at", "updated_at"),
+		}, nil
+	})
+}
+
+// parseIMDb parses the ratings export of IMDb. Only ratings of whole shows
+// can be imported: episodes are exported without their show, season and
+// number, so they could never be matched, nor resolved by hand, and are
+// ignored along with anything which is not TV.
+func parseIMDb(data []byte) ([]*watch.ImportItem, error) {
+	return parseCSV(data, func(row csvRow) (*watch.ImportItem, error) {
+		titleType := row.get("title type")
+		if !strings.HasPrefix(titleType, "tv") || titleType == "tvMovie" || titleType == "tvEpisode" {
+			return nil, nil
+		}
+
+		year, _ := row.int("year")
+		rating, _ := row.int("your rating")
+		return &watch.ImportItem{
+			Title:   row.get("title"),
+			Year:    year,
+			IMDbID:  row.get("const"),
+			Rating:  (rating + 1) / 2,
+			Watched: row.time("date rated", "created"),
+		}, nil
+	})
+}
+
+type csvRow struct {
+	columns map[string]int
+	record  []string
+}
+
+// get the first non empty value of the given columns.
+func (r csvRow) get(names ...string) string {
+	for _, name := range names {
+		if i, ok := r.columns[name]; ok && i < len(r.record) {
+			if v := strings.TrimSpace(r.record[i]); v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+func (r csvRow) int(names ...string) (int, error) {
+	v := r.get(names...)
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", names[0], v, err)
+	}
+	return n, nil
+}
+
+var csvTimeFormats = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+func (r csvRow) time(names ...string) time.Time {
+	v := r.get(names...)
+	for _, format := range csvTimeFormats {
+		if t, err := time.Parse(format, v); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// parseCSV parses a CSV file with a header, converting each row into an
+// item. Rows converted to nil are skipped.
+func parseCSV(data []byte, convert func(csvRow) (*watch.ImportItem, error)) ([]*watch.ImportItem, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read header: %w", err)
+	}
+	columns := columnIndex(header)
+
+	items := make([]*watch.ImportItem, 0)
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		item, err := convert(csvRow{columns: columns, record: record})
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if item != nil {
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+// columnIndex maps the lower case names of the header to their index.
+func columnIndex(header []string) map[string]int {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+	return columns
+}
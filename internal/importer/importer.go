@@ -0,0 +1,250 @@
+// Package importer imports the watch history exported from other trackers,
+// matching their shows to our catalog. Anything which can't be matched is
+// queued up so the user can review it.
+package importer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"tracker/internal/database"
+	"tracker/internal/types/watch"
+)
+
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+const (
+	ErrUnknownFormat = Error("importer: unknown export format")
+	ErrNotOwner      = Error("importer: item belongs to another profile")
+	ErrNoEpisode     = Error("importer: item has no episode to mark as watched")
+)
+
+// Show is an entry of the catalog which items can be matched against.
+type Show struct {
+	ID     int
+	Title  string
+	Year   int
+	IMDbID string
+	TVDBID string
+	TMDBID string
+}
+
+// Catalog provides all shows known to the tracker.
+type Catalog interface {
+	Shows(ctx context.Context) ([]*Show, error)
+}
+
+// Result summarises a single import.
+type Result struct {
+	Watched int `json:"watched"`
+	Rated   int `json:"rated"`
+	Queued  int `json:"queued"`
+}
+
+// Importer imports items into the watch state of a profile.
+type Importer struct {
+	catalog Catalog
+	watch   database.WatchDatabase
+	queue   database.ImportQueueDatabase
+}
+
+// New creates a new importer.
+func New(catalog Catalog, watch database.WatchDatabase, queue database.ImportQueueDatabase) *Importer {
+	return &Importer{
+		catalog: catalog,
+		watch:   watch,
+		queue:   queue,
+	}
+}
+
+// Import the items for the profile. Items are written straight to the watch
+// state, without creating any activity, as importing years of history would
+// flood the feeds of followers.
+func (i *Importer) Import(ctx context.Context, profile int64, items []*watch.ImportItem) (*Result, error) {
+	shows, err := i.catalog.Shows(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load catalog: %w", err)
+	}
+	m := NewMatcher(shows)
+
+	res := &Result{}
+	for _, item := range items {
+		item.Profile = profile
+
+		show := m.Match(item)
+		if show == nil || item.Reason != "" {
+			if err := i.queue.Add(ctx, item); err != nil {
+				return res, err
+			}
+			res.Queued++
+			continue
+		}
+
+		if err := i.apply(ctx, item, show.ID); err != nil {
+			return res, err
+		}
+		if item.Rating > 0 {
+			res.Rated++
+		} else {
+			res.Watched++
+		}
+	}
+
+	return res, nil
+}
+
+// Queue lists the items waiting for review by the profile.
+func (i *Importer) Queue(ctx context.Context, profile int64) ([]*watch.ImportItem, error) {
+	return i.queue.List(ctx, profile)
+}
+
+// Resolve a queued item by manually matching it to a show.
+func (i *Importer) Resolve(ctx context.Context, profile, id int64, showID int) error {
+	item, err := i.queuedItem(ctx, profile, id)
+	if err != nil {
+		return err
+	}
+	if item.Rating == 0 && item.Season == 0 && item.Episode == 0 {
+		return ErrNoEpisode
+	}
+
+	if err := i.apply(ctx, item, showID); err != nil {
+		return err
+	}
+	return i.queue.Remove(ctx, id)
+}
+
+// Dismiss a queued item without importing it.
+func (i *Importer) Dismiss(ctx context.Context, profile, id int64) error {
+	if _, err := i.queuedItem(ctx, profile, id); err != nil {
+		return err
+	}
+	return i.queue.Remove(ctx, id)
+}
+
+func (i *Importer) queuedItem(ctx context.Context, profile, id int64) (*watch.ImportItem, error) {
+	item, err := i.queue.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if item.Profile != profile {
+		return nil, ErrNotOwner
+	}
+	return item, nil
+}
+
+func (i *Importer) apply(ctx context.Context, item *watch.ImportItem, showID int) error {
+	if item.Rating > 0 {
+		return i.watch.Rate(ctx, &watch.Rating{
+			Profile: item.Profile,
+			ShowID:  showID,
+			Rating:  item.Rating,
+			Rated:   item.Watched,
+		})
+	}
+
+	return i.watch.MarkWatched(ctx, &watch.Episode{
+		Profile: item.Profile,
+		ShowID:  showID,
+		Season:  item.Season,
+		Episode: item.Episode,
+		Watched: item.Watched,
+	})
+}
+
+// Matcher matches imported items to shows of the catalog.
+type Matcher struct {
+	byIMDb  map[string]*Show
+	byTVDB  map[string]*Show
+	byTMDB  map[string]*Show
+	byTitle map[string][]*Show
+}
+
+// NewMatcher indexes the shows for matching.
+func NewMatcher(shows []*Show) *Matcher {
+	m := &Matcher{
+		byIMDb:  map[string]*Show{},
+		byTVDB:  map[string]*Show{},
+		byTMDB:  map[string]*Show{},
+		byTitle: map[string][]*Show{},
+	}
+
+	for _, s := range shows {
+		if s.IMDbID != "" {
+			m.byIMDb[s.IMDbID] = s
+		}
+		if s.TVDBID != "" {
+			m.byTVDB[s.TVDBID] = s
+		}
+		if s.TMDBID != "" {
+			m.byTMDB[s.TMDBID] = s
+		}
+		title := normalizeTitle(s.Title)
+		m.byTitle[title] = append(m.byTitle[title], s)
+	}
+
+	return m
+}
+
+// Match the item to a show. External IDs are preferred, falling back to the
+// title and year. If no show matches, the reason is set on the item.
+func (m *Matcher) Match(item *watch.ImportItem) *Show {
+	if s, ok := m.byIMDb[item.IMDbID]; ok && item.IMDbID != "" {
+		return s
+	}
+	if s, ok := m.byTVDB[item.TVDBID]; ok && item.TVDBID != "" {
+		return s
+	}
+	if s, ok := m.byTMDB[item.TMDBID]; ok && item.TMDBID != "" {
+		return s
+	}
+
+	candidates := make([]*Show, 0)
+	for _, s := range m.byTitle[normalizeTitle(item.Title)] {
+		if item.Year == 0 || s.Year == 0 || abs(item.Year-s.Year) <= 1 {
+			candidates = append(candidates, s)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		if item.Reason == "" {
+			item.Reason = "no show with this title"
+		}
+		return nil
+	case 1:
+		return candidates[0]
+	default:
+		if item.Reason == "" {
+			item.Reason = "multiple shows with this title"
+		}
+		return nil
+	}
+}
+
+var (
+	titleYearRegexp   = regexp.MustCompile(`\s*\(\d{4}\)\s*$`)
+	titleSymbolRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// normalizeTitle removes everything from a title which commonly differs
+// between trackers, such as punctuation, a leading "The" and a trailing year.
+func normalizeTitle(title string) string {
+	title = strings.ToLower(titleYearRegexp.ReplaceAllString(title, ""))
+	title = strings.TrimPrefix(title, "the ")
+	title = strings.ReplaceAll(title, "&", "and")
+	return titleSymbolRegexp.ReplaceAllString(title, "")
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,222 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"tracker/internal/database"
+	"tracker/internal/types/watch"
+)
+
+func TestParse(t *testing.T) {
+	testCases := map[string]struct {
+		format Format
+		want   []*watch.ImportItem
+	}{
+		"trakt-history.json": {Trakt, []*watch.ImportItem{
+			{Source: "trakt", Title: "Game of Thrones", Year: 2011, IMDbID: "tt0944947",
+				TVDBID: "121361", TMDBID: "1399", Season: 8, Episode: 1,
+				Watched: time.Date(2019, time.April, 15, 2, 0, 0, 0, time.UTC)},
+			{Source: "trakt", Title: "Stranger Things", Year: 2016, Season: 1, Episode: 1,
+				Watched: time.Date(2016, time.July, 20, 20, 0, 0, 0, time.UTC)},
+			{Source: "trakt", Title: "Game of Thrones", Year: 2011, IMDbID: "tt0944947",
+				Rating: 4, Watched: time.Date(2019, time.May, 20, 10, 0, 0, 0, time.UTC)},
+		}},
+		"tvtime-seen_episode.csv": {TVTime, []*watch.ImportItem{
+			{Source: "tvtime", Title: "Game of Thrones", TVDBID: "121361", Season: 1, Episode: 1,
+				Watched: time.Date(2012, time.May, 1, 20, 0, 0, 0, time.UTC)},
+			{Source: "tvtime", Title: "Game of Thrones", TVDBID: "121361", Season: 1, Episode: 2,
+				Watched: time.Date(2012, time.May, 2, 20, 0, 0, 0, time.UTC)},
+			{Source: "tvtime", Title: "The Office (2005)", TVDBID: "999999", Season: 2, Episode: 3,
+				Watched: time.Date(2013, time.January, 1, 12, 0, 0, 0, time.UTC)},
+		}},
+		"imdb-ratings.csv": {IMDb, []*watch.ImportItem{
+			{Source: "imdb", Title: "Game of Thrones", Year: 2011, IMDbID: "tt0944947", Rating: 5,
+				Watched: time.Date(2019, time.May, 20, 0, 0, 0, 0, time.UTC)},
+		}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data, err := ioutil.ReadFile("testdata/" + name)
+			if err != nil {
+				t.Fatalf("unable to read file: %v", err)
+			}
+
+			format, err := Detect(name, data)
+			if err != nil {
+				t.Fatalf("Detect() err = %v, want %v", err, nil)
+			}
+			if format != tc.format {
+				t.Fatalf("Detect() = %s, want %s", format, tc.format)
+			}
+
+			got, err := Parse(format, data)
+			if err != nil {
+				t.Fatalf("Parse() err = %v, want %v", err, nil)
+			}
+			if diff := deep.Equal(got, tc.want); diff != nil {
+				t.Errorf("Parse() diff = %v", diff)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	m := NewMatcher([]*Show{
+		{ID: 1, Title: "Game of Thrones", Year: 2011, IMDbID: "tt0944947"},
+		{ID: 2, Title: "The Office", Year: 2005},
+		{ID: 3, Title: "The Office", Year: 2001},
+		{ID: 4, Title: "Law & Order", Year: 1990},
+	})
+
+	testCases := map[string]struct {
+		item *watch.ImportItem
+		want int
+	}{
+		"imdb id":        {&watch.ImportItem{Title: "GoT", IMDbID: "tt0944947"}, 1},
+		"title":          {&watch.ImportItem{Title: "game of thrones"}, 1},
+		"title and year": {&watch.ImportItem{Title: "The Office (2005)", Year: 2005}, 2},
+		"ambiguous":      {&watch.ImportItem{Title: "The Office"}, 0},
+		"symbols":        {&watch.ImportItem{Title: "Law and Order"}, 4},
+		"wrong year":     {&watch.ImportItem{Title: "Game of Thrones", Year: 2019}, 0},
+		"unknown":        {&watch.ImportItem{Title: "Unknown Show"}, 0},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := m.Match(tc.item)
+			if tc.want == 0 {
+				if got != nil || tc.item.Reason == "" {
+					t.Errorf("Match() = %v (reason %q), want no match with a reason", got, tc.item.Reason)
+				}
+				return
+			}
+			if got == nil || got.ID != tc.want {
+				t.Errorf("Match() = %v, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	catalog := testCatalog{{ID: 1, Title: "Game of Thrones", Year: 2011}}
+	w := &testWatch{}
+	q := &testQueue{m: map[int64]*watch.ImportItem{}}
+	i := New(catalog, w, q)
+
+	res, err := i.Import(ctx, 7, []*watch.ImportItem{
+		{Title: "Game of Thrones", Season: 1, Episode: 1},
+		{Title: "Game of Thrones", Rating: 5},
+		{Title: "Unknown", Season: 1, Episode: 1},
+	})
+	if err != nil {
+		t.Fatalf("Import() err = %v, want %v", err, nil)
+	}
+	if diff := deep.Equal(res, &Result{Watched: 1, Rated: 1, Queued: 1}); diff != nil {
+		t.Errorf("Import() diff = %v", diff)
+	}
+
+	queue, _ := i.Queue(ctx, 7)
+	if len(queue) != 1 {
+		t.Fatalf("Queue() returned %d items, want %d", len(queue), 1)
+	}
+	if err := i.Resolve(ctx, 8, queue[0].ID, 1); !errors.Is(err, ErrNotOwner) {
+		t.Errorf("Resolve(other profile) err = %v, want %v", err, ErrNotOwner)
+	}
+	if err := i.Resolve(ctx, 7, queue[0].ID, 1); err != nil {
+		t.Fatalf("Resolve() err = %v, want %v", err, nil)
+	}
+	if len(w.episodes) != 2 || len(q.m) != 0 {
+		t.Errorf("Resolve() left %d episodes and %d queued, want %d and %d",
+			len(w.episodes), len(q.m), 2, 0)
+	}
+}
+
+type testCatalog []*Show
+
+func (c testCatalog) Shows(context.Context) ([]*Show, error) {
+	return c, nil
+}
+
+type testWatch struct {
+	episodes []*watch.Episode
+	ratings  []*watch.Rating
+}
+
+func (db *testWatch) MarkWatched(_ context.Context, e *watch.Episode) error {
+	db.episodes = append(db.episodes, e)
+	return nil
+}
+
+func (db *testWatch) UnmarkWatched(context.Context, *watch.Episode) error {
+	return nil
+}
+
+func (db *testWatch) Watched(context.Context, int64) ([]*watch.Episode, error) {
+	return db.episodes, nil
+}
+
+func (db *testWatch) Rate(_ context.Context, r *watch.Rating) error {
+	db.ratings = append(db.ratings, r)
+	return nil
+}
+
+func (db *testWatch) Ratings(context.Context, int64) ([]*watch.Rating, error) {
+	return db.ratings, nil
+}
+
+func (db *testWatch) RemoveProfile(context.Context, int64) error {
+	db.episodes, db.ratings = nil, nil
+	return nil
+}
+
+type testQueue struct {
+	m    map[int64]*watch.ImportItem
+	next int64
+}
+
+func (db *testQueue) Add(_ context.Context, item *watch.ImportItem) error {
+	db.next++
+	item.ID = db.next
+	db.m[item.ID] = item
+	return nil
+}
+
+func (db *testQueue) Get(_ context.Context, id int64) (*watch.ImportItem, error) {
+	item, ok := db.m[id]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return item, nil
+}
+
+func (db *testQueue) List(_ context.Context, profile int64) ([]*watch.ImportItem, error) {
+	items := make([]*watch.ImportItem, 0)
+	for _, item := range db.m {
+		if item.Profile == profile {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (db *testQueue) Remove(_ context.Context, id int64) error {
+	delete(db.m, id)
+	return nil
+}
+
+func (db *testQueue) RemoveProfile(_ context.Context, profile int64) error {
+	for id, item := range db.m {
+		if item.Profile == profile {
+			delete(db.m, id)
+		}
+	}
+	return nil
+}
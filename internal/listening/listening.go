@@ -0,0 +1,53 @@
+// Package listening keeps track of the podcast episodes profiles have
+// listened to.
+package listening
+
+import (
+	"context"
+
+	"tracker/internal/database"
+	"tracker/internal/types/watch"
+)
+
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+const (
+	ErrInvalidPodcast = Error("listening: podcast must be positive")
+	ErrInvalidEpisode = Error("listening: episode must have a guid")
+)
+
+// Service updates the episodes profiles have listened to.
+type Service struct {
+	listening database.ListeningDatabase
+}
+
+// NewService creates a new listening service.
+func NewService(listening database.ListeningDatabase) *Service {
+	return &Service{listening: listening}
+}
+
+// SetListened marks the episode as listened to by the profile.
+func (s *Service) SetListened(ctx context.Context, l *watch.Listen) error {
+	if l.PodcastID <= 0 {
+		return ErrInvalidPodcast
+	}
+	if l.GUID == "" {
+		return ErrInvalidEpisode
+	}
+
+	return s.listening.SetListened(ctx, l)
+}
+
+// RemoveListened marks the episode as not listened to by the profile.
+func (s *Service) RemoveListened(ctx context.Context, profile int64, podcast int, guid string) error {
+	return s.listening.RemoveListened(ctx, profile, podcast, guid)
+}
+
+// Listened lists all episodes the profile listened to.
+func (s *Service) Listened(ctx context.Context, profile int64) ([]*watch.Listen, error) {
+	return s.listening.Listened(ctx, profile)
+}
@@ -0,0 +1,73 @@
+package listening
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	"tracker/internal/types/watch"
+)
+
+func TestSetListened(t *testing.T) {
+	testCases := map[string]struct {
+		listen *watch.Listen
+		want   *watch.Listen
+		err    error
+	}{
+		"listened": {
+			listen: &watch.Listen{Profile: 1, PodcastID: 2, GUID: "episode-3"},
+			want:   &watch.Listen{Profile: 1, PodcastID: 2, GUID: "episode-3"},
+		},
+		"no podcast": {
+			listen: &watch.Listen{GUID: "episode-3"},
+			err:    ErrInvalidPodcast,
+		},
+		"no guid": {
+			listen: &watch.Listen{PodcastID: 2},
+			err:    ErrInvalidEpisode,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db := &testListening{}
+			s := NewService(db)
+
+			err := s.SetListened(context.Background(), tc.listen)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("SetListened() err = %v, want %v", err, tc.err)
+			}
+			if diff := deep.Equal(db.listen, tc.want); diff != nil {
+				t.Errorf("SetListened() diff = %v", diff)
+			}
+		})
+	}
+}
+
+type testListening struct {
+	listen *watch.Listen
+}
+
+func (db *testListening) SetListened(_ context.Context, l *watch.Listen) error {
+	db.listen = l
+	return nil
+}
+
+func (db *testListening) RemoveListened(context.Context, int64, int, string) error {
+	db.listen = nil
+	return nil
+}
+
+func (db *testListening) Listened(context.Context, int64) ([]*watch.Listen, error) {
+	if db.listen == nil {
+		return nil, nil
+	}
+	return []*watch.Listen{db.listen}, nil
+}
+
+func (db *testListening) RemoveProfile(context.Context, int64) error {
+	db.listen = nil
+	return nil
+}
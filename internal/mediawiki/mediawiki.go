@@ -0,0 +1,171 @@
+// Package mediawiki reads pages from the API of a MediaWiki site, such as
+// Wikipedia, as wikitext. Unlike the rendered pages, the templates of the
+// wikitext don't change with the skin of the site, and every page comes
+// with the ID of its revision.
+package mediawiki
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"tracker/internal/fetch"
+)
+
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// ErrMissing is returned for pages which don't exist.
+const ErrMissing = Error("mediawiki: page doesn't exist")
+
+// APIError is an error returned by the API.
+type APIError struct {
+	Code string `json:"code"`
+	Info string `json:"info"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("mediawiki: %s: %s", e.Code, e.Info)
+}
+
+const (
+	// APIPath is the path of the API on Wikipedia, which robots.txt keeps
+	// crawlers out of. See fetch.APIPaths.
+	APIPath = "/w/api.php"
+
+	// DefaultEndpoint is the API of the English Wikipedia.
+	DefaultEndpoint = "https://en.wikipedia.org" + APIPath
+)
+
+// Client reads pages from the API of a MediaWiki site.
+type Client struct {
+	endpoint string
+	fetcher  *fetch.Client
+}
+
+// New creates a client with default values, which can be overridden using
+// the options.
+func New(opts ...Option) *Client {
+	c := &Client{endpoint: DefaultEndpoint}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Page is the latest revision of a page.
+type Page struct {
+	// Title is the title of the page, after following redirects.
+	Title      string    `json:"title"`
+	RevisionID int64     `json:"revision_id"`
+	Timestamp  time.Time `json:"timestamp"`
+	Wikitext   string    `json:"-"`
+}
+
+// queryResponse is the response of action=query with formatversion=2.
+type queryResponse struct {
+	Error *APIError `json:"error"`
+	Query struct {
+		Pages []struct {
+			Title     string `json:"title"`
+			Missing   bool   `json:"missing"`
+			Invalid   bool   `json:"invalid"`
+			Revisions []struct {
+				RevID     int64     `json:"revid"`
+				Timestamp time.Time `json:"timestamp"`
+				Slots     struct {
+					Main struct {
+						Content string `json:"content"`
+					} `json:"main"`
+				} `json:"slots"`
+			} `json:"revisions"`
+		} `json:"pages"`
+	} `json:"query"`
+}
+
+// Page returns the wikitext of the latest revision of the page with the
+// title, following redirects. Titles may use underscores, as in URLs.
+func (c *Client) Page(ctx context.Context, title string) (*Page, error) {
+	params := url.Values{
+		"action":        {"query"},
+		"prop":          {"revisions"},
+		"rvprop":        {"ids|timestamp|content"},
+		"rvslots":       {"main"},
+		"titles":        {title},
+		"redirects":     {"1"},
+		"format":        {"json"},
+		"formatversion": {"2"},
+	}
+
+	body, err := c.fetch().Get(ctx, c.endpoint+"?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var resp queryResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("mediawiki: unable to decode response: %w", err)
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	if len(resp.Query.Pages) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMissing, title)
+	}
+
+	p := resp.Query.Pages[0]
+	if p.Missing || p.Invalid || len(p.Revisions) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMissing, title)
+	}
+	rev := p.Revisions[0]
+	return &Page{
+		Title:      p.Title,
+		RevisionID: rev.RevID,
+		Timestamp:  rev.Timestamp,
+		Wikitext:   rev.Slots.Main.Content,
+	}, nil
+}
+
+// fetch returns the client pages are fetched with.
+func (c *Client) fetch() *fetch.Client {
+	if c.fetcher != nil {
+		return c.fetcher
+	}
+	return fetch.Default()
+}
+
+// Option allows to modify the client.
+type Option func(*Client)
+
+// Endpoint sets the URL of the api.php of the site.
+func Endpoint(u string) Option {
+	return func(c *Client) {
+		c.endpoint = u
+	}
+}
+
+// Language reads the Wikipedia of the language, as in "de", instead of the
+// English one.
+func Language(lang string) Option {
+	return Endpoint(WikipediaEndpoint(lang))
+}
+
+// WikipediaEndpoint returns the URL of the API of the Wikipedia of the
+// language.
+func WikipediaEndpoint(lang string) string {
+	return "https://" + lang + ".wikipedia.org" + APIPath
+}
+
+// Fetcher sets the client pages are fetched with, instead of the default
+// client of fetch.
+func Fetcher(f *fetch.Client) Option {
+	return func(c *Client) {
+		c.fetcher = f
+	}
+}
@@ -0,0 +1,62 @@
+package mediawiki
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"tracker/internal/fetch"
+)
+
+// testServer serves the recorded response in testdata named by the title
+// requested.
+func testServer(t *testing.T) *Client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("action") != "query" || q.Get("formatversion") != "2" {
+			http.Error(w, "unexpected query", http.StatusBadRequest)
+			return
+		}
+		http.ServeFile(w, r, "testdata/"+q.Get("titles")+".json")
+	}))
+	t.Cleanup(srv.Close)
+
+	return New(Endpoint(srv.URL+APIPath), Fetcher(fetch.New(fetch.RateLimit(0, 0))))
+}
+
+func TestPage(t *testing.T) {
+	c := testServer(t)
+
+	p, err := c.Page(context.Background(), "page")
+	if err != nil {
+		t.Fatalf("Page() err = %v, want %v", err, nil)
+	}
+	if p.Title != "Tracker (TV series)" {
+		t.Errorf("Page().Title = %q, want %q", p.Title, "Tracker (TV series)")
+	}
+	if p.RevisionID != 1001 {
+		t.Errorf("Page().RevisionID = %d, want %d", p.RevisionID, 1001)
+	}
+	if want := time.Date(2021, time.February, 1, 10, 0, 0, 0, time.UTC); !p.Timestamp.Equal(want) {
+		t.Errorf("Page().Timestamp = %v, want %v", p.Timestamp, want)
+	}
+	if got := Templates(p.Wikitext, "Infobox television"); len(got) != 1 {
+		t.Errorf("Page().Wikitext has %d infoboxes, want 1", len(got))
+	}
+}
+
+func TestPageError(t *testing.T) {
+	c := testServer(t)
+
+	if _, err := c.Page(context.Background(), "missing"); !errors.Is(err, ErrMissing) {
+		t.Errorf("Page(missing) err = %v, want %v", err, ErrMissing)
+	}
+
+	var apiErr *APIError
+	if _, err := c.Page(context.Background(), "error"); !errors.As(err, &apiErr) || apiErr.Code != "missingtitle" {
+		t.Errorf("Page(error) err = %v, want a missingtitle error", err)
+	}
+}
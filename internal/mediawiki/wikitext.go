@@ -0,0 +1,314 @@
+package mediawiki
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"tracker/internal/timeutil"
+)
+
+// Template is a template call in wikitext, such as {{Episode list|Title=..}}.
+// Positional parameters are named by their position, starting at "1".
+type Template struct {
+	Name   string
+	Params map[string]string
+
+	// keys are the names of the parameters in the order they are written.
+	keys []string
+}
+
+// Is returns true if the template has the name, ignoring the case of the
+// first letter and spaces versus underscores, as MediaWiki does. Subpages
+// of the template, such as "Episode list/sublist", match as well.
+func (t *Template) Is(name string) bool {
+	got, want := normalizeName(t.Name), normalizeName(name)
+	return got == want || strings.HasPrefix(got, want+"/")
+}
+
+// Param returns the trimmed value of the first of the parameters which is set.
+func (t *Template) Param(names ...string) string {
+	for _, name := range names {
+		if v := strings.TrimSpace(t.Params[name]); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func normalizeName(name string) string {
+	name = strings.TrimSpace(strings.ReplaceAll(name, "_", " "))
+	name = strings.TrimPrefix(name, "Template:")
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+var commentRegexp = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+// Templates returns the templates with any of the names in the wikitext,
+// including the ones nested in parameters of other templates, in the order
+// they appear. All templates are returned if no names are given.
+func Templates(text string, names ...string) []*Template {
+	var found []*Template
+	for _, t := range parseTemplates(commentRegexp.ReplaceAllString(text, "")) {
+		if len(names) == 0 {
+			found = append(found, t)
+		}
+		for _, name := range names {
+			if t.Is(name) {
+				found = append(found, t)
+				break
+			}
+		}
+
+		// The parameters are walked in the order they are written, so that
+		// the nested templates keep their order.
+		for _, key := range t.keys {
+			found = append(found, Templates(t.Params[key], names...)...)
+		}
+	}
+	return found
+}
+
+// parseTemplates parses the top level templates of the wikitext.
+func parseTemplates(text string) []*Template {
+	var templates []*Template
+	for i := 0; i < len(text)-1; i++ {
+		if text[i] != '{' || text[i+1] != '{' {
+			continue
+		}
+		end := closing(text, i)
+		if end < 0 {
+			break
+		}
+		if t := parseTemplate(text[i+2 : end]); t != nil {
+			templates = append(templates, t)
+		}
+		i = end + 1
+	}
+	return templates
+}
+
+// closing returns the index of the }} closing the {{ at start, or -1 if it
+// isn't closed.
+func closing(text string, start int) int {
+	depth := 0
+	for i := start; i < len(text)-1; i++ {
+		switch text[i : i+2] {
+		case "{{", "[[":
+			depth++
+			i++
+		case "}}", "]]":
+			depth--
+			if depth == 0 {
+				return i
+			}
+			i++
+		}
+	}
+	return -1
+}
+
+// parseTemplate parses the inside of a template call, nil if it is a
+// template parameter such as {{{1}}}.
+func parseTemplate(inner string) *Template {
+	if strings.HasPrefix(inner, "{") {
+		return nil
+	}
+
+	parts := split(inner)
+	t := &Template{Name: strings.TrimSpace(parts[0]), Params: map[string]string{}}
+	position := 1
+	for _, part := range parts[1:] {
+		key, value, named := cut(part)
+		if !named {
+			key = strconv.Itoa(position)
+			position++
+		}
+		if _, ok := t.Params[key]; !ok {
+			t.keys = append(t.keys, key)
+		}
+		t.Params[key] = value
+	}
+	return t
+}
+
+// split splits the inside of a template at the pipes which aren't nested in
+// links or other templates.
+func split(inner string) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	for i := 0; i < len(inner); i++ {
+		if i < len(inner)-1 {
+			switch inner[i : i+2] {
+			case "{{", "[[":
+				depth++
+				i++
+				continue
+			case "}}", "]]":
+				depth--
+				i++
+				continue
+			}
+		}
+		if inner[i] == '|' && depth == 0 {
+			parts = append(parts, inner[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, inner[start:])
+}
+
+// cut splits a named parameter at its equals sign, unless the sign is nested.
+func cut(part string) (key, value string, named bool) {
+	depth := 0
+	for i := 0; i < len(part); i++ {
+		switch {
+		case strings.HasPrefix(part[i:], "{{"), strings.HasPrefix(part[i:], "[["):
+			depth++
+			i++
+		case strings.HasPrefix(part[i:], "}}"), strings.HasPrefix(part[i:], "]]"):
+			depth--
+			i++
+		case part[i] == '=' && depth == 0:
+			return strings.TrimSpace(part[:i]), strings.TrimSpace(part[i+1:]), true
+		}
+	}
+	return "", part, false
+}
+
+// Section is the text under a heading of the wikitext, up to the next
+// heading. The text before the first heading is a section of level 0.
+type Section struct {
+	Level int
+	Title string
+	Text  string
+}
+
+var headingRegexp = regexp.MustCompile(`(?m)^(={1,6})\s*(.+?)\s*(={1,6})\s*$`)
+
+// Sections splits the wikitext at its headings.
+func Sections(text string) []*Section {
+	text = commentRegexp.ReplaceAllString(text, "")
+	sections := []*Section{{}}
+	last := 0
+	for _, m := range headingRegexp.FindAllStringSubmatchIndex(text, -1) {
+		sections[len(sections)-1].Text = text[last:m[0]]
+		level := len(text[m[2]:m[3]])
+		if closing := len(text[m[6]:m[7]]); closing < level {
+			level = closing
+		}
+		sections = append(sections, &Section{Level: level, Title: Plain(text[m[4]:m[5]])})
+		last = m[1]
+	}
+	sections[len(sections)-1].Text = text[last:]
+	return sections
+}
+
+var (
+	refRegexp      = regexp.MustCompile(`(?is)<ref[^>]*/>|<ref[^>]*>.*?</ref>`)
+	tagRegexp      = regexp.MustCompile(`<[^>]+>`)
+	linkRegexp     = regexp.MustCompile(`\[\[(?:[^\]|]*\|)?([^\]]*)\]\]`)
+	externalRegexp = regexp.MustCompile(`\[https?://[^\s\]]+\s*([^\]]*)\]`)
+	quotesRegexp   = regexp.MustCompile(`'{2,}`)
+	spaceRegexp    = regexp.MustCompile(`\s+`)
+)
+
+// Plain returns the text of the wikitext, without markup, references or
+// templates. Links are replaced by their label.
+func Plain(text string) string {
+	text = commentRegexp.ReplaceAllString(text, "")
+	text = refRegexp.ReplaceAllString(text, "")
+	text = removeTemplates(text)
+	text = linkRegexp.ReplaceAllString(text, "$1")
+	text = externalRegexp.ReplaceAllString(text, "$1")
+	text = tagRegexp.ReplaceAllString(text, " ")
+	text = quotesRegexp.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	text = strings.ReplaceAll(text, "\u00a0", " ")
+	return strings.TrimSpace(spaceRegexp.ReplaceAllString(text, " "))
+}
+
+func removeTemplates(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		if strings.HasPrefix(text[i:], "{{") {
+			if end := closing(text, i); end >= 0 {
+				i = end + 1
+				continue
+			}
+		}
+		b.WriteByte(text[i])
+	}
+	return b.String()
+}
+
+// dateTemplates are the templates giving a date as year, month and day
+// parameters.
+var dateTemplates = []string{"Start date", "Start date text", "Film date", "Release date", "Dts"}
+
+// Date returns the date of a date template such as {{Start date|2021|3|6}}
+// in the wikitext, false if there is none. Templates without the day or the
+// month give a date only known to the month or the year.
+func Date(text string) (timeutil.Date, bool) {
+	for _, t := range Templates(text, dateTemplates...) {
+		year, err := strconv.Atoi(t.Param("1"))
+		if err != nil {
+			continue
+		}
+		d := timeutil.Date{Precision: timeutil.PrecisionYear}
+		month, day := 1, 1
+		if m, err := strconv.Atoi(t.Param("2")); err == nil && m >= 1 && m <= 12 {
+			month, d.Precision = m, timeutil.PrecisionMonth
+			if n, err := strconv.Atoi(t.Param("3")); err == nil {
+				day, d.Precision = n, timeutil.PrecisionDay
+			}
+		}
+		d.Time = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		return d, true
+	}
+	return timeutil.Date{}, false
+}
+
+// listTemplates are the templates listing items as their positional
+// parameters, or as a bulleted list in their first one.
+var listTemplates = []string{"Unbulleted list", "Ubl", "Plainlist", "Plain list", "Flatlist", "Hlist", "Bulleted list"}
+
+// listSplitRegexp splits the items of lists written as lines or with line
+// breaks.
+var listSplitRegexp = regexp.MustCompile(`(?i)<br\s*/?>|\n`)
+
+// List returns the items of the list in the wikitext, as plain text. Lists
+// are either list templates, such as {{Plainlist}} or {{ubl|A|B}}, bulleted
+// lines or values separated by line breaks, as in the values of infoboxes.
+func List(text string) []string {
+	text = commentRegexp.ReplaceAllString(text, "")
+	text = refRegexp.ReplaceAllString(text, "")
+
+	var items []string
+	add := func(text string) {
+		for _, line := range listSplitRegexp.Split(text, -1) {
+			if item := Plain(strings.TrimLeft(strings.TrimSpace(line), "*#")); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	for _, t := range Templates(text, listTemplates...) {
+		for i := 1; ; i++ {
+			v, ok := t.Params[strconv.Itoa(i)]
+			if !ok {
+				break
+			}
+			add(v)
+		}
+	}
+	add(removeTemplates(text))
+	return items
+}
@@ -0,0 +1,134 @@
+package mediawiki
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"tracker/internal/timeutil"
+)
+
+func TestTemplates(t *testing.T) {
+	text := `{{Episode table |background=#0047AB |episodes=
+<!-- {{Episode list|Title=Commented out}} -->
+{{Episode list
+ |EpisodeNumber = 1
+ |Title = [[Pilot (Tracker)|Pilot]]
+ |OriginalAirDate = {{Start date|2020|1|5}}
+}}
+{{episode_list/sublist|List of Tracker episodes
+ |EpisodeNumber = 2
+ |Title = a = b
+}}
+}}`
+
+	got := Templates(text, "Episode table", "Episode list")
+	want := []*Template{{
+		Name: "Episode table",
+		Params: map[string]string{
+			"background": "#0047AB",
+			"episodes":   got[0].Params["episodes"],
+		},
+	}, {
+		Name: "Episode list",
+		Params: map[string]string{
+			"EpisodeNumber":   "1",
+			"Title":           "[[Pilot (Tracker)|Pilot]]",
+			"OriginalAirDate": "{{Start date|2020|1|5}}",
+		},
+	}, {
+		Name: "episode_list/sublist",
+		Params: map[string]string{
+			"1":             "List of Tracker episodes\n ",
+			"EpisodeNumber": "2",
+			"Title":         "a = b",
+		},
+	}}
+
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Templates() diff = %v", diff)
+	}
+}
+
+func TestPlain(t *testing.T) {
+	testCases := map[string]string{
+		"Pilot": "Pilot",
+		`"[[Second Thoughts (Tracker)|Second Thoughts]]"`: `"Second Thoughts"`,
+		"[[Pilot]]<ref>{{cite web|title=Source}}</ref>":   "Pilot",
+		"'''Bold''' and ''italic''":                       "Bold and italic",
+		"Three &amp; Out<br />Part 1":                     "Three & Out Part 1",
+		"[https://example.com External] link":             "External link",
+		"TBA<!-- no title yet -->":                        "TBA",
+		"{{Abbr|TBA|To be announced}}":                    "",
+	}
+
+	for text, want := range testCases {
+		if got := Plain(text); got != want {
+			t.Errorf("Plain(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	testCases := map[string][]string{
+		"[[Drama]]": {"Drama"},
+		"[[Drama]]<br />[[Thriller (genre)|Thriller]]":                {"Drama", "Thriller"},
+		"{{ubl|[[Jane Doe]]|John Roe<ref>Source</ref>}}":              {"Jane Doe", "John Roe"},
+		"{{Plainlist|\n* [[Jane Doe]]\n* John Roe\n}}":                {"Jane Doe", "John Roe"},
+		"\n* Jane Doe\n* John Roe <!-- since season 2 -->":            {"Jane Doe", "John Roe"},
+		"{{Plainlist|\n* Jane Doe}}\n{{ubl|John Roe|Max Mustermann}}": {"Jane Doe", "John Roe", "Max Mustermann"},
+		"": nil,
+	}
+
+	for text, want := range testCases {
+		if diff := deep.Equal(List(text), want); diff != nil {
+			t.Errorf("List(%q) diff = %v", text, diff)
+		}
+	}
+}
+
+func TestDate(t *testing.T) {
+	day := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := map[string]struct {
+		want timeutil.Date
+		ok   bool
+	}{
+		"{{Start date|2020|1|5}}":            {want: timeutil.Date{Time: day(2020, time.January, 5)}, ok: true},
+		"{{start date|df=y|2021|03|06}}":     {want: timeutil.Date{Time: day(2021, time.March, 6)}, ok: true},
+		"{{Start date|2021|3}}":              {want: timeutil.Date{Time: day(2021, time.March, 1), Precision: timeutil.PrecisionMonth}, ok: true},
+		"{{Start date|2021}}":                {want: timeutil.Date{Time: day(2021, time.January, 1), Precision: timeutil.PrecisionYear}, ok: true},
+		"January 19, 2020":                   {},
+		"{{Start date|2020|1|5}}<ref></ref>": {want: timeutil.Date{Time: day(2020, time.January, 5)}, ok: true},
+	}
+
+	for text, tc := range testCases {
+		got, ok := Date(text)
+		if diff := deep.Equal(got, tc.want); diff != nil || ok != tc.ok {
+			t.Errorf("Date(%q) = %v, %v, want %v, %v", text, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestSections(t *testing.T) {
+	text := `Lead
+== Episodes ==
+=== Season 1 (2020) ===
+{{Episode table}}
+<!-- == Commented == -->
+=== [[Tracker specials|Specials]] ===
+Special`
+
+	want := []*Section{
+		{Level: 0, Title: "", Text: "Lead\n"},
+		{Level: 2, Title: "Episodes", Text: "\n"},
+		{Level: 3, Title: "Season 1 (2020)", Text: "\n{{Episode table}}\n\n"},
+		{Level: 3, Title: "Specials", Text: "\nSpecial"},
+	}
+	if diff := deep.Equal(Sections(text), want); diff != nil {
+		t.Errorf("Sections() diff = %v", diff)
+	}
+}
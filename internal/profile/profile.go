@@ -0,0 +1,128 @@
+// Package profile manages the profiles of an account, allowing multiple people
+// to share a single login.
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"tracker/internal/database"
+	"tracker/internal/types/user"
+)
+
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+const (
+	ErrNotOwner    = Error("profile: profile belongs to another account")
+	ErrInvalidName = Error("profile: invalid profile name")
+	ErrNameTaken   = Error("profile: profile name already in use")
+	ErrTooMany     = Error("profile: too many profiles")
+)
+
+const (
+	// DefaultName is the name of the profile created for new accounts.
+	DefaultName = "Default"
+	// MaxProfiles is the maximum amount of profiles per account.
+	MaxProfiles = 6
+)
+
+// Service manages the profiles of accounts.
+type Service struct {
+	profiles database.ProfilesDatabase
+}
+
+// NewService creates a new profile service using the given store.
+func NewService(profiles database.ProfilesDatabase) *Service {
+	return &Service{profiles: profiles}
+}
+
+// List all profiles of the account. If the account has no profiles yet, the
+// default profile is created. Concurrent requests of an account without
+// profiles create it only once.
+func (s *Service) List(ctx context.Context, owner string) ([]*user.Profile, error) {
+	profiles, err := s.profiles.List(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(profiles) > 0 {
+		return profiles, nil
+	}
+
+	if err := s.profiles.Ensure(ctx, &user.Profile{Owner: owner, Name: DefaultName}); err != nil {
+		return nil, fmt.Errorf("unable to create default profile: %w", err)
+	}
+	return s.profiles.List(ctx, owner)
+}
+
+// Active returns the profile with the given ID, along with all profiles of
+// the account. If the ID does not belong to the account, the first profile of
+// the account is returned instead.
+func (s *Service) Active(ctx context.Context, owner string, id int64) (*user.Profile, []*user.Profile, error) {
+	profiles, err := s.List(ctx, owner)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, p := range profiles {
+		if p.ID == id {
+			return p, profiles, nil
+		}
+	}
+	return profiles[0], profiles, nil
+}
+
+// Get the profile, verifying it belongs to the owner.
+func (s *Service) Get(ctx context.Context, owner string, id int64) (*user.Profile, error) {
+	p, err := s.profiles.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Owner != owner {
+		return nil, ErrNotOwner
+	}
+	return p, nil
+}
+
+// Create a new profile for the account.
+func (s *Service) Create(ctx context.Context, owner, name string) (*user.Profile, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 64 {
+		return nil, ErrInvalidName
+	}
+
+	profiles, err := s.List(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(profiles) >= MaxProfiles {
+		return nil, ErrTooMany
+	}
+	for _, p := range profiles {
+		if strings.EqualFold(p.Name, name) {
+			return nil, ErrNameTaken
+		}
+	}
+
+	p := &user.Profile{Owner: owner, Name: name}
+	if err := s.profiles.Create(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// SetPreferences of the profile, verifying it belongs to the owner.
+func (s *Service) SetPreferences(ctx context.Context, owner string, id int64, prefs user.Preferences) error {
+	if _, err := s.Get(ctx, owner, id); err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return ErrNotOwner
+		}
+		return err
+	}
+	return s.profiles.SetPreferences(ctx, id, prefs)
+}
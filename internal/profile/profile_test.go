@@ -0,0 +1,162 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"tracker/internal/database"
+	"tracker/internal/types/user"
+)
+
+func TestActive(t *testing.T) {
+	s := NewService(&testProfiles{m: map[int64]*user.Profile{}})
+	ctx := context.Background()
+
+	def, profiles, err := s.Active(ctx, "user@example.com", 0)
+	if err != nil {
+		t.Fatalf("Active() err = %v, want %v", err, nil)
+	}
+	if def.Name != DefaultName || len(profiles) != 1 {
+		t.Fatalf("Active() = %v, %d profiles, want %s, 1 profile", def.Name, len(profiles), DefaultName)
+	}
+
+	kid, err := s.Create(ctx, "user@example.com", "Kid")
+	if err != nil {
+		t.Fatalf("Create() err = %v, want %v", err, nil)
+	}
+	other, err := s.Create(ctx, "other@example.com", "Other")
+	if err != nil {
+		t.Fatalf("Create() err = %v, want %v", err, nil)
+	}
+
+	testCases := map[string]struct {
+		id   int64
+		want int64
+	}{
+		"own profile":     {kid.ID, kid.ID},
+		"unknown profile": {1234, def.ID},
+		"other account":   {other.ID, def.ID},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, _, err := s.Active(ctx, "user@example.com", tc.id)
+			if err != nil {
+				t.Fatalf("Active() err = %v, want %v", err, nil)
+			}
+			if got.ID != tc.want {
+				t.Errorf("Active(%d) = %d, want %d", tc.id, got.ID, tc.want)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	s := NewService(&testProfiles{m: map[int64]*user.Profile{}})
+	ctx := context.Background()
+
+	testCases := []struct {
+		name string
+		err  error
+	}{
+		{"", ErrInvalidName},
+		{"Alice", nil},
+		{"alice", ErrNameTaken},
+		{DefaultName, ErrNameTaken},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := s.Create(ctx, "user@example.com", tc.name); !errors.Is(err, tc.err) {
+				t.Errorf("Create(%q) err = %v, want %v", tc.name, err, tc.err)
+			}
+		})
+	}
+
+	for i := 0; i < MaxProfiles; i++ {
+		s.Create(ctx, "user@example.com", fmt.Sprintf("Profile %d", i))
+	}
+	if _, err := s.Create(ctx, "user@example.com", "One too many"); !errors.Is(err, ErrTooMany) {
+		t.Errorf("Create() err = %v, want %v", err, ErrTooMany)
+	}
+
+	if err := s.SetPreferences(ctx, "other@example.com", 1, user.Preferences{}); !errors.Is(err, ErrNotOwner) {
+		t.Errorf("SetPreferences() err = %v, want %v", err, ErrNotOwner)
+	}
+}
+
+func TestListConcurrently(t *testing.T) {
+	// Another request creates the default profile after this one found none.
+	db := &racingProfiles{testProfiles: &testProfiles{m: map[int64]*user.Profile{}}}
+	profiles, err := NewService(db).List(context.Background(), "user@example.com")
+	if err != nil {
+		t.Fatalf("List() err = %v, want %v", err, nil)
+	}
+	if len(profiles) != 1 {
+		t.Errorf("List() = %d profiles, want 1", len(profiles))
+	}
+}
+
+// racingProfiles creates the default profile of the account when it is
+// first listed, but lists none then.
+type racingProfiles struct {
+	*testProfiles
+	raced bool
+}
+
+func (db *racingProfiles) List(ctx context.Context, owner string) ([]*user.Profile, error) {
+	if !db.raced {
+		db.raced = true
+		return []*user.Profile{}, db.Create(ctx, &user.Profile{Owner: owner, Name: DefaultName})
+	}
+	return db.testProfiles.List(ctx, owner)
+}
+
+type testProfiles struct {
+	m map[int64]*user.Profile
+}
+
+func (db *testProfiles) Create(_ context.Context, p *user.Profile) error {
+	p.ID = int64(len(db.m) + 1)
+	db.m[p.ID] = p
+	return nil
+}
+
+func (db *testProfiles) Ensure(ctx context.Context, p *user.Profile) error {
+	for _, o := range db.m {
+		if o.Owner == p.Owner && o.Name == p.Name {
+			return nil
+		}
+	}
+	return db.Create(ctx, p)
+}
+
+func (db *testProfiles) Get(_ context.Context, id int64) (*user.Profile, error) {
+	p, ok := db.m[id]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return p, nil
+}
+
+func (db *testProfiles) List(_ context.Context, owner string) ([]*user.Profile, error) {
+	profiles := make([]*user.Profile, 0)
+	for id := int64(1); id <= int64(len(db.m)); id++ {
+		if db.m[id].Owner == owner {
+			profiles = append(profiles, db.m[id])
+		}
+	}
+	return profiles, nil
+}
+
+func (db *testProfiles) SetPreferences(_ context.Context, id int64, prefs user.Preferences) error {
+	db.m[id].Preferences = prefs
+	return nil
+}
+
+func (db *testProfiles) Delete(_ context.Context, id int64) error {
+	delete(db.m, id)
+	return nil
+}
@@ -0,0 +1,61 @@
+// Package reading keeps track of the progress of profiles through the
+// volumes of book series.
+package reading
+
+import (
+	"context"
+
+	"tracker/internal/database"
+	"tracker/internal/types/watch"
+)
+
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+const (
+	ErrInvalidState  = Error("reading: unknown reading state")
+	ErrInvalidVolume = Error("reading: volume must be positive")
+	ErrInvalidPage   = Error("reading: page can't be negative")
+)
+
+// Service updates the reading progress of profiles.
+type Service struct {
+	reading database.ReadingDatabase
+}
+
+// NewService creates a new reading service.
+func NewService(reading database.ReadingDatabase) *Service {
+	return &Service{reading: reading}
+}
+
+// SetProgress updates how far the profile is through the volume. Finishing a
+// volume forgets the page the profile was on.
+func (s *Service) SetProgress(ctx context.Context, p *watch.Progress) error {
+	if !p.State.Valid() {
+		return ErrInvalidState
+	}
+	if p.Volume <= 0 {
+		return ErrInvalidVolume
+	}
+	if p.Page < 0 {
+		return ErrInvalidPage
+	}
+	if p.State == watch.Finished {
+		p.Page = 0
+	}
+
+	return s.reading.SetProgress(ctx, p)
+}
+
+// RemoveProgress marks the volume as not read by the profile.
+func (s *Service) RemoveProgress(ctx context.Context, profile int64, series, volume int) error {
+	return s.reading.RemoveProgress(ctx, profile, series, volume)
+}
+
+// Progress lists the progress of the profile through all volumes.
+func (s *Service) Progress(ctx context.Context, profile int64) ([]*watch.Progress, error) {
+	return s.reading.Progress(ctx, profile)
+}
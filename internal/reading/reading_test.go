@@ -0,0 +1,81 @@
+package reading
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	"tracker/internal/types/watch"
+)
+
+func TestSetProgress(t *testing.T) {
+	testCases := map[string]struct {
+		progress *watch.Progress
+		want     *watch.Progress
+		err      error
+	}{
+		"reading": {
+			progress: &watch.Progress{Profile: 1, SeriesID: 2, Volume: 3, State: watch.Reading, Page: 42},
+			want:     &watch.Progress{Profile: 1, SeriesID: 2, Volume: 3, State: watch.Reading, Page: 42},
+		},
+		"finished": {
+			progress: &watch.Progress{Profile: 1, SeriesID: 2, Volume: 3, State: watch.Finished, Page: 42},
+			want:     &watch.Progress{Profile: 1, SeriesID: 2, Volume: 3, State: watch.Finished},
+		},
+		"unknown state": {
+			progress: &watch.Progress{Volume: 1, State: "skimmed"},
+			err:      ErrInvalidState,
+		},
+		"no volume": {
+			progress: &watch.Progress{State: watch.Reading},
+			err:      ErrInvalidVolume,
+		},
+		"negative page": {
+			progress: &watch.Progress{Volume: 1, State: watch.Reading, Page: -1},
+			err:      ErrInvalidPage,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			db := &testReading{}
+			s := NewService(db)
+
+			err := s.SetProgress(context.Background(), tc.progress)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("SetProgress() err = %v, want %v", err, tc.err)
+			}
+			if diff := deep.Equal(db.progress, tc.want); diff != nil {
+				t.Errorf("SetProgress() diff = %v", diff)
+			}
+		})
+	}
+}
+
+type testReading struct {
+	progress *watch.Progress
+}
+
+func (db *testReading) SetProgress(_ context.Context, p *watch.Progress) error {
+	db.progress = p
+	return nil
+}
+
+func (db *testReading) RemoveProgress(context.Context, int64, int, int) error {
+	db.progress = nil
+	return nil
+}
+
+func (db *testReading) Progress(context.Context, int64) ([]*watch.Progress, error) {
+	if db.progress == nil {
+		return nil, nil
+	}
+	return []*watch.Progress{db.progress}, nil
+}
+
+func (db *testReading) RemoveProfile(context.Context, int64) error {
+	db.progress = nil
+	return nil
+}
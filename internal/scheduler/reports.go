@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"embed"
+	"encoding/json"
+	"html/template"
+	"net/http"
+
+	"tracker/trackable"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var reportsTemplate = template.Must(template.ParseFS(templates, "templates/reports.html"))
+
+// Reports are the latest scrape reports, and the trackables with problems.
+type Reports struct {
+	Recent   []*trackable.Report `json:"recent"`
+	Problems []*trackable.Report `json:"problems"`
+}
+
+// ReportsHandler serves the latest limit reports and the problems of the
+// store, as a page or as JSON with format=json.
+type ReportsHandler struct {
+	Store ReportStore
+	Limit int
+}
+
+func (h *ReportsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	recent, err := h.Store.Reports(r.Context(), h.Limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	problems, err := h.Store.Problems(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	reports := &Reports{Recent: recent, Problems: problems}
+
+	if r.URL.Query().Get("format") == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(reports); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := reportsTemplate.Execute(w, reports); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,479 @@
+// Package scheduler scrapes trackables continuously, each at an interval
+// depending on how actively it is releasing.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"tracker/trackable"
+)
+
+// DefaultIntervals between two scrapes of a trackable, by its status. A zero
+// interval means trackables with the status are only scraped once.
+var DefaultIntervals = map[trackable.Status]time.Duration{
+	trackable.Active:   6 * time.Hour,
+	trackable.Upcoming: 24 * time.Hour,
+	trackable.Idle:     7 * 24 * time.Hour,
+	trackable.Finished: 30 * 24 * time.Hour,
+}
+
+// State of the scraping of a single trackable.
+type State struct {
+	trackable.Ref
+	Status trackable.Status `json:"status"`
+
+	LastRun time.Time `json:"last_run"`
+	NextRun time.Time `json:"next_run"`
+
+	// Failures counts the scrapes which failed in a row, the last one with
+	// LastError.
+	Failures  int    `json:"failures"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+// Due returns true if the trackable should be scraped at the given time.
+func (s *State) Due(now time.Time) bool {
+	return s.LastRun.IsZero() || (!s.NextRun.IsZero() && !s.NextRun.After(now))
+}
+
+// Store persists the state of the scheduler between runs.
+type Store interface {
+	// States returns the state of every trackable scraped before.
+	States(ctx context.Context) ([]*State, error)
+	// SetState replaces the state of the trackable.
+	SetState(ctx context.Context, s *State) error
+}
+
+// ReportStore keeps the reports of scrapes. Stores which implement it also
+// keep the report of every scrape of the scheduler.
+type ReportStore interface {
+	AddReport(ctx context.Context, r *trackable.Report) error
+
+	// Reports returns the latest reports, newest first.
+	Reports(ctx context.Context, limit int) ([]*trackable.Report, error)
+
+	// Problems returns the latest report of every trackable whose latest
+	// scrape had a problem, newest first.
+	Problems(ctx context.Context) ([]*trackable.Report, error)
+}
+
+// Scheduler scrapes the trackables of the registered kinds when they are due.
+type Scheduler struct {
+	store Store
+	log   *zap.Logger
+	now   func() time.Time
+
+	modules   []*trackable.Module
+	intervals map[trackable.Status]time.Duration
+	poll      time.Duration
+	reload    time.Duration
+	retry     time.Duration
+	timeout   time.Duration
+	workers   int
+	notify    func(*trackable.Diff)
+
+	mu       sync.RWMutex
+	items    map[trackable.Ref]trackable.Trackable
+	states   map[trackable.Ref]*State
+	restored bool
+	loaded   time.Time
+	started  time.Time
+	running  map[trackable.Ref]bool
+	lastPoll time.Time
+}
+
+// New creates a scheduler for all registered kinds, persisting its state in
+// the store. The options allow to override the defaults.
+func New(store Store, opts ...Option) *Scheduler {
+	s := &Scheduler{
+		store:     store,
+		log:       zap.NewNop(),
+		now:       time.Now,
+		modules:   trackable.Modules(),
+		intervals: DefaultIntervals,
+		poll:      time.Minute,
+		reload:    time.Hour,
+		retry:     30 * time.Minute,
+		timeout:   5 * time.Minute,
+		workers:   1,
+		items:     map[trackable.Ref]trackable.Trackable{},
+		states:    map[trackable.Ref]*State{},
+		running:   map[trackable.Ref]bool{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Run scrapes due trackables until the context is cancelled.
+func (s *Scheduler) Run(ctx context.Context) error {
+	s.mu.Lock()
+	s.started = s.now()
+	s.mu.Unlock()
+
+	if err := s.restore(ctx); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(s.poll)
+	defer ticker.Stop()
+	for {
+		if err := s.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			s.log.Error("unable to run scheduler", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// restore loads the persisted state of every trackable.
+func (s *Scheduler) restore(ctx context.Context) error {
+	states, err := s.store.States(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to load scheduler state: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, st := range states {
+		s.states[st.Ref] = st
+	}
+	s.restored = true
+	return nil
+}
+
+// ScrapeNow scrapes and persists the trackable right away, whether it is due
+// or not, and schedules its next scrape as the daemon would. Its report is
+// kept if the store keeps reports.
+func (s *Scheduler) ScrapeNow(ctx context.Context, item trackable.Trackable) (*trackable.Report, error) {
+	s.mu.RLock()
+	restored := s.restored
+	s.mu.RUnlock()
+	if !restored {
+		if err := s.restore(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return s.scrape(ctx, item)
+}
+
+// ScrapeAll scrapes and persists every trackable right away, whether it is
+// due or not, using the workers of the scheduler, and schedules their next
+// scrapes as the daemon would. It returns an error if a kind failed to load,
+// or a trackable to be scraped, once the others are.
+func (s *Scheduler) ScrapeAll(ctx context.Context) error {
+	if err := s.restore(ctx); err != nil {
+		return err
+	}
+	unloaded := s.load(ctx, s.now())
+
+	s.mu.RLock()
+	items := make([]trackable.Trackable, 0, len(s.items))
+	for _, item := range s.items {
+		items = append(items, item)
+	}
+	s.mu.RUnlock()
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i].Ref(), items[j].Ref()
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.ID < b.ID
+	})
+
+	failed := s.scrapeAll(ctx, items)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if unloaded > 0 || failed > 0 {
+		return fmt.Errorf("%d kind(s) failed to load, %d of %d trackable(s) failed to scrape",
+			unloaded, failed, len(items))
+	}
+	return nil
+}
+
+// RunOnce scrapes every trackable which is due, using the workers of the
+// scheduler. A trackable which fails is retried later, and doesn't stop the
+// others from being scraped.
+func (s *Scheduler) RunOnce(ctx context.Context) error {
+	now := s.now()
+	s.mu.Lock()
+	s.lastPoll = now
+	s.mu.Unlock()
+
+	if now.Sub(s.loadedAt()) >= s.reload {
+		s.load(ctx, now)
+	}
+
+	s.scrapeAll(ctx, s.due(now))
+	return ctx.Err()
+}
+
+// scrapeAll scrapes the trackables in order, using the workers of the
+// scheduler, until they are all scraped or the context is cancelled. It
+// returns the number of trackables which failed.
+func (s *Scheduler) scrapeAll(ctx context.Context, items []trackable.Trackable) int {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed int
+	)
+	queue := make(chan trackable.Trackable)
+	for i := 0; i < s.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range queue {
+				if _, err := s.scrape(ctx, item); err != nil {
+					mu.Lock()
+					failed++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for _, item := range items {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case queue <- item:
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	return failed
+}
+
+func (s *Scheduler) loadedAt() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.loaded
+}
+
+// load the trackables of every kind, picking up ones added since the last
+// load. A kind which fails to load keeps its previous trackables. It returns
+// the number of kinds which failed.
+func (s *Scheduler) load(ctx context.Context, now time.Time) int {
+	failed := 0
+	for _, m := range s.modules {
+		items, err := m.Load(ctx)
+		if err != nil {
+			s.log.Error("unable to load trackables", zap.String("kind", string(m.Kind)),
+				zap.Error(err))
+			failed++
+			continue
+		}
+
+		s.mu.Lock()
+		for ref := range s.items {
+			if ref.Kind == m.Kind {
+				delete(s.items, ref)
+			}
+		}
+		for _, item := range items {
+			s.items[item.Ref()] = item
+		}
+		s.mu.Unlock()
+	}
+
+	s.mu.Lock()
+	s.loaded = now
+	s.mu.Unlock()
+	return failed
+}
+
+// due returns the trackables to scrape, those never scraped first.
+func (s *Scheduler) due(now time.Time) []trackable.Trackable {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	due := make([]trackable.Trackable, 0)
+	for ref, item := range s.items {
+		if st, ok := s.states[ref]; !ok || st.Due(now) {
+			due = append(due, item)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		a, b := s.states[due[i].Ref()], s.states[due[j].Ref()]
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return a.NextRun.Before(b.NextRun)
+	})
+	return due
+}
+
+// scrape and persist a single trackable, then schedule its next scrape.
+func (s *Scheduler) scrape(ctx context.Context, item trackable.Trackable) (*trackable.Report, error) {
+	ref := item.Ref()
+	s.mu.Lock()
+	s.running[ref] = true
+	st, ok := s.states[ref]
+	if !ok {
+		st = &State{Ref: ref}
+	}
+	next := *st
+	s.mu.Unlock()
+
+	report, err := s.safeScrape(ctx, item)
+	if reports, ok := s.store.(ReportStore); ok {
+		if err := reports.AddReport(ctx, report); err != nil {
+			s.log.Error("unable to save scrape report", zap.Stringer("ref", ref), zap.Error(err))
+		}
+	}
+
+	diff := report.Diff
+	now := s.now()
+	next.LastRun = now
+	next.Status = status(item, now)
+	if err != nil {
+		next.Failures++
+		next.LastError = err.Error()
+		next.NextRun = now.Add(s.retryAfter(next.Status, next.Failures))
+		s.log.Warn("unable to scrape", zap.Stringer("ref", ref), zap.Error(err))
+	} else {
+		if !diff.Empty() {
+			s.log.Info("releases changed", zap.Stringer("diff", diff))
+			if s.notify != nil {
+				s.notify(diff)
+			}
+		}
+		if report.Problem() {
+			s.log.Warn("scraped with problems", zap.Stringer("ref", ref),
+				zap.Int("skipped", len(report.Skipped)), zap.Int("warnings", len(report.Warnings)))
+		}
+		next.Failures = 0
+		next.LastError = ""
+		next.NextRun = time.Time{}
+		if interval := s.intervals[next.Status]; interval > 0 {
+			next.NextRun = now.Add(interval)
+		}
+	}
+
+	if err := s.store.SetState(ctx, &next); err != nil {
+		s.log.Error("unable to save scheduler state", zap.Stringer("ref", ref), zap.Error(err))
+	}
+
+	s.mu.Lock()
+	s.states[ref] = &next
+	delete(s.running, ref)
+	s.mu.Unlock()
+	return report, err
+}
+
+// safeScrape scrapes and writes the trackable, turning panics into errors so
+// a single trackable can't bring down the daemon.
+func (s *Scheduler) safeScrape(ctx context.Context, item trackable.Trackable) (r *trackable.Report, err error) {
+	started := time.Now()
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("panic: %v", rec)
+			r = &trackable.Report{Ref: item.Ref(), Started: started.UTC(),
+				Duration: time.Since(started), Error: err.Error()}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return trackable.Scrape(ctx, item)
+}
+
+// retryAfter backs off linearly with the number of failures, but never waits
+// longer than the interval of the status.
+func (s *Scheduler) retryAfter(st trackable.Status, failures int) time.Duration {
+	retry := time.Duration(failures) * s.retry
+	if interval := s.intervals[st]; interval > 0 && retry > interval {
+		return interval
+	}
+	return retry
+}
+
+// status of the trackable, idle unless it knows better.
+func status(item trackable.Trackable, now time.Time) trackable.Status {
+	if st, ok := item.(trackable.Statuser); ok {
+		return st.Status(now)
+	}
+	return trackable.Idle
+}
+
+// Option allows to modify the scheduler.
+type Option func(*Scheduler)
+
+// Logger sets the logger of the scheduler.
+func Logger(log *zap.Logger) Option {
+	return func(s *Scheduler) {
+		s.log = log
+	}
+}
+
+// Modules limits the scheduler to the given kinds.
+func Modules(modules ...*trackable.Module) Option {
+	return func(s *Scheduler) {
+		s.modules = modules
+	}
+}
+
+// Intervals overrides the interval between scrapes of the given statuses.
+func Intervals(intervals map[trackable.Status]time.Duration) Option {
+	return func(s *Scheduler) {
+		merged := make(map[trackable.Status]time.Duration, len(DefaultIntervals))
+		for st, d := range DefaultIntervals {
+			merged[st] = d
+		}
+		for st, d := range intervals {
+			merged[st] = d
+		}
+		s.intervals = merged
+	}
+}
+
+// PollInterval sets how often the scheduler checks for due trackables.
+func PollInterval(d time.Duration) Option {
+	return func(s *Scheduler) {
+		s.poll = d
+	}
+}
+
+// Workers sets how many trackables are scraped at once.
+func Workers(n int) Option {
+	return func(s *Scheduler) {
+		if n > 0 {
+			s.workers = n
+		}
+	}
+}
+
+// Notify calls fn with the diff of every trackable whose releases changed
+// when it was written, for example to notify its followers.
+func Notify(fn func(*trackable.Diff)) Option {
+	return func(s *Scheduler) {
+		s.notify = fn
+	}
+}
+
+// Clock overrides the current time, for tests.
+func Clock(now func() time.Time) Option {
+	return func(s *Scheduler) {
+		s.now = now
+	}
+}
@@ -0,0 +1,342 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"tracker/trackable"
+)
+
+type testTrackable struct {
+	id     int
+	status trackable.Status
+	err    error
+	panics bool
+
+	scrapes int
+}
+
+func (t *testTrackable) Ref() trackable.Ref {
+	return trackable.Ref{Kind: "test", ID: t.id}
+}
+
+func (t *testTrackable) Scrape(context.Context) error {
+	t.scrapes++
+	if t.panics {
+		panic("nil table")
+	}
+	return t.err
+}
+
+func (t *testTrackable) Write() error                                       { return nil }
+func (t *testTrackable) Releases(time.Time, time.Time) []*trackable.Release { return nil }
+
+func (t *testTrackable) Status(time.Time) trackable.Status {
+	return t.status
+}
+
+// testDiffer reports a diff every time it is written.
+type testDiffer struct {
+	testTrackable
+}
+
+func (t *testDiffer) WriteDiff(context.Context) (*trackable.Diff, error) {
+	return &trackable.Diff{Ref: t.Ref(), Added: []*trackable.Release{{Ref: t.Ref(), Title: "New"}}}, nil
+}
+
+type testStore struct {
+	states  map[trackable.Ref]*State
+	reports []*trackable.Report
+}
+
+func (s *testStore) States(context.Context) ([]*State, error) {
+	states := make([]*State, 0)
+	for _, st := range s.states {
+		states = append(states, st)
+	}
+	return states, nil
+}
+
+func (s *testStore) SetState(_ context.Context, st *State) error {
+	s.states[st.Ref] = st
+	return nil
+}
+
+func (s *testStore) AddReport(_ context.Context, r *trackable.Report) error {
+	s.reports = append(s.reports, r)
+	return nil
+}
+
+func (s *testStore) Reports(_ context.Context, limit int) ([]*trackable.Report, error) {
+	if len(s.reports) > limit {
+		return s.reports[:limit], nil
+	}
+	return s.reports, nil
+}
+
+func (s *testStore) Problems(context.Context) ([]*trackable.Report, error) {
+	problems := make([]*trackable.Report, 0)
+	for _, r := range s.reports {
+		if r.Problem() {
+			problems = append(problems, r)
+		}
+	}
+	return problems, nil
+}
+
+func testScheduler(store *testStore, now *time.Time, items []trackable.Trackable, opts ...Option) *Scheduler {
+	m := &trackable.Module{
+		Kind: "test",
+		Load: func(context.Context) ([]trackable.Trackable, error) {
+			return items, nil
+		},
+	}
+
+	return New(store, append([]Option{
+		Modules(m),
+		Workers(2),
+		Intervals(map[trackable.Status]time.Duration{trackable.Finished: 0}),
+		Clock(func() time.Time { return *now }),
+	}, opts...)...)
+}
+
+func TestRunOnce(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	airing := &testTrackable{id: 1, status: trackable.Active}
+	upcoming := &testTrackable{id: 2, status: trackable.Upcoming}
+	finished := &testTrackable{id: 3, status: trackable.Finished}
+	failing := &testTrackable{id: 4, status: trackable.Active, err: errors.New("unreachable")}
+	panics := &testTrackable{id: 5, status: trackable.Idle, panics: true}
+
+	store := &testStore{states: map[trackable.Ref]*State{}}
+	s := testScheduler(store, &now, []trackable.Trackable{airing, upcoming, finished, failing, panics})
+
+	// Every trackable is scraped on the first run, whatever its status.
+	if err := s.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce() err = %v, want %v", err, nil)
+	}
+
+	want := map[trackable.Ref]*State{
+		airing.Ref(): {Ref: airing.Ref(), Status: trackable.Active, LastRun: now,
+			NextRun: now.Add(6 * time.Hour)},
+		upcoming.Ref(): {Ref: upcoming.Ref(), Status: trackable.Upcoming, LastRun: now,
+			NextRun: now.Add(24 * time.Hour)},
+		finished.Ref(): {Ref: finished.Ref(), Status: trackable.Finished, LastRun: now},
+		failing.Ref(): {Ref: failing.Ref(), Status: trackable.Active, LastRun: now,
+			NextRun: now.Add(30 * time.Minute), Failures: 1,
+			LastError: "unable to scrape: unreachable"},
+		panics.Ref(): {Ref: panics.Ref(), Status: trackable.Idle, LastRun: now,
+			NextRun: now.Add(30 * time.Minute), Failures: 1,
+			LastError: "panic: nil table"},
+	}
+	if diff := deep.Equal(store.states, want); diff != nil {
+		t.Errorf("RunOnce() states diff = %v", diff)
+	}
+
+	// Seven hours later, only the airing and failing trackables are due.
+	now = now.Add(7 * time.Hour)
+	if err := s.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce() err = %v, want %v", err, nil)
+	}
+
+	wantScrapes := map[int]int{1: 2, 2: 1, 3: 1, 4: 2, 5: 2}
+	for _, item := range []*testTrackable{airing, upcoming, finished, failing, panics} {
+		if item.scrapes != wantScrapes[item.id] {
+			t.Errorf("%s scrapes = %d, want %d", item.Ref(), item.scrapes, wantScrapes[item.id])
+		}
+	}
+
+	// Failures back off, but never beyond the interval of the status.
+	if got, want := store.states[failing.Ref()].NextRun, now.Add(time.Hour); !got.Equal(want) {
+		t.Errorf("failing next run = %v, want %v", got, want)
+	}
+	if got, want := store.states[failing.Ref()].Failures, 2; got != want {
+		t.Errorf("failing failures = %d, want %d", got, want)
+	}
+}
+
+func TestNotify(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	changed := &testDiffer{testTrackable{id: 1, status: trackable.Active}}
+	unchanged := &testTrackable{id: 2, status: trackable.Active, err: trackable.ErrUnchanged}
+
+	var diffs []*trackable.Diff
+	store := &testStore{states: map[trackable.Ref]*State{}}
+	s := testScheduler(store, &now, []trackable.Trackable{changed, unchanged},
+		Workers(1), Notify(func(d *trackable.Diff) { diffs = append(diffs, d) }))
+
+	if err := s.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce() err = %v, want %v", err, nil)
+	}
+
+	want := []*trackable.Diff{{
+		Ref:   changed.Ref(),
+		Added: []*trackable.Release{{Ref: changed.Ref(), Title: "New"}},
+	}}
+	if diff := deep.Equal(diffs, want); diff != nil {
+		t.Errorf("notified diff = %v", diff)
+	}
+	if st := store.states[unchanged.Ref()]; st.Failures != 0 {
+		t.Errorf("unchanged failures = %d, want %d", st.Failures, 0)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	item := &testTrackable{id: 1, status: trackable.Active}
+	store := &testStore{states: map[trackable.Ref]*State{
+		item.Ref(): {Ref: item.Ref(), Status: trackable.Active,
+			LastRun: now.Add(-time.Hour), NextRun: now.Add(5 * time.Hour)},
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s := testScheduler(store, &now, []trackable.Trackable{item})
+	if err := s.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() err = %v, want %v", err, context.Canceled)
+	}
+	if item.scrapes != 0 {
+		t.Errorf("scrapes = %d, want %d", item.scrapes, 0)
+	}
+}
+
+func TestScrapeNow(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	item := &testTrackable{id: 1, status: trackable.Active, err: errors.New("unreachable")}
+	store := &testStore{states: map[trackable.Ref]*State{
+		item.Ref(): {Ref: item.Ref(), Status: trackable.Active, LastRun: now.Add(-time.Hour),
+			NextRun: now.Add(5 * time.Hour), Failures: 2, LastError: "unable to scrape: unreachable"},
+	}}
+	s := testScheduler(store, &now, nil)
+
+	// The failures so far are restored, even though the trackable isn't due.
+	if _, err := s.ScrapeNow(context.Background(), item); err == nil {
+		t.Fatalf("ScrapeNow() err = %v, want an error", err)
+	}
+	if got := store.states[item.Ref()].Failures; got != 3 {
+		t.Errorf("ScrapeNow() failures = %d, want %d", got, 3)
+	}
+
+	item.err = nil
+	r, err := s.ScrapeNow(context.Background(), item)
+	if err != nil {
+		t.Fatalf("ScrapeNow() err = %v, want %v", err, nil)
+	}
+	want := &State{Ref: item.Ref(), Status: trackable.Active, LastRun: now, NextRun: now.Add(6 * time.Hour)}
+	if diff := deep.Equal(store.states[item.Ref()], want); diff != nil {
+		t.Errorf("ScrapeNow() state diff = %v", diff)
+	}
+	if len(store.reports) != 2 || store.reports[1] != r {
+		t.Errorf("ScrapeNow() reports = %v, want the report of every scrape", store.reports)
+	}
+}
+
+func TestScrapeAll(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	scraped := &testTrackable{id: 1, status: trackable.Active}
+	failing := &testTrackable{id: 2, status: trackable.Active, err: errors.New("unreachable")}
+	store := &testStore{states: map[trackable.Ref]*State{
+		scraped.Ref(): {Ref: scraped.Ref(), Status: trackable.Active, LastRun: now.Add(-time.Hour),
+			NextRun: now.Add(5 * time.Hour)},
+	}}
+	s := testScheduler(store, &now, []trackable.Trackable{scraped, failing})
+
+	// Trackables which aren't due are scraped too, and the failures kept.
+	if err := s.ScrapeAll(context.Background()); err == nil {
+		t.Fatalf("ScrapeAll() err = %v, want an error", err)
+	}
+	want := map[trackable.Ref]*State{
+		scraped.Ref(): {Ref: scraped.Ref(), Status: trackable.Active, LastRun: now,
+			NextRun: now.Add(6 * time.Hour)},
+		failing.Ref(): {Ref: failing.Ref(), Status: trackable.Active, LastRun: now,
+			NextRun: now.Add(30 * time.Minute), Failures: 1,
+			LastError: "unable to scrape: unreachable"},
+	}
+	if diff := deep.Equal(store.states, want); diff != nil {
+		t.Errorf("ScrapeAll() states diff = %v", diff)
+	}
+	if len(store.reports) != 2 {
+		t.Errorf("ScrapeAll() reports = %d, want %d", len(store.reports), 2)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	ok := &testTrackable{id: 1, status: trackable.Active}
+	failing := &testTrackable{id: 2, status: trackable.Upcoming, err: errors.New("unreachable")}
+
+	store := &testStore{states: map[trackable.Ref]*State{}}
+	s := testScheduler(store, &now, []trackable.Trackable{ok, failing})
+	if err := s.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce() err = %v, want %v", err, nil)
+	}
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/status", nil))
+
+	var got Status
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("unable to decode status: %v", err)
+	}
+	if got.Trackables != 2 || got.Failing != 1 {
+		t.Errorf("Status() trackables = %d, failing = %d, want %d and %d",
+			got.Trackables, got.Failing, 2, 1)
+	}
+	if len(got.States) != 2 || got.States[0].Ref != failing.Ref() {
+		t.Errorf("Status() states = %v, want the failing trackable first", got.States)
+	}
+}
+
+func TestReports(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	ok := &testTrackable{id: 1, status: trackable.Active}
+	failing := &testTrackable{id: 2, status: trackable.Active, err: errors.New("unreachable")}
+	panics := &testTrackable{id: 3, status: trackable.Active, panics: true}
+
+	store := &testStore{states: map[trackable.Ref]*State{}}
+	s := testScheduler(store, &now, []trackable.Trackable{ok, failing, panics}, Workers(1))
+	if err := s.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce() err = %v, want %v", err, nil)
+	}
+
+	errs := map[trackable.Ref]string{}
+	for _, r := range store.reports {
+		errs[r.Ref] = r.Error
+	}
+	want := map[trackable.Ref]string{
+		ok.Ref():      "",
+		failing.Ref(): "unable to scrape: unreachable",
+		panics.Ref():  "panic: nil table",
+	}
+	if diff := deep.Equal(errs, want); diff != nil {
+		t.Errorf("report errors diff = %v", diff)
+	}
+
+	h := &ReportsHandler{Store: store, Limit: 10}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/reports?format=json", nil))
+
+	var got Reports
+	if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+		t.Fatalf("unable to decode reports: %v", err)
+	}
+	if len(got.Recent) != 3 || len(got.Problems) != 2 {
+		t.Errorf("Reports() recent = %d, problems = %d, want %d and %d",
+			len(got.Recent), len(got.Problems), 3, 2)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/reports", nil))
+	if body := w.Body.String(); w.Code != http.StatusOK || !strings.Contains(body, "panic: nil table") {
+		t.Errorf("ServeHTTP() = %d %q, want the problems listed", w.Code, body)
+	}
+}
@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"tracker/trackable"
+)
+
+// Status of the scheduler, and of every trackable it knows about.
+type Status struct {
+	Started  time.Time       `json:"started"`
+	LastPoll time.Time       `json:"last_poll"`
+	Running  []trackable.Ref `json:"running"`
+
+	Trackables int `json:"trackables"`
+	Failing    int `json:"failing"`
+
+	States []*State `json:"states"`
+}
+
+// Status returns a snapshot of the state of the scheduler. States are ordered
+// by their next run, those which won't run again last.
+func (s *Scheduler) Status() *Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	status := &Status{
+		Started:    s.started,
+		LastPoll:   s.lastPoll,
+		Running:    make([]trackable.Ref, 0, len(s.running)),
+		Trackables: len(s.items),
+		States:     make([]*State, 0, len(s.states)),
+	}
+	for ref := range s.running {
+		status.Running = append(status.Running, ref)
+	}
+	sort.Slice(status.Running, func(i, j int) bool {
+		return status.Running[i].String() < status.Running[j].String()
+	})
+	for _, st := range s.states {
+		copied := *st
+		status.States = append(status.States, &copied)
+		if st.Failures > 0 {
+			status.Failing++
+		}
+	}
+
+	sort.Slice(status.States, func(i, j int) bool {
+		a, b := status.States[i].NextRun, status.States[j].NextRun
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero() && b.IsZero()
+		}
+		return a.Before(b)
+	})
+	return status
+}
+
+// ServeHTTP serves the status of the scheduler as JSON.
+func (s *Scheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.Status()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,187 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"tracker/trackable"
+)
+
+// reportRetention is how long reports are kept.
+const reportRetention = 30 * 24 * time.Hour
+
+// SQLStore persists the state of the scheduler in the tracker database.
+type SQLStore struct {
+	db *sql.DB
+}
+
+// NewSQLStore creates a store using the tracker database.
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+func (s *SQLStore) States(ctx context.Context) ([]*State, error) {
+	rows, err := s.db.QueryContext(ctx, statesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query scrape state: %w", err)
+	}
+	defer rows.Close()
+
+	states := make([]*State, 0)
+	for rows.Next() {
+		st := &State{}
+		var lastRun, nextRun sql.NullTime
+		if err := rows.Scan(&st.Kind, &st.ID, &st.Status, &lastRun, &nextRun,
+			&st.Failures, &st.LastError); err != nil {
+			return nil, fmt.Errorf("unable to scan scrape state: %w", err)
+		}
+		st.LastRun = lastRun.Time
+		st.NextRun = nextRun.Time
+		states = append(states, st)
+	}
+
+	return states, rows.Err()
+}
+
+func (s *SQLStore) SetState(ctx context.Context, st *State) error {
+	if _, err := s.db.ExecContext(ctx, setStateQuery, st.Kind, st.ID, st.Status,
+		sql.NullTime{Time: st.LastRun, Valid: !st.LastRun.IsZero()},
+		sql.NullTime{Time: st.NextRun, Valid: !st.NextRun.IsZero()},
+		st.Failures, st.LastError); err != nil {
+		return fmt.Errorf("unable to set scrape state of %s: %w", st.Ref, err)
+	}
+
+	return nil
+}
+
+// AddReport stores the report, and drops the reports of the trackable which
+// are past their retention.
+func (s *SQLStore) AddReport(ctx context.Context, r *trackable.Report) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("unable to encode report of %s: %w", r.Ref, err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, addReportQuery, r.Kind, r.ID, r.Started,
+		r.Problem(), data); err != nil {
+		return fmt.Errorf("unable to add report of %s: %w", r.Ref, err)
+	}
+	if _, err := s.db.ExecContext(ctx, pruneReportsQuery, r.Kind, r.ID,
+		r.Started.Add(-reportRetention)); err != nil {
+		return fmt.Errorf("unable to prune reports of %s: %w", r.Ref, err)
+	}
+	return nil
+}
+
+func (s *SQLStore) Reports(ctx context.Context, limit int) ([]*trackable.Report, error) {
+	return s.queryReports(ctx, reportsQuery, limit)
+}
+
+func (s *SQLStore) Problems(ctx context.Context) ([]*trackable.Report, error) {
+	return s.queryReports(ctx, problemsQuery)
+}
+
+func (s *SQLStore) queryReports(ctx context.Context, query string, args ...interface{}) ([]*trackable.Report, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query scrape reports: %w", err)
+	}
+	defer rows.Close()
+
+	reports := make([]*trackable.Report, 0)
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("unable to scan scrape report: %w", err)
+		}
+		r := &trackable.Report{}
+		if err := json.Unmarshal(data, r); err != nil {
+			return nil, fmt.Errorf("unable to decode scrape report: %w", err)
+		}
+		reports = append(reports, r)
+	}
+
+	return reports, rows.Err()
+}
+
+var (
+	_ Store       = &SQLStore{}
+	_ ReportStore = &SQLStore{}
+)
+
+const statesQuery = `
+SELECT
+	kind,
+	trackable_id,
+	status,
+	last_run,
+	next_run,
+	failures,
+	COALESCE(last_error, '')
+FROM scrape_state;
+`
+
+const setStateQuery = `
+REPLACE INTO scrape_state (
+	kind,
+	trackable_id,
+	status,
+	last_run,
+	next_run,
+	failures,
+	last_error
+) VALUES (
+	?,
+	?,
+	?,
+	?,
+	?,
+	?,
+	?
+);
+`
+
+const addReportQuery = `
+INSERT INTO scrape_reports (
+	kind,
+	trackable_id,
+	started,
+	problem,
+	report
+) VALUES (
+	?,
+	?,
+	?,
+	?,
+	?
+);
+`
+
+const pruneReportsQuery = `
+DELETE FROM scrape_reports
+WHERE kind = ? AND trackable_id = ? AND started < ?;
+`
+
+const reportsQuery = `
+SELECT report
+FROM scrape_reports
+ORDER BY started DESC
+LIMIT ?;
+`
+
+const problemsQuery = `
+SELECT r.report
+FROM scrape_reports r
+JOIN (
+	SELECT kind, trackable_id, MAX(started) AS started
+	FROM scrape_reports
+	GROUP BY kind, trackable_id
+) latest ON latest.kind = r.kind
+	AND latest.trackable_id = r.trackable_id
+	AND latest.started = r.started
+WHERE r.problem
+ORDER BY r.started DESC;
+`
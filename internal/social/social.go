@@ -0,0 +1,197 @@
+// Package social implements following other users and the activity feed
+// built from the people a user follows.
+package social
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"tracker/internal/database"
+	"tracker/internal/types/social"
+	"tracker/internal/types/user"
+)
+
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+const (
+	ErrForbidden  = Error("social: not allowed to view profile")
+	ErrSelfFollow = Error("social: unable to follow yourself")
+	ErrBadPrivacy = Error("social: unknown privacy setting")
+)
+
+const (
+	// defaultLimit is the amount of activity returned when no limit is given.
+	defaultLimit = 50
+	// perUserLimit is the amount of activity fetched per followed user when
+	// building a feed.
+	perUserLimit = 20
+)
+
+// Service combines the stores needed for the social features.
+type Service struct {
+	users    database.UsersDatabase
+	follows  database.FollowsDatabase
+	activity database.ActivityDatabase
+}
+
+// NewService creates a new social service using the given stores.
+func NewService(users database.UsersDatabase, follows database.FollowsDatabase,
+	activity database.ActivityDatabase) *Service {
+	return &Service{
+		users:    users,
+		follows:  follows,
+		activity: activity,
+	}
+}
+
+// Follow makes the profile of the follower follow the followee, and records
+// the activity unless it already did.
+func (s *Service) Follow(ctx context.Context, follower string, profile int64, followee string) error {
+	if follower == followee {
+		return ErrSelfFollow
+	}
+	if _, err := s.users.Details(ctx, followee); err != nil {
+		return fmt.Errorf("unable to find %s: %w", followee, err)
+	}
+
+	followed, err := s.follows.Follow(ctx, follower, profile, followee)
+	if err != nil || !followed {
+		return err
+	}
+
+	return s.Record(ctx, &social.Activity{
+		User:    follower,
+		Profile: profile,
+		Kind:    social.ActivityFollowed,
+		Target:  followee,
+	})
+}
+
+// Unfollow removes the relationship between the profile and the user.
+func (s *Service) Unfollow(ctx context.Context, follower string, profile int64, followee string) error {
+	return s.follows.Unfollow(ctx, follower, profile, followee)
+}
+
+// Record an activity of a user so it shows up in their followers feeds.
+func (s *Service) Record(ctx context.Context, a *social.Activity) error {
+	return s.activity.Record(ctx, a)
+}
+
+// SetPrivacy of the users profile.
+func (s *Service) SetPrivacy(ctx context.Context, email string, privacy user.Privacy) error {
+	if !privacy.Valid() {
+		return ErrBadPrivacy
+	}
+	return s.users.SetPrivacy(ctx, email, privacy)
+}
+
+// CanView returns true if the viewer is allowed to see the activity of the
+// owner, based on the privacy setting of the owner.
+func (s *Service) CanView(ctx context.Context, viewer, owner string) (bool, error) {
+	if viewer == owner {
+		return true, nil
+	}
+
+	u, err := s.users.Details(ctx, owner)
+	if err != nil {
+		return false, err
+	}
+
+	switch u.Privacy {
+	case user.PrivacyPublic:
+		return true, nil
+	case user.PrivacyPrivate:
+		return false, nil
+	}
+
+	// Anything else is treated as friends only, which requires both users to
+	// follow each other from any of their profiles.
+	if viewer == "" {
+		return false, nil
+	}
+	a, err := s.isFollowing(ctx, viewer, owner)
+	if err != nil || !a {
+		return false, err
+	}
+	return s.isFollowing(ctx, owner, viewer)
+}
+
+// Profile returns the activity of the owner, if the viewer is allowed to see
+// it.
+func (s *Service) Profile(ctx context.Context, viewer, owner string, limit int) ([]*social.Activity, error) {
+	ok, err := s.CanView(ctx, viewer, owner)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrForbidden
+	}
+
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	return s.activity.List(ctx, owner, limit)
+}
+
+// Feed returns the most recent activity of everyone the profile of the viewer
+// follows and is allowed to see, newest first.
+func (s *Service) Feed(ctx context.Context, viewer string, profile int64, limit int) ([]*social.Activity, error) {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	following, err := s.follows.Following(ctx, viewer, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := make([]*social.Activity, 0)
+	for _, f := range following {
+		activities, err := s.Profile(ctx, viewer, f.Followee, perUserLimit)
+		if errors.Is(err, ErrForbidden) || errors.Is(err, database.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		feed = append(feed, activities...)
+	}
+
+	sort.SliceStable(feed, func(i, j int) bool {
+		return feed[i].Created.After(feed[j].Created)
+	})
+	if len(feed) > limit {
+		feed = feed[:limit]
+	}
+
+	return feed, nil
+}
+
+// Following lists the users the profile of the given user follows.
+func (s *Service) Following(ctx context.Context, email string, profile int64) ([]*social.Follow, error) {
+	return s.follows.Following(ctx, email, profile)
+}
+
+// Followers lists the users following the given user.
+func (s *Service) Followers(ctx context.Context, email string) ([]*social.Follow, error) {
+	return s.follows.Followers(ctx, email)
+}
+
+func (s *Service) isFollowing(ctx context.Context, follower, followee string) (bool, error) {
+	followers, err := s.follows.Followers(ctx, followee)
+	if err != nil {
+		return false, err
+	}
+	for _, f := range followers {
+		if f.Follower == follower {
+			return true, nil
+		}
+	}
+	return false, nil
+}
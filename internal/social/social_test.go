@@ -0,0 +1,235 @@
+package social
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"tracker/internal/database"
+	"tracker/internal/types/social"
+	"tracker/internal/types/user"
+)
+
+func TestCanView(t *testing.T) {
+	s := newTestService(map[string]user.Privacy{
+		"public@example.com":  user.PrivacyPublic,
+		"friends@example.com": user.PrivacyFriends,
+		"private@example.com": user.PrivacyPrivate,
+		"viewer@example.com":  user.PrivacyFriends,
+	})
+	ctx := context.Background()
+
+	testCases := []struct {
+		name    string
+		follows [][2]string
+		viewer  string
+		owner   string
+		want    bool
+	}{
+		{"public", nil, "viewer@example.com", "public@example.com", true},
+		{"public anonymous", nil, "", "public@example.com", true},
+		{"private", nil, "viewer@example.com", "private@example.com", false},
+		{"private owner", nil, "private@example.com", "private@example.com", true},
+		{"friends not following", nil, "viewer@example.com", "friends@example.com", false},
+		{"friends one way", [][2]string{
+			{"viewer@example.com", "friends@example.com"},
+		}, "viewer@example.com", "friends@example.com", false},
+		{"friends mutual", [][2]string{
+			{"viewer@example.com", "friends@example.com"},
+			{"friends@example.com", "viewer@example.com"},
+		}, "viewer@example.com", "friends@example.com", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s.follows.(*testFollows).m = map[string][]string{}
+			for _, f := range tc.follows {
+				s.follows.Follow(ctx, f[0], 1, f[1])
+			}
+
+			got, err := s.CanView(ctx, tc.viewer, tc.owner)
+			if err != nil {
+				t.Fatalf("CanView() err = %v, want %v", err, nil)
+			}
+			if got != tc.want {
+				t.Errorf("CanView(%s, %s) = %t, want %t", tc.viewer, tc.owner, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFeed(t *testing.T) {
+	s := newTestService(map[string]user.Privacy{
+		"viewer@example.com":  user.PrivacyFriends,
+		"public@example.com":  user.PrivacyPublic,
+		"private@example.com": user.PrivacyPrivate,
+	})
+	ctx := context.Background()
+
+	for _, followee := range []string{"public@example.com", "private@example.com"} {
+		if err := s.Follow(ctx, "viewer@example.com", 1, followee); err != nil {
+			t.Fatalf("Follow(%s) err = %v, want %v", followee, err, nil)
+		}
+	}
+	if err := s.Follow(ctx, "viewer@example.com", 1, "viewer@example.com"); !errors.Is(err, ErrSelfFollow) {
+		t.Errorf("Follow(self) err = %v, want %v", err, ErrSelfFollow)
+	}
+
+	// Following someone again isn't recorded twice.
+	if err := s.Follow(ctx, "viewer@example.com", 1, "public@example.com"); err != nil {
+		t.Fatalf("Follow(again) err = %v, want %v", err, nil)
+	}
+	if activity, _ := s.activity.List(ctx, "viewer@example.com", 10); len(activity) != 2 {
+		t.Errorf("List() returned %d entries after following again, want %d", len(activity), 2)
+	}
+
+	now := time.Now()
+	s.Record(ctx, &social.Activity{User: "public@example.com", Kind: social.ActivityWatched,
+		ShowID: 1, Created: now.Add(-time.Hour)})
+	s.Record(ctx, &social.Activity{User: "public@example.com", Kind: social.ActivityRated,
+		ShowID: 1, Rating: 4, Created: now})
+	s.Record(ctx, &social.Activity{User: "private@example.com", Kind: social.ActivityWatched,
+		ShowID: 2, Created: now})
+
+	feed, err := s.Feed(ctx, "viewer@example.com", 1, 0)
+	if err != nil {
+		t.Fatalf("Feed() err = %v, want %v", err, nil)
+	}
+	if len(feed) != 2 {
+		t.Fatalf("Feed() returned %d entries, want %d", len(feed), 2)
+	}
+	if feed[0].Kind != social.ActivityRated || feed[1].Kind != social.ActivityWatched {
+		t.Errorf("Feed() = [%s, %s], want [%s, %s]", feed[0].Kind, feed[1].Kind,
+			social.ActivityRated, social.ActivityWatched)
+	}
+
+	if _, err := s.Profile(ctx, "viewer@example.com", "private@example.com", 0); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Profile(private) err = %v, want %v", err, ErrForbidden)
+	}
+}
+
+func newTestService(users map[string]user.Privacy) *Service {
+	return NewService(
+		&testUsers{m: users},
+		&testFollows{m: map[string][]string{}},
+		&testActivity{m: map[string][]*social.Activity{}},
+	)
+}
+
+type testUsers struct {
+	m map[string]user.Privacy
+}
+
+func (db *testUsers) Create(_ context.Context, u *user.User) error {
+	db.m[u.Email] = u.Privacy
+	return nil
+}
+
+func (db *testUsers) Details(_ context.Context, email string) (*user.User, error) {
+	p, ok := db.m[email]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return &user.User{Email: email, Privacy: p}, nil
+}
+
+func (db *testUsers) SetPrivacy(_ context.Context, email string, p user.Privacy) error {
+	db.m[email] = p
+	return nil
+}
+
+func (db *testUsers) List(context.Context) ([]*user.User, error) {
+	users := make([]*user.User, 0)
+	for email, p := range db.m {
+		users = append(users, &user.User{Email: email, Privacy: p})
+	}
+	return users, nil
+}
+
+func (db *testUsers) Delete(_ context.Context, email string) error {
+	delete(db.m, email)
+	return nil
+}
+
+type testFollows struct {
+	m map[string][]string
+}
+
+func (db *testFollows) Follow(_ context.Context, follower string, _ int64, followee string) (bool, error) {
+	for _, f := range db.m[follower] {
+		if f == followee {
+			return false, nil
+		}
+	}
+	db.m[follower] = append(db.m[follower], followee)
+	return true, nil
+}
+
+func (db *testFollows) Unfollow(_ context.Context, follower string, _ int64, followee string) error {
+	following := db.m[follower][:0]
+	for _, f := range db.m[follower] {
+		if f != followee {
+			following = append(following, f)
+		}
+	}
+	db.m[follower] = following
+	return nil
+}
+
+func (db *testFollows) Following(_ context.Context, email string, _ int64) ([]*social.Follow, error) {
+	follows := make([]*social.Follow, 0)
+	for _, f := range db.m[email] {
+		follows = append(follows, &social.Follow{Follower: email, Followee: f})
+	}
+	return follows, nil
+}
+
+func (db *testFollows) Followers(_ context.Context, email string) ([]*social.Follow, error) {
+	follows := make([]*social.Follow, 0)
+	for follower, following := range db.m {
+		for _, f := range following {
+			if f == email {
+				follows = append(follows, &social.Follow{Follower: follower, Followee: f})
+			}
+		}
+	}
+	return follows, nil
+}
+
+func (db *testFollows) RemoveUser(_ context.Context, email string) error {
+	delete(db.m, email)
+	for follower := range db.m {
+		db.Unfollow(context.Background(), follower, 0, email)
+	}
+	return nil
+}
+
+type testActivity struct {
+	m map[string][]*social.Activity
+}
+
+func (db *testActivity) Record(_ context.Context, a *social.Activity) error {
+	db.m[a.User] = append(db.m[a.User], a)
+	return nil
+}
+
+func (db *testActivity) List(_ context.Context, email string, limit int) ([]*social.Activity, error) {
+	activities := db.m[email]
+	if len(activities) > limit {
+		activities = activities[:limit]
+	}
+	return activities, nil
+}
+
+func (db *testActivity) RemoveUser(_ context.Context, email string) error {
+	delete(db.m, email)
+	for _, activities := range db.m {
+		for _, a := range activities {
+			if a.Target == email {
+				a.Target = ""
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,328 @@
+package timeutil
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Precision is how precisely a date is known. The zero value is an exact
+// day, so that dates built from a time.Time are exact.
+type Precision int
+
+const (
+	PrecisionDay Precision = iota
+	PrecisionMonth
+	PrecisionQuarter
+	PrecisionYear
+	PrecisionUnknown
+)
+
+var precisionNames = map[Precision]string{
+	PrecisionDay:     "day",
+	PrecisionMonth:   "month",
+	PrecisionQuarter: "quarter",
+	PrecisionYear:    "year",
+	PrecisionUnknown: "unknown",
+}
+
+func (p Precision) String() string {
+	if name, ok := precisionNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("Precision(%d)", int(p))
+}
+
+// ParsePrecision returns the precision named by the string.
+func ParsePrecision(str string) (Precision, error) {
+	for p, name := range precisionNames {
+		if name == str {
+			return p, nil
+		}
+	}
+	return PrecisionUnknown, fmt.Errorf("%w: unknown precision %q", ErrInvalidTime, str)
+}
+
+func (p Precision) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Precision) UnmarshalText(b []byte) (err error) {
+	*p, err = ParsePrecision(string(b))
+	return err
+}
+
+// Scan reads the precision from its name in the database.
+func (p *Precision) Scan(src interface{}) (err error) {
+	switch v := src.(type) {
+	case string:
+		*p, err = ParsePrecision(v)
+	case []byte:
+		*p, err = ParsePrecision(string(v))
+	case nil:
+		*p = PrecisionDay
+	default:
+		err = fmt.Errorf("unable to scan %T into a precision", src)
+	}
+	return err
+}
+
+// Value stores the precision by its name in the database.
+func (p Precision) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// Date is a date which may only be known to the month, quarter or year, or
+// not at all. The time is the first day of the period. Tentative dates are
+// announced, but not confirmed.
+type Date struct {
+	time.Time
+	Precision Precision
+	Tentative bool
+}
+
+// Known returns true if the date is known at least to the year.
+func (d Date) Known() bool {
+	return !d.IsZero() && d.Precision != PrecisionUnknown
+}
+
+// Exact returns true if the date is known to the day.
+func (d Date) Exact() bool {
+	return !d.IsZero() && d.Precision == PrecisionDay
+}
+
+// End returns the day following the period of the date, the zero time if
+// the date is unknown.
+func (d Date) End() time.Time {
+	switch {
+	case !d.Known():
+		return time.Time{}
+	case d.Precision == PrecisionMonth:
+		return d.AddDate(0, 1, 0)
+	case d.Precision == PrecisionQuarter:
+		return d.AddDate(0, 3, 0)
+	case d.Precision == PrecisionYear:
+		return d.AddDate(1, 0, 0)
+	}
+	return d.AddDate(0, 0, 1)
+}
+
+// Released returns true if the last day of the period of the date isn't
+// after now. Unknown dates are never released.
+func (d Date) Released(now time.Time) bool {
+	return d.Known() && !d.End().Add(-Day).After(now)
+}
+
+// Overlaps returns true if the period of the date overlaps the range.
+func (d Date) Overlaps(start, end time.Time) bool {
+	return d.Known() && d.Time.Before(end) && d.End().After(start)
+}
+
+// Equal returns true if both dates are the same period, equally tentative.
+func (d Date) Equal(o Date) bool {
+	if !d.Known() || !o.Known() {
+		return d.Known() == o.Known() && d.Tentative == o.Tentative
+	}
+	return d.Time.Equal(o.Time) && d.Precision == o.Precision && d.Tentative == o.Tentative
+}
+
+// String formats the date as precisely as it is known, as in "2021-03-06",
+// "2021-03", "2021-Q1" or "2021", with a trailing "?" if it is tentative.
+// Unknown dates are empty. ParseDate parses the string back.
+func (d Date) String() string {
+	var s string
+	switch {
+	case !d.Known():
+		return ""
+	case d.Precision == PrecisionMonth:
+		s = d.Format("2006-01")
+	case d.Precision == PrecisionQuarter:
+		s = fmt.Sprintf("%d-Q%d", d.Year(), (int(d.Month())-1)/3+1)
+	case d.Precision == PrecisionYear:
+		s = d.Format("2006")
+	default:
+		s = d.Format(Format)
+	}
+	if d.Tentative {
+		s += "?"
+	}
+	return s
+}
+
+// Text formats the date for people, as in "6 March 2021", "March 2021",
+// "Q1 2021", "2021" or "TBA".
+func (d Date) Text() string {
+	var s string
+	switch {
+	case !d.Known():
+		return "TBA"
+	case d.Precision == PrecisionMonth:
+		s = d.Format("January 2006")
+	case d.Precision == PrecisionQuarter:
+		s = fmt.Sprintf("Q%d %d", (int(d.Month())-1)/3+1, d.Year())
+	case d.Precision == PrecisionYear:
+		s = d.Format("2006")
+	default:
+		s = d.Format("2 January 2006")
+	}
+	if d.Tentative {
+		s += " (tentative)"
+	}
+	return s
+}
+
+// MarshalJSON encodes the date along with its precision, and how it reads.
+func (d Date) MarshalJSON() ([]byte, error) {
+	var date *string
+	if d.Known() {
+		s := d.Format(Format)
+		date = &s
+	}
+	precision := d.Precision
+	if !d.Known() {
+		precision = PrecisionUnknown
+	}
+	return json.Marshal(struct {
+		Date      *string   `json:"date"`
+		Precision Precision `json:"precision"`
+		Tentative bool      `json:"tentative"`
+		Text      string    `json:"text"`
+	}{date, precision, d.Tentative, d.Text()})
+}
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Date      *string   `json:"date"`
+		Precision Precision `json:"precision"`
+		Tentative bool      `json:"tentative"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*d = Date{Precision: v.Precision, Tentative: v.Tentative}
+	if v.Date != nil {
+		t, err := time.Parse(Format, *v.Date)
+		if err != nil {
+			return err
+		}
+		d.Time = t
+	}
+	return nil
+}
+
+var (
+	// tentativeRegexp matches the words which say a date isn't confirmed.
+	tentativeRegexp = regexp.MustCompile(`(?i)\b(?:tentative(?:ly)?|expected|planned|scheduled|projected|estimated|circa)\b|\bc\.\s|\?`)
+	// noiseRegexp matches references and parenthetical remarks.
+	noiseRegexp = regexp.MustCompile(`\[[^\]]*\]|\([^)]*\)`)
+
+	unknownRegexp = regexp.MustCompile(`(?i)^(?:tba|tbd|tbc|to be (?:announced|determined|confirmed)|unknown|n/a)$`)
+	isoRegexp     = regexp.MustCompile(`^([0-9]{4})(?:-(?:([0-9]{2})|Q([1-4])))?$`)
+	yearRegexp    = regexp.MustCompile(`(?i)^(?:early|mid|late)[- ]?([0-9]{4})$`)
+	quarterRegexp = regexp.MustCompile(`(?i)^(?:q([1-4])|(first|second|third|fourth) quarter(?: of)?)\s+([0-9]{4})$`)
+	seasonRegexp  = regexp.MustCompile(`(?i)^(?:early |mid-?|late )?(spring|summer|fall|autumn|winter)(?: of)?\s+([0-9]{4})$`)
+	monthRegexp   = regexp.MustCompile(`(?i)^(?:early |mid-?|late )?([a-z]+),?\s+([0-9]{4})$`)
+)
+
+var quarterWords = map[string]int{"first": 1, "second": 2, "third": 3, "fourth": 4}
+
+// seasonQuarters are the quarters of the seasons of the northern hemisphere,
+// with winter at the start of the year.
+var seasonQuarters = map[string]int{"winter": 1, "spring": 2, "summer": 3, "fall": 4, "autumn": 4}
+
+// ParseDate parses a date which may be partial, as in "March 2025",
+// "Fall 2024", "Q3 2025", "Late 2024" or "2025", or unknown, as in "TBA" or
+// "—". Dates which are expected or followed by "?" are tentative. The
+// formats of Date.String are parsed as well.
+func ParseDate(str string) (Date, error) {
+	d := Date{Tentative: tentativeRegexp.MatchString(str)}
+
+	text := tentativeRegexp.ReplaceAllString(str, " ")
+	text = noiseRegexp.ReplaceAllString(text, " ")
+	text = strings.Join(strings.Fields(text), " ")
+	// A dash stands in for a date which isn't known.
+	dash := text != "" && strings.Trim(text, "–—-") == ""
+	text = strings.Trim(text, " ,.;:–—-")
+
+	quarter := func(year, q int) Date {
+		d.Time = time.Date(year, time.Month(3*q-2), 1, 0, 0, 0, 0, time.UTC)
+		d.Precision = PrecisionQuarter
+		return d
+	}
+
+	if dash || unknownRegexp.MatchString(text) {
+		return Date{Precision: PrecisionUnknown, Tentative: d.Tentative}, nil
+	}
+	if m := isoRegexp.FindStringSubmatch(text); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		switch {
+		case m[2] != "":
+			month, _ := strconv.Atoi(m[2])
+			if month < 1 || month > 12 {
+				return Date{}, fmt.Errorf("%w: invalid month %q", ErrInvalidTime, str)
+			}
+			d.Time = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+			d.Precision = PrecisionMonth
+		case m[3] != "":
+			q, _ := strconv.Atoi(m[3])
+			return quarter(year, q), nil
+		default:
+			d.Time = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+			d.Precision = PrecisionYear
+		}
+		return d, nil
+	}
+	if m := yearRegexp.FindStringSubmatch(text); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		d.Time = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		d.Precision = PrecisionYear
+		return d, nil
+	}
+	if t, err := time.Parse(Format, text); err == nil {
+		d.Time = t
+		return d, nil
+	}
+	if m := quarterRegexp.FindStringSubmatch(text); m != nil {
+		year, _ := strconv.Atoi(m[3])
+		q, _ := strconv.Atoi(m[1])
+		if q == 0 {
+			q = quarterWords[strings.ToLower(m[2])]
+		}
+		return quarter(year, q), nil
+	}
+	if m := seasonRegexp.FindStringSubmatch(text); m != nil {
+		year, _ := strconv.Atoi(m[2])
+		return quarter(year, seasonQuarters[strings.ToLower(m[1])]), nil
+	}
+	if m := monthRegexp.FindStringSubmatch(text); m != nil && monthNumber(m[1]) != 0 {
+		year, _ := strconv.Atoi(m[2])
+		d.Time = time.Date(year, time.Month(monthNumber(m[1])), 1, 0, 0, 0, 0, time.UTC)
+		d.Precision = PrecisionMonth
+		return d, nil
+	}
+
+	t, err := Parse(text)
+	if err != nil {
+		return Date{}, fmt.Errorf("%w: %q", ErrInvalidTime, str)
+	}
+	d.Time = t
+	return d, nil
+}
+
+// HasDate checks whether the string is possibly a date, exact or partial,
+// or a date which is yet to be announced.
+func HasDate(str string) bool {
+	if strings.TrimSpace(str) == "" {
+		return false
+	}
+	if HasMonth(str) {
+		return true
+	}
+	_, err := ParseDate(str)
+	return err == nil
+}
@@ -0,0 +1,150 @@
+package timeutil
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+func TestParseDate(t *testing.T) {
+	day := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := map[string]struct {
+		want Date
+		err  error
+	}{
+		"March 6, 2021":             {want: Date{Time: day(2021, time.March, 6)}},
+		"6 March 2021[12]":          {want: Date{Time: day(2021, time.March, 6)}},
+		"March 6, 2021 (tentative)": {want: Date{Time: day(2021, time.March, 6), Tentative: true}},
+		"March 2025":                {want: Date{Time: day(2025, time.March, 1), Precision: PrecisionMonth}},
+		"Early March 2025":          {want: Date{Time: day(2025, time.March, 1), Precision: PrecisionMonth}},
+		"Q3 2025":                   {want: Date{Time: day(2025, time.July, 1), Precision: PrecisionQuarter}},
+		"second quarter of 2025":    {want: Date{Time: day(2025, time.April, 1), Precision: PrecisionQuarter}},
+		"Fall 2024":                 {want: Date{Time: day(2024, time.October, 1), Precision: PrecisionQuarter}},
+		"Expected summer 2024":      {want: Date{Time: day(2024, time.July, 1), Precision: PrecisionQuarter, Tentative: true}},
+		"2025":                      {want: Date{Time: day(2025, time.January, 1), Precision: PrecisionYear}},
+		"2025?":                     {want: Date{Time: day(2025, time.January, 1), Precision: PrecisionYear, Tentative: true}},
+		"Early 2025":                {want: Date{Time: day(2025, time.January, 1), Precision: PrecisionYear}},
+		"Mid-2025":                  {want: Date{Time: day(2025, time.January, 1), Precision: PrecisionYear}},
+		"late 2024":                 {want: Date{Time: day(2024, time.January, 1), Precision: PrecisionYear}},
+		"2021-03-06":                {want: Date{Time: day(2021, time.March, 6)}},
+		"2025-03":                   {want: Date{Time: day(2025, time.March, 1), Precision: PrecisionMonth}},
+		"2025-Q2":                   {want: Date{Time: day(2025, time.April, 1), Precision: PrecisionQuarter}},
+		"TBA":                       {want: Date{Precision: PrecisionUnknown}},
+		"—":                         {want: Date{Precision: PrecisionUnknown}},
+		"":                          {err: ErrInvalidTime},
+		"2025-13":                   {err: ErrInvalidTime},
+		"Jane Doe":                  {err: ErrInvalidTime},
+		"2.45":                      {err: ErrInvalidTime},
+	}
+
+	for in, tc := range testCases {
+		t.Run(in, func(t *testing.T) {
+			got, err := ParseDate(in)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("ParseDate() err = %v, want %v", err, tc.err)
+			}
+			if diff := deep.Equal(got, tc.want); diff != nil {
+				t.Errorf("ParseDate() diff = %v", diff)
+			}
+		})
+	}
+}
+
+func TestDate_String(t *testing.T) {
+	testCases := []struct {
+		in         string
+		want, text string
+	}{
+		{in: "March 6, 2021", want: "2021-03-06", text: "6 March 2021"},
+		{in: "March 2025", want: "2025-03", text: "March 2025"},
+		{in: "Fall 2024", want: "2024-Q4", text: "Q4 2024"},
+		{in: "2025 (tentative)", want: "2025?", text: "2025 (tentative)"},
+		{in: "TBA", want: "", text: "TBA"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			d, err := ParseDate(tc.in)
+			if err != nil {
+				t.Fatalf("ParseDate() err = %v, want %v", err, nil)
+			}
+			if got := d.String(); got != tc.want {
+				t.Errorf("String() = %q, want %q", got, tc.want)
+			}
+			if got := d.Text(); got != tc.text {
+				t.Errorf("Text() = %q, want %q", got, tc.text)
+			}
+
+			// The string of a known date parses back to the same date.
+			if !d.Known() {
+				return
+			}
+			back, err := ParseDate(d.String())
+			if err != nil || !back.Equal(d) {
+				t.Errorf("ParseDate(%q) = %v, %v, want %v", d.String(), back, err, d)
+			}
+		})
+	}
+}
+
+func TestDate_Released(t *testing.T) {
+	now := time.Date(2025, time.March, 15, 12, 0, 0, 0, time.UTC)
+	testCases := map[string]bool{
+		"March 15, 2025": true,
+		"March 16, 2025": false,
+		"February 2025":  true,
+		"March 2025":     false,
+		"Q1 2025":        false,
+		"2024":           true,
+		"TBA":            false,
+	}
+
+	for in, want := range testCases {
+		t.Run(in, func(t *testing.T) {
+			d, err := ParseDate(in)
+			if err != nil {
+				t.Fatalf("ParseDate() err = %v, want %v", err, nil)
+			}
+			if got := d.Released(now); got != want {
+				t.Errorf("Released() = %t, want %t", got, want)
+			}
+		})
+	}
+}
+
+func TestDate_JSON(t *testing.T) {
+	testCases := map[string]string{
+		"March 2025": `{"date":"2025-03-01","precision":"month","tentative":false,"text":"March 2025"}`,
+		"TBA":        `{"date":null,"precision":"unknown","tentative":false,"text":"TBA"}`,
+	}
+
+	for in, want := range testCases {
+		t.Run(in, func(t *testing.T) {
+			d, err := ParseDate(in)
+			if err != nil {
+				t.Fatalf("ParseDate() err = %v, want %v", err, nil)
+			}
+			got, err := json.Marshal(d)
+			if err != nil {
+				t.Fatalf("Marshal() err = %v, want %v", err, nil)
+			}
+			if string(got) != want {
+				t.Errorf("Marshal() = %s, want %s", got, want)
+			}
+
+			var back Date
+			if err := json.Unmarshal(got, &back); err != nil {
+				t.Fatalf("Unmarshal() err = %v, want %v", err, nil)
+			}
+			if diff := deep.Equal(back, d); diff != nil {
+				t.Errorf("Unmarshal() diff = %v", diff)
+			}
+		})
+	}
+}
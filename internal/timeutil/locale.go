@@ -0,0 +1,188 @@
+package timeutil
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Locale is how dates are written in a language. Dates of a locale are read
+// by rewriting them the way English ones are, and parsing them as such.
+type Locale struct {
+	// Lang is the code of the language, as in "de".
+	Lang string
+
+	// Months are the names of the months, lowercase, followed by their
+	// abbreviations.
+	Months [12][]string
+
+	// Words are other words of dates, lowercase, and the English words they
+	// are read as, such as seasons of the year or "expected". Words read as
+	// the empty string are dropped.
+	Words map[string]string
+
+	// Numeric is the order of the day, month and year of dates written with
+	// numbers only, as in "05.01.2020": "dmy", "mdy" or "ymd". Such dates
+	// aren't read if it is empty.
+	Numeric string
+}
+
+var (
+	// English is the locale dates are read with unless told otherwise.
+	English = &Locale{Lang: "en"}
+
+	German = &Locale{
+		Lang: "de",
+		Months: [12][]string{
+			{"januar", "jänner", "jan"}, {"februar", "feber", "feb"}, {"märz", "mär", "mrz"},
+			{"april", "apr"}, {"mai"}, {"juni", "jun"}, {"juli", "jul"}, {"august", "aug"},
+			{"september", "sept", "sep"}, {"oktober", "okt"}, {"november", "nov"}, {"dezember", "dez"},
+		},
+		Words: map[string]string{
+			"frühling": "spring", "frühjahr": "spring", "sommer": "summer", "herbst": "fall",
+			"anfang": "", "mitte": "", "ende": "",
+			"voraussichtlich": "expected", "geplant": "expected", "unbekannt": "TBA",
+		},
+		Numeric: "dmy",
+	}
+
+	French = &Locale{
+		Lang: "fr",
+		Months: [12][]string{
+			{"janvier", "janv"}, {"février", "fevrier", "févr", "fevr"}, {"mars"}, {"avril", "avr"},
+			{"mai"}, {"juin"}, {"juillet", "juil"}, {"août", "aout"}, {"septembre", "sept"},
+			{"octobre", "oct"}, {"novembre", "nov"}, {"décembre", "decembre", "déc", "dec"},
+		},
+		Words: map[string]string{
+			"printemps": "spring", "été": "summer", "automne": "fall", "hiver": "winter",
+			"début": "", "fin": "", "le": "", "er": "",
+			"prévu": "expected", "prévue": "expected", "provisoire": "expected",
+			"inconnu": "TBA", "inconnue": "TBA",
+		},
+		Numeric: "dmy",
+	}
+
+	Spanish = &Locale{
+		Lang: "es",
+		Months: [12][]string{
+			{"enero", "ene"}, {"febrero", "feb"}, {"marzo", "mar"}, {"abril", "abr"},
+			{"mayo", "may"}, {"junio", "jun"}, {"julio", "jul"}, {"agosto", "ago"},
+			{"septiembre", "setiembre", "sept", "sep"}, {"octubre", "oct"}, {"noviembre", "nov"},
+			{"diciembre", "dic"},
+		},
+		Words: map[string]string{
+			"primavera": "spring", "verano": "summer", "otoño": "fall", "invierno": "winter",
+			"principios": "", "mediados": "", "finales": "", "de": "", "del": "",
+			"previsto": "expected", "prevista": "expected",
+			"desconocido": "TBA", "desconocida": "TBA",
+		},
+		Numeric: "dmy",
+	}
+
+	// Japanese dates, as in "2020年1月5日", are read in every locale.
+	Japanese = &Locale{
+		Lang:    "ja",
+		Words:   map[string]string{"予定": "expected", "未定": "TBA"},
+		Numeric: "ymd",
+	}
+)
+
+var locales = map[string]*Locale{}
+
+func init() {
+	for _, l := range []*Locale{English, German, French, Spanish, Japanese} {
+		locales[l.Lang] = l
+	}
+}
+
+// LocaleOf returns the locale of the language, English if it isn't known.
+func LocaleOf(lang string) *Locale {
+	if l, ok := locales[lang]; ok {
+		return l
+	}
+	return English
+}
+
+var (
+	wordRegexp    = regexp.MustCompile(`\pL+\.?`)
+	kanjiRegexp   = regexp.MustCompile(`([0-9]{4})\s*年(?:\s*([0-9]{1,2})\s*月(?:\s*([0-9]{1,2})\s*日)?)?`)
+	numericRegexp = regexp.MustCompile(`\b([0-9]{1,4})[./]([0-9]{1,2})[./]([0-9]{1,4})\b`)
+	// ordinalRegexp matches days followed by a dot, as in "5. Januar".
+	ordinalRegexp = regexp.MustCompile(`\b([0-9]{1,2})\.(\s|$)`)
+)
+
+// English rewrites the date the way it is written in English.
+func (l *Locale) English(str string) string {
+	str = kanjiRegexp.ReplaceAllStringFunc(str, func(s string) string {
+		m := kanjiRegexp.FindStringSubmatch(s)
+		switch {
+		case m[3] != "":
+			return fmt.Sprintf(" %s-%02s-%02s ", m[1], m[2], m[3])
+		case m[2] != "":
+			return fmt.Sprintf(" %s-%02s ", m[1], m[2])
+		}
+		return " " + m[1] + " "
+	})
+
+	if l.Numeric != "" {
+		str = numericRegexp.ReplaceAllStringFunc(str, func(s string) string {
+			m := numericRegexp.FindStringSubmatch(s)
+			day, month, year := m[1], m[2], m[3]
+			switch l.Numeric {
+			case "mdy":
+				day, month = m[2], m[1]
+			case "ymd":
+				year, day = m[1], m[3]
+			}
+			if len(year) != 4 {
+				return s
+			}
+			return fmt.Sprintf("%s-%02s-%02s", year, month, day)
+		})
+		str = ordinalRegexp.ReplaceAllString(str, "$1$2")
+	}
+
+	if len(l.Words) == 0 && l.Months[0] == nil {
+		return str
+	}
+	return wordRegexp.ReplaceAllStringFunc(str, func(w string) string {
+		word := strings.ToLower(strings.TrimSuffix(w, "."))
+		for i, names := range l.Months {
+			for _, name := range names {
+				if word == name {
+					return englishMonths[i]
+				}
+			}
+		}
+		if english, ok := l.Words[word]; ok {
+			return " " + english + " "
+		}
+		return w
+	})
+}
+
+var englishMonths = [12]string{
+	"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December",
+}
+
+// ParseDate parses a date of the locale, exact, partial or unknown, as
+// ParseDate does English ones.
+func (l *Locale) ParseDate(str string) (Date, error) {
+	d, err := ParseDate(l.English(str))
+	if err != nil {
+		return Date{}, fmt.Errorf("%w: %q", ErrInvalidTime, str)
+	}
+	return d, nil
+}
+
+// HasDate checks whether the string is possibly a date of the locale, as
+// HasDate does English ones.
+func (l *Locale) HasDate(str string) bool {
+	return HasDate(l.English(str))
+}
+
+// HasMonth checks whether there is a month of the locale in the string.
+func (l *Locale) HasMonth(str string) bool {
+	return HasMonth(l.English(str))
+}
@@ -0,0 +1,90 @@
+package timeutil
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+func TestLocale_ParseDate(t *testing.T) {
+	day := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		locale *Locale
+		in     string
+		want   Date
+		err    error
+	}{
+		{locale: German, in: "5. Januar 2020", want: Date{Time: day(2020, time.January, 5)}},
+		{locale: German, in: "17. März 2021", want: Date{Time: day(2021, time.March, 17)}},
+		{locale: German, in: "3. Okt. 2022", want: Date{Time: day(2022, time.October, 3)}},
+		{locale: German, in: "05.01.2020", want: Date{Time: day(2020, time.January, 5)}},
+		{locale: German, in: "Mitte März 2025", want: Date{Time: day(2025, time.March, 1), Precision: PrecisionMonth}},
+		{locale: German, in: "Herbst 2024 (voraussichtlich)",
+			want: Date{Time: day(2024, time.October, 1), Precision: PrecisionQuarter, Tentative: true}},
+		{locale: German, in: "unbekannt", want: Date{Precision: PrecisionUnknown}},
+		{locale: French, in: "1er mars 2021", want: Date{Time: day(2021, time.March, 1)}},
+		{locale: French, in: "12 févr. 2021", want: Date{Time: day(2021, time.February, 12)}},
+		{locale: French, in: "le 24 décembre 2020", want: Date{Time: day(2020, time.December, 24)}},
+		{locale: French, in: "automne 2024", want: Date{Time: day(2024, time.October, 1), Precision: PrecisionQuarter}},
+		{locale: Spanish, in: "5 de enero de 2020", want: Date{Time: day(2020, time.January, 5)}},
+		{locale: Spanish, in: "marzo de 2025", want: Date{Time: day(2025, time.March, 1), Precision: PrecisionMonth}},
+		{locale: Spanish, in: "otoño de 2024 (previsto)",
+			want: Date{Time: day(2024, time.October, 1), Precision: PrecisionQuarter, Tentative: true}},
+		{locale: Spanish, in: "25/12/2020", want: Date{Time: day(2020, time.December, 25)}},
+		{locale: Japanese, in: "2020年1月5日", want: Date{Time: day(2020, time.January, 5)}},
+		{locale: Japanese, in: "2025年3月予定", want: Date{Time: day(2025, time.March, 1), Precision: PrecisionMonth, Tentative: true}},
+		{locale: Japanese, in: "2020/01/05", want: Date{Time: day(2020, time.January, 5)}},
+		{locale: Japanese, in: "未定", want: Date{Precision: PrecisionUnknown}},
+		{locale: English, in: "March 6, 2021", want: Date{Time: day(2021, time.March, 6)}},
+		{locale: English, in: "03/06/2021", err: ErrInvalidTime},
+		{locale: German, in: "Max Mustermann", err: ErrInvalidTime},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.locale.Lang+" "+tc.in, func(t *testing.T) {
+			got, err := tc.locale.ParseDate(tc.in)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("ParseDate() err = %v, want %v", err, tc.err)
+			}
+			if diff := deep.Equal(got, tc.want); diff != nil {
+				t.Errorf("ParseDate() diff = %v", diff)
+			}
+		})
+	}
+}
+
+func TestLocale_HasDate(t *testing.T) {
+	testCases := []struct {
+		locale *Locale
+		in     string
+		want   bool
+	}{
+		{locale: German, in: "17. März 2021", want: true},
+		{locale: German, in: "Folge 3", want: false},
+		{locale: French, in: "1er mars 2021", want: true},
+		{locale: Spanish, in: "5 de enero de 2020", want: true},
+		{locale: Japanese, in: "2020年1月5日", want: true},
+		{locale: Japanese, in: "第1話", want: false},
+		{locale: English, in: "17. März 2021", want: false},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.locale.HasDate(tc.in); got != tc.want {
+			t.Errorf("%s HasDate(%q) = %t, want %t", tc.locale.Lang, tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestLocaleOf(t *testing.T) {
+	if got := LocaleOf("de"); got != German {
+		t.Errorf("LocaleOf(%q) = %q, want %q", "de", got.Lang, German.Lang)
+	}
+	if got := LocaleOf("xx"); got != English {
+		t.Errorf("LocaleOf(%q) = %q, want %q", "xx", got.Lang, English.Lang)
+	}
+}
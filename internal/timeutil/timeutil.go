@@ -62,13 +62,23 @@ func HasMonth(str string) bool {
 
 var timeRegexp = regexp.MustCompile(`([a-zA-Z]+)[^0-9]+([0-9]+)[^0-9]+([0-9]+)`)
 
+// dayFirstRegexp matches dates where the day comes before the month, as in
+// "12 May 2020".
+var dayFirstRegexp = regexp.MustCompile(`([0-9]{1,2})\s+([a-zA-Z]+)\s+([0-9]{4})`)
+
 // Parse the time
 // TODO: If this implements a valid time RFC, we should convert to that instead.
 //       Maybe using time.Parse(Format, str) would suffice.
 func Parse(str string) (t time.Time, err error) {
 	var day, month, year int
 
-	if matches := timeRegexp.FindStringSubmatch(str); len(matches) >= 3 {
+	// Swap the day and the month so both orders are handled below.
+	matches := timeRegexp.FindStringSubmatch(str)
+	if m := dayFirstRegexp.FindStringSubmatch(str); m != nil && monthNumber(m[2]) != 0 {
+		matches = []string{m[0], m[2], m[1], m[3]}
+	}
+
+	if len(matches) >= 3 {
 		if day, err = strconv.Atoi(matches[2]); err != nil {
 			return t, fmt.Errorf("unable to parse day %s: %w",
 				matches[2], err)
@@ -110,6 +120,11 @@ func (t JSONTime) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf("\"%s\"", time.Time(t).Format(Format))), nil
 }
 
+// CalendarString formats the time as a day in a calendar, e.g. "Sat 6 Mar".
+func (t JSONTime) CalendarString() string {
+	return time.Time(t).Format("Mon 2 Jan")
+}
+
 func (t *JSONTime) UnmarshalJSON(b []byte) error {
 	s := strings.TrimPrefix(strings.TrimSuffix(string(b), "\""), "\"")
 
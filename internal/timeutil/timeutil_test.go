@@ -40,7 +40,28 @@ func TestHasMonth(t *testing.T) {
 }
 
 func TestParse(t *testing.T) {
+	testCases := map[string]struct {
+		want time.Time
+		err  error
+	}{
+		"March 6, 2021": {time.Date(2021, time.March, 6, 0, 0, 0, 0, time.UTC), nil},
+		"6 March 2021":  {time.Date(2021, time.March, 6, 0, 0, 0, 0, time.UTC), nil},
+		"Released: 12 May 2020 (US)": {
+			time.Date(2020, time.May, 12, 0, 0, 0, 0, time.UTC), nil},
+		"TBA": {time.Time{}, ErrInvalidTime},
+	}
 
+	for in, tc := range testCases {
+		t.Run(in, func(t *testing.T) {
+			got, err := Parse(in)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Parse() err = %v, want %v", err, tc.err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("Parse() = %v, want %v", got, tc.want)
+			}
+		})
+	}
 }
 
 func TestMonthNumber(t *testing.T) {
@@ -0,0 +1,39 @@
+// Package social contains the definitions for relationships between users
+// and the activity they share with each other.
+package social
+
+import "time"
+
+// Follow is a one way relationship from a profile of the follower to the
+// followee. Users are identified by their email address.
+type Follow struct {
+	Follower string    `json:"follower"`
+	Profile  int64     `json:"profile"`
+	Followee string    `json:"followee"`
+	Created  time.Time `json:"created"`
+}
+
+// ActivityKind describes what happened in an Activity.
+type ActivityKind string
+
+const (
+	ActivityWatched  ActivityKind = "watched"
+	ActivityRated    ActivityKind = "rated"
+	ActivityFollowed ActivityKind = "followed"
+)
+
+// Activity is a single entry in a users activity feed.
+type Activity struct {
+	ID      int64        `json:"id"`
+	User    string       `json:"user"`
+	Profile int64        `json:"profile"`
+	Kind    ActivityKind `json:"kind"`
+	Created time.Time    `json:"created"`
+
+	// Depending on the kind, only some of these fields are set.
+	ShowID  int    `json:"show_id,omitempty"`
+	Season  int    `json:"season,omitempty"`
+	Episode int    `json:"episode,omitempty"`
+	Rating  int    `json:"rating,omitempty"`
+	Target  string `json:"target,omitempty"`
+}
@@ -1,6 +1,8 @@
 // Package user contains all the definitions for the user.
 package user
 
+import "time"
+
 // User contains the data for a user. This can be used for authentication
 // with third party services, as it contains the necessary fields.
 type User struct {
@@ -15,4 +17,55 @@ type User struct {
 	FamilyName    string `json:"family_name"`
 	Profile       string `json:"profile"`
 	Picture       string `json:"picture"`
+
+	// Privacy controls who is able to see the activity of the user.
+	Privacy Privacy `json:"privacy"`
+}
+
+// Privacy of a users profile and activity.
+type Privacy string
+
+const (
+	// PrivacyPublic allows everyone to see the profile.
+	PrivacyPublic Privacy = "public"
+	// PrivacyFriends only allows users who follow each other to see the
+	// profile.
+	PrivacyFriends Privacy = "friends"
+	// PrivacyPrivate hides the profile from everyone but the owner.
+	PrivacyPrivate Privacy = "private"
+)
+
+// Valid returns true if the privacy is one of the known values.
+func (p Privacy) Valid() bool {
+	switch p {
+	case PrivacyPublic, PrivacyFriends, PrivacyPrivate:
+		return true
+	}
+	return false
+}
+
+// Profile belongs to an account, and allows people sharing an account to keep
+// their own watch state, follows and preferences.
+type Profile struct {
+	ID          int64       `json:"id"`
+	Owner       string      `json:"owner"`
+	Name        string      `json:"name"`
+	Preferences Preferences `json:"preferences"`
+}
+
+// Preferences of a single profile.
+type Preferences struct {
+	// DefaultList is the show list displayed on the landing page.
+	DefaultList string `json:"default_list,omitempty"`
+	// HideSpoilers hides the titles of unwatched episodes.
+	HideSpoilers bool `json:"hide_spoilers,omitempty"`
+}
+
+// Session is a record of a single login of a user.
+type Session struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Created   time.Time `json:"created"`
+	UserAgent string    `json:"user_agent"`
+	Address   string    `json:"address"`
 }
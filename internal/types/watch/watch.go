@@ -0,0 +1,100 @@
+// Package watch contains the definitions for the watch state of a profile.
+package watch
+
+import "time"
+
+// Episode marks a single episode of a show as watched by a profile.
+type Episode struct {
+	Profile int64     `json:"profile"`
+	ShowID  int       `json:"show_id"`
+	Season  int       `json:"season"`
+	Episode int       `json:"episode"`
+	Watched time.Time `json:"watched"`
+}
+
+// Rating of a show by a profile, between 1 and 5.
+type Rating struct {
+	Profile int64     `json:"profile"`
+	ShowID  int       `json:"show_id"`
+	Rating  int       `json:"rating"`
+	Rated   time.Time `json:"rated"`
+}
+
+// ImportItem is a single entry of a watch history exported from another
+// tracker. Items which can't be matched to a show are kept for review.
+type ImportItem struct {
+	ID      int64  `json:"id"`
+	Profile int64  `json:"profile"`
+	Source  string `json:"source"`
+
+	// Show details used for matching against the catalog.
+	Title  string `json:"title"`
+	Year   int    `json:"year,omitempty"`
+	IMDbID string `json:"imdb_id,omitempty"`
+	TVDBID string `json:"tvdb_id,omitempty"`
+	TMDBID string `json:"tmdb_id,omitempty"`
+
+	// Either an episode which was watched, or a rating of the show.
+	Season  int       `json:"season,omitempty"`
+	Episode int       `json:"episode,omitempty"`
+	Rating  int       `json:"rating,omitempty"`
+	Watched time.Time `json:"watched"`
+
+	// Reason the item could not be imported automatically.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ReadingState is how far a profile is through a volume of a book series.
+type ReadingState string
+
+const (
+	Reading  ReadingState = "reading"
+	Finished ReadingState = "finished"
+)
+
+// Valid returns true if the state is known.
+func (s ReadingState) Valid() bool {
+	return s == Reading || s == Finished
+}
+
+// Progress of a profile through a single volume of a book series.
+type Progress struct {
+	Profile  int64        `json:"profile"`
+	SeriesID int          `json:"series_id"`
+	Volume   int          `json:"volume"`
+	State    ReadingState `json:"state"`
+	Page     int          `json:"page,omitempty"`
+	Updated  time.Time    `json:"updated"`
+}
+
+// PlayState is whether a profile wants to play, is playing or has played a
+// game.
+type PlayState string
+
+const (
+	Backlog PlayState = "backlog"
+	Playing PlayState = "playing"
+	Played  PlayState = "played"
+)
+
+// Valid returns true if the state is known.
+func (s PlayState) Valid() bool {
+	return s == Backlog || s == Playing || s == Played
+}
+
+// Play is the state of a game for a profile.
+type Play struct {
+	Profile int64     `json:"profile"`
+	GameID  int       `json:"game_id"`
+	State   PlayState `json:"state"`
+	Updated time.Time `json:"updated"`
+}
+
+// Listen marks a single episode of a podcast as listened to by a profile. The
+// GUID identifies the episode within the feed of the podcast.
+type Listen struct {
+	Profile   int64     `json:"profile"`
+	PodcastID int       `json:"podcast_id"`
+	GUID      string    `json:"guid"`
+	Listened  time.Time `json:"listened"`
+}
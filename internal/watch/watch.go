@@ -0,0 +1,93 @@
+// Package watch keeps track of the episodes watched and the shows rated by a
+// profile, sharing the progress with the followers of the account.
+package watch
+
+import (
+	"context"
+
+	"tracker/internal/database"
+	"tracker/internal/social"
+	types "tracker/internal/types/social"
+	"tracker/internal/types/watch"
+)
+
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+const (
+	ErrInvalidRating = Error("watch: rating must be between 1 and 5")
+)
+
+// Service updates the watch state of profiles.
+type Service struct {
+	watch  database.WatchDatabase
+	social *social.Service
+}
+
+// NewService creates a new watch service. The social service is optional,
+// and is used to record activity when set.
+func NewService(watch database.WatchDatabase, social *social.Service) *Service {
+	return &Service{
+		watch:  watch,
+		social: social,
+	}
+}
+
+// MarkWatched marks the episode as watched by the profile of the owner.
+func (s *Service) MarkWatched(ctx context.Context, owner string, e *watch.Episode) error {
+	if err := s.watch.MarkWatched(ctx, e); err != nil {
+		return err
+	}
+
+	return s.record(ctx, &types.Activity{
+		User:    owner,
+		Profile: e.Profile,
+		Kind:    types.ActivityWatched,
+		ShowID:  e.ShowID,
+		Season:  e.Season,
+		Episode: e.Episode,
+	})
+}
+
+// UnmarkWatched removes the episode from the episodes watched by the profile.
+func (s *Service) UnmarkWatched(ctx context.Context, e *watch.Episode) error {
+	return s.watch.UnmarkWatched(ctx, e)
+}
+
+// Rate the show for the profile of the owner.
+func (s *Service) Rate(ctx context.Context, owner string, r *watch.Rating) error {
+	if r.Rating < 1 || r.Rating > 5 {
+		return ErrInvalidRating
+	}
+	if err := s.watch.Rate(ctx, r); err != nil {
+		return err
+	}
+
+	return s.record(ctx, &types.Activity{
+		User:    owner,
+		Profile: r.Profile,
+		Kind:    types.ActivityRated,
+		ShowID:  r.ShowID,
+		Rating:  r.Rating,
+	})
+}
+
+// Watched lists the episodes watched by the profile.
+func (s *Service) Watched(ctx context.Context, profile int64) ([]*watch.Episode, error) {
+	return s.watch.Watched(ctx, profile)
+}
+
+// Ratings lists the ratings of the profile.
+func (s *Service) Ratings(ctx context.Context, profile int64) ([]*watch.Rating, error) {
+	return s.watch.Ratings(ctx, profile)
+}
+
+func (s *Service) record(ctx context.Context, a *types.Activity) error {
+	if s.social == nil {
+		return nil
+	}
+	return s.social.Record(ctx, a)
+}
@@ -0,0 +1,3 @@
+-- Users choose who can see their activity.
+ALTER TABLE `accounts`.`users`
+	ADD COLUMN privacy VARCHAR(16) NOT NULL DEFAULT 'friends' AFTER email;
@@ -0,0 +1,23 @@
+-- Follows and activity belong to a profile of the user. Existing ones are
+-- moved to the default profile of each account, which is created here, so
+-- the profiles table of the schema must exist already.
+ALTER TABLE `accounts`.`follows`
+	ADD COLUMN profile_id BIGINT NOT NULL DEFAULT 0 AFTER follower,
+	DROP PRIMARY KEY,
+	ADD PRIMARY KEY(follower, profile_id, followee);
+
+ALTER TABLE `accounts`.`activity`
+	ADD COLUMN profile_id BIGINT NOT NULL DEFAULT 0 AFTER user;
+
+INSERT IGNORE INTO `accounts`.`profiles` (owner, name)
+	SELECT email, 'Default' FROM `accounts`.`users`;
+
+UPDATE `accounts`.`follows` f
+	JOIN `accounts`.`profiles` p ON p.owner=f.follower AND p.name='Default'
+	SET f.profile_id=p.id
+	WHERE f.profile_id=0;
+
+UPDATE `accounts`.`activity` a
+	JOIN `accounts`.`profiles` p ON p.owner=a.user AND p.name='Default'
+	SET a.profile_id=p.id
+	WHERE a.profile_id=0;
@@ -0,0 +1,5 @@
+-- Shows are matched to imported watch history by their external IDs.
+ALTER TABLE `tracker`.`shows`
+	ADD COLUMN imdb_id VARCHAR(32) AFTER finished,
+	ADD COLUMN tvdb_id VARCHAR(32) AFTER imdb_id,
+	ADD COLUMN tmdb_id VARCHAR(32) AFTER tvdb_id;
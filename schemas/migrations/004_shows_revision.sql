@@ -0,0 +1,4 @@
+-- The revision of the page listing the episodes of a show when it was last
+-- read from its wikitext.
+ALTER TABLE `tracker`.`shows`
+	ADD COLUMN revision BIGINT NOT NULL DEFAULT 0 AFTER tmdb_id;
@@ -0,0 +1,4 @@
+-- Release dates may be known only to the month or year, and be tentative.
+ALTER TABLE `tracker`.`episodes`
+	ADD COLUMN release_precision VARCHAR(8) NOT NULL DEFAULT 'day' AFTER release_date,
+	ADD COLUMN release_tentative BOOLEAN NOT NULL DEFAULT false AFTER release_precision;
@@ -0,0 +1,4 @@
+-- The source each field of an episode was read from.
+ALTER TABLE `tracker`.`episodes`
+	ADD COLUMN title_source VARCHAR(32) NOT NULL DEFAULT '' AFTER release_tentative,
+	ADD COLUMN date_source VARCHAR(32) NOT NULL DEFAULT '' AFTER title_source;
@@ -0,0 +1,3 @@
+-- The language of the Wikipedia a show is read from.
+ALTER TABLE `tracker`.`shows`
+	ADD COLUMN language VARCHAR(8) NOT NULL DEFAULT 'en' AFTER wikipedia;
@@ -0,0 +1,12 @@
+-- What the infobox of the article of a show says of it, and the revision of
+-- the article it was read from.
+ALTER TABLE `tracker`.`shows`
+	ADD COLUMN article_revision BIGINT NOT NULL DEFAULT 0 AFTER revision,
+	ADD COLUMN country VARCHAR(255) NOT NULL DEFAULT '' AFTER article_revision,
+	ADD COLUMN original_language VARCHAR(64) NOT NULL DEFAULT '' AFTER country,
+	ADD COLUMN network VARCHAR(255) NOT NULL DEFAULT '' AFTER original_language,
+	ADD COLUMN seasons INTEGER NOT NULL DEFAULT 0 AFTER network,
+	ADD COLUMN episode_count INTEGER NOT NULL DEFAULT 0 AFTER seasons,
+	ADD COLUMN first_aired VARCHAR(16) NOT NULL DEFAULT '' AFTER episode_count,
+	ADD COLUMN last_aired VARCHAR(16) NOT NULL DEFAULT '' AFTER first_aired,
+	ADD COLUMN website VARCHAR(255) NOT NULL DEFAULT '' AFTER last_aired;
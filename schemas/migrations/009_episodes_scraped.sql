@@ -0,0 +1,5 @@
+-- What the scraper read of the fields of an episode which were corrected,
+-- when they were corrected.
+ALTER TABLE `tracker`.`episodes`
+	ADD COLUMN scraped_title VARCHAR(255) NOT NULL DEFAULT '' AFTER date_source,
+	ADD COLUMN scraped_date VARCHAR(32) NOT NULL DEFAULT '' AFTER scraped_title;
@@ -4,8 +4,22 @@ CREATE TABLE IF NOT EXISTS `tracker`.`shows` (
 	id INTEGER NOT NULL AUTO_INCREMENT,
 	title VARCHAR(255) NOT NULL,
 	wikipedia VARCHAR(255),
+	language VARCHAR(8) NOT NULL DEFAULT 'en',
 	trailer VARCHAR(255),
 	finished BOOLEAN DEFAULT false,
+	imdb_id VARCHAR(32),
+	tvdb_id VARCHAR(32),
+	tmdb_id VARCHAR(32),
+	revision BIGINT NOT NULL DEFAULT 0,
+	article_revision BIGINT NOT NULL DEFAULT 0,
+	country VARCHAR(255) NOT NULL DEFAULT '',
+	original_language VARCHAR(64) NOT NULL DEFAULT '',
+	network VARCHAR(255) NOT NULL DEFAULT '',
+	seasons INTEGER NOT NULL DEFAULT 0,
+	episode_count INTEGER NOT NULL DEFAULT 0,
+	first_aired VARCHAR(16) NOT NULL DEFAULT '',
+	last_aired VARCHAR(16) NOT NULL DEFAULT '',
+	website VARCHAR(255) NOT NULL DEFAULT '',
 	PRIMARY KEY(id)
 );
 
@@ -17,15 +31,280 @@ CREATE TABLE `tracker`.`episodes` (
 	episode INTEGER NOT NULL,
 	title VARCHAR(255),
 	release_date DATE,
+	release_precision VARCHAR(8) NOT NULL DEFAULT 'day',
+	release_tentative BOOLEAN NOT NULL DEFAULT false,
+	title_source VARCHAR(32) NOT NULL DEFAULT '',
+	date_source VARCHAR(32) NOT NULL DEFAULT '',
+	scraped_title VARCHAR(255) NOT NULL DEFAULT '',
+	scraped_date VARCHAR(32) NOT NULL DEFAULT '',
 	PRIMARY KEY(id),
 	UNIQUE KEY(show_id, season, episode)
 );
 
+CREATE TABLE IF NOT EXISTS `tracker`.`show_sources` (
+	show_id INTEGER NOT NULL,
+	position INTEGER NOT NULL,
+	source VARCHAR(32) NOT NULL,
+	ref VARCHAR(255) NOT NULL DEFAULT '',
+	PRIMARY KEY(show_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS `tracker`.`show_info` (
+	show_id INTEGER NOT NULL,
+	field VARCHAR(16) NOT NULL,
+	position INTEGER NOT NULL,
+	value VARCHAR(255) NOT NULL,
+	PRIMARY KEY(show_id, field, position)
+);
+
+CREATE TABLE IF NOT EXISTS `tracker`.`show_history` (
+	id INTEGER NOT NULL AUTO_INCREMENT,
+	show_id INTEGER NOT NULL,
+	season INTEGER NOT NULL,
+	episode INTEGER NOT NULL,
+	field VARCHAR(32) NOT NULL,
+	old_value VARCHAR(255) NOT NULL,
+	new_value VARCHAR(255) NOT NULL,
+	source VARCHAR(16) NOT NULL,
+	changed DATETIME NOT NULL,
+	PRIMARY KEY(id),
+	KEY(show_id, changed)
+);
+
+CREATE TABLE IF NOT EXISTS `tracker`.`artists` (
+	id INTEGER NOT NULL AUTO_INCREMENT,
+	name VARCHAR(255) NOT NULL,
+	wikipedia VARCHAR(255),
+	solo BOOLEAN DEFAULT false,
+	PRIMARY KEY(id)
+);
+
+CREATE TABLE IF NOT EXISTS `tracker`.`albums` (
+	id INTEGER NOT NULL AUTO_INCREMENT,
+	artist_id INTEGER NOT NULL,
+	title VARCHAR(255) NOT NULL,
+	kind VARCHAR(16) NOT NULL,
+	release_date DATE,
+	wikipedia VARCHAR(255),
+	PRIMARY KEY(id),
+	UNIQUE KEY(artist_id, title)
+);
+
+CREATE TABLE IF NOT EXISTS `tracker`.`tracks` (
+	album_id INTEGER NOT NULL,
+	number INTEGER NOT NULL,
+	title VARCHAR(255) NOT NULL,
+	runtime INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY(album_id, number)
+);
+
+CREATE TABLE IF NOT EXISTS `tracker`.`movies` (
+	id INTEGER NOT NULL AUTO_INCREMENT,
+	title VARCHAR(255) NOT NULL,
+	wikipedia VARCHAR(255),
+	trailer VARCHAR(255),
+	PRIMARY KEY(id)
+);
+
+CREATE TABLE IF NOT EXISTS `tracker`.`movie_releases` (
+	movie_id INTEGER NOT NULL,
+	kind VARCHAR(16) NOT NULL,
+	region VARCHAR(64) NOT NULL DEFAULT '',
+	release_date DATE NOT NULL,
+	PRIMARY KEY(movie_id, kind, region)
+);
+
+CREATE TABLE IF NOT EXISTS `tracker`.`series` (
+	id INTEGER NOT NULL AUTO_INCREMENT,
+	title VARCHAR(255) NOT NULL,
+	wikipedia VARCHAR(255),
+	PRIMARY KEY(id)
+);
+
+CREATE TABLE IF NOT EXISTS `tracker`.`series_authors` (
+	series_id INTEGER NOT NULL,
+	position INTEGER NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	PRIMARY KEY(series_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS `tracker`.`volumes` (
+	series_id INTEGER NOT NULL,
+	number INTEGER NOT NULL,
+	title VARCHAR(255) NOT NULL,
+	published DATE,
+	isbn VARCHAR(32) NOT NULL DEFAULT '',
+	PRIMARY KEY(series_id, number)
+);
+
+CREATE TABLE IF NOT EXISTS `tracker`.`games` (
+	id INTEGER NOT NULL AUTO_INCREMENT,
+	title VARCHAR(255) NOT NULL,
+	wikipedia VARCHAR(255),
+	platforms TEXT,
+	PRIMARY KEY(id)
+);
+
+CREATE TABLE IF NOT EXISTS `tracker`.`game_releases` (
+	game_id INTEGER NOT NULL,
+	kind VARCHAR(16) NOT NULL,
+	platform VARCHAR(64) NOT NULL DEFAULT '',
+	title VARCHAR(255) NOT NULL DEFAULT '',
+	release_date DATE,
+	PRIMARY KEY(game_id, kind, platform, title)
+);
+
+CREATE TABLE IF NOT EXISTS `tracker`.`podcasts` (
+	id INTEGER NOT NULL AUTO_INCREMENT,
+	title VARCHAR(255) NOT NULL,
+	feed VARCHAR(1024) NOT NULL,
+	website VARCHAR(1024),
+	PRIMARY KEY(id)
+);
+
+CREATE TABLE IF NOT EXISTS `tracker`.`podcast_episodes` (
+	podcast_id INTEGER NOT NULL,
+	guid VARCHAR(255) NOT NULL,
+	title VARCHAR(255) NOT NULL,
+	published DATETIME,
+	duration INTEGER NOT NULL DEFAULT 0,
+	url VARCHAR(1024),
+	PRIMARY KEY(podcast_id, guid)
+);
+
+CREATE TABLE IF NOT EXISTS `tracker`.`scrape_state` (
+	kind VARCHAR(16) NOT NULL,
+	trackable_id INTEGER NOT NULL,
+	status VARCHAR(16) NOT NULL,
+	last_run DATETIME,
+	next_run DATETIME,
+	failures INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT,
+	PRIMARY KEY(kind, trackable_id)
+);
+
+CREATE TABLE IF NOT EXISTS `tracker`.`scrape_reports` (
+	id INTEGER NOT NULL AUTO_INCREMENT,
+	kind VARCHAR(16) NOT NULL,
+	trackable_id INTEGER NOT NULL,
+	started DATETIME(3) NOT NULL,
+	problem BOOLEAN NOT NULL,
+	report TEXT NOT NULL,
+	PRIMARY KEY(id),
+	KEY(kind, trackable_id, started),
+	KEY(started)
+);
+
 CREATE DATABASE IF NOT EXISTS `accounts`;
 
 CREATE TABLE IF NOT EXISTS `accounts`.`users` (
 	id INTEGER NOT NULL AUTO_INCREMENT,
 	username VARCHAR(255) NOT NULl,
 	email VARCHAR(255) UNIQUE NOT NULL,
+	privacy VARCHAR(16) NOT NULL DEFAULT 'friends',
 	PRIMARY KEY(id)
 );
+
+CREATE TABLE IF NOT EXISTS `accounts`.`profiles` (
+	id BIGINT NOT NULL AUTO_INCREMENT,
+	owner VARCHAR(255) NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	preferences TEXT,
+	PRIMARY KEY(id),
+	UNIQUE KEY(owner, name)
+);
+
+CREATE TABLE IF NOT EXISTS `accounts`.`follows` (
+	follower VARCHAR(255) NOT NULL,
+	profile_id BIGINT NOT NULL,
+	followee VARCHAR(255) NOT NULL,
+	created TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY(follower, profile_id, followee),
+	KEY(followee)
+);
+
+CREATE TABLE IF NOT EXISTS `accounts`.`activity` (
+	id BIGINT NOT NULL AUTO_INCREMENT,
+	user VARCHAR(255) NOT NULL,
+	profile_id BIGINT NOT NULL DEFAULT 0,
+	kind VARCHAR(32) NOT NULL,
+	created TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	show_id INTEGER NOT NULL DEFAULT 0,
+	season INTEGER NOT NULL DEFAULT 0,
+	episode INTEGER NOT NULL DEFAULT 0,
+	rating INTEGER NOT NULL DEFAULT 0,
+	target VARCHAR(255) NOT NULL DEFAULT '',
+	PRIMARY KEY(id),
+	KEY(user, created)
+);
+
+CREATE TABLE IF NOT EXISTS `accounts`.`watched` (
+	profile_id BIGINT NOT NULL,
+	show_id INTEGER NOT NULL,
+	season INTEGER NOT NULL,
+	episode INTEGER NOT NULL,
+	watched TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY(profile_id, show_id, season, episode)
+);
+
+CREATE TABLE IF NOT EXISTS `accounts`.`reading` (
+	profile_id BIGINT NOT NULL,
+	series_id INTEGER NOT NULL,
+	volume INTEGER NOT NULL,
+	state VARCHAR(16) NOT NULL,
+	page INTEGER NOT NULL DEFAULT 0,
+	updated TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY(profile_id, series_id, volume)
+);
+
+CREATE TABLE IF NOT EXISTS `accounts`.`backlog` (
+	profile_id BIGINT NOT NULL,
+	game_id INTEGER NOT NULL,
+	state VARCHAR(16) NOT NULL,
+	updated TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY(profile_id, game_id)
+);
+
+CREATE TABLE IF NOT EXISTS `accounts`.`listened` (
+	profile_id BIGINT NOT NULL,
+	podcast_id INTEGER NOT NULL,
+	guid VARCHAR(255) NOT NULL,
+	listened TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY(profile_id, podcast_id, guid)
+);
+
+CREATE TABLE IF NOT EXISTS `accounts`.`ratings` (
+	profile_id BIGINT NOT NULL,
+	show_id INTEGER NOT NULL,
+	rating INTEGER NOT NULL,
+	rated TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY(profile_id, show_id)
+);
+
+CREATE TABLE IF NOT EXISTS `accounts`.`import_queue` (
+	id BIGINT NOT NULL AUTO_INCREMENT,
+	profile_id BIGINT NOT NULL,
+	source VARCHAR(32) NOT NULL,
+	title VARCHAR(255) NOT NULL,
+	year INTEGER NOT NULL DEFAULT 0,
+	imdb_id VARCHAR(32) NOT NULL DEFAULT '',
+	tvdb_id VARCHAR(32) NOT NULL DEFAULT '',
+	tmdb_id VARCHAR(32) NOT NULL DEFAULT '',
+	season INTEGER NOT NULL DEFAULT 0,
+	episode INTEGER NOT NULL DEFAULT 0,
+	rating INTEGER NOT NULL DEFAULT 0,
+	watched TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	reason VARCHAR(255) NOT NULL DEFAULT '',
+	PRIMARY KEY(id),
+	KEY(profile_id)
+);
+
+CREATE TABLE IF NOT EXISTS `accounts`.`sessions` (
+	id VARCHAR(64) NOT NULL,
+	email VARCHAR(255) NOT NULL,
+	created TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	user_agent VARCHAR(255) NOT NULL DEFAULT '',
+	address VARCHAR(64) NOT NULL DEFAULT '',
+	PRIMARY KEY(id),
+	KEY(email)
+);
@@ -2,6 +2,7 @@ package scrape
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"golang.org/x/net/html"
@@ -35,8 +36,19 @@ func (s *Tag) FindAll(tag string, params map[string]string) []*Tag {
 	return findTags(s.bytes, tag, params, -1)
 }
 
+// FindAllOf will return all Tags matching any of the given tags, in the order
+// they appear in the document.
+func (s *Tag) FindAllOf(tags []string, params map[string]string) []*Tag {
+	return findTagsOf(s.bytes, tags, params, -1)
+}
+
 // findTags will return "count" matching Tags
 func findTags(bytes []byte, tag string, params map[string]string, count int) []*Tag {
+	return findTagsOf(bytes, []string{tag}, params, count)
+}
+
+// findTagsOf will return "count" Tags matching any of the tags
+func findTagsOf(bytes []byte, tagNames []string, params map[string]string, count int) []*Tag {
 	tags := make([]*Tag, 0)
 
 	tokenizer := html.NewTokenizer(strings.NewReader(string(bytes)))
@@ -50,7 +62,7 @@ func findTags(bytes []byte, tag string, params map[string]string, count int) []*
 
 		if tagType == html.StartTagToken {
 			currentTag := tokenizer.Token()
-			if currentTag.Data == tag {
+			if containsTag(tagNames, currentTag.Data) {
 
 				// Return a "tag" object instead of just a html token
 				tagData := &Tag{
@@ -72,6 +84,15 @@ func findTags(bytes []byte, tag string, params map[string]string, count int) []*
 	return tags
 }
 
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
 // Text will retrieve all text from inside a tag
 func (t *Tag) Text() string {
 	text := ""
@@ -88,6 +109,47 @@ func (t *Tag) Text() string {
 	return text
 }
 
+// lineBreaks are the tags which start a new line when rendered.
+var lineBreaks = map[string]bool{
+	"br": true, "p": true, "div": true, "li": true, "ul": true, "ol": true,
+	"dl": true, "dt": true, "dd": true, "tr": true, "table": true,
+}
+
+// spaceBeforePunctuation matches the whitespace left between inline tags and
+// the punctuation following them.
+var spaceBeforePunctuation = regexp.MustCompile(`\s+([,.;:)])`)
+
+// Lines will retrieve the text from inside a tag split into lines, breaking
+// at line breaks and block tags such as list items. Whitespace within each
+// line is collapsed and empty lines are dropped.
+func (t *Tag) Lines() []string {
+	lines := make([]string, 0)
+	line := ""
+	flush := func() {
+		l := strings.Join(strings.Fields(line), " ")
+		l = spaceBeforePunctuation.ReplaceAllString(l, "$1")
+		if l != "" {
+			lines = append(lines, l)
+		}
+		line = ""
+	}
+
+	tokenizer := html.NewTokenizer(strings.NewReader(string(t.bytes)))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			flush()
+			return lines
+		case html.TextToken:
+			line += " " + tokenizer.Token().Data
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			if lineBreaks[tokenizer.Token().Data] {
+				flush()
+			}
+		}
+	}
+}
+
 // tagContents returns the HTML contained within the current Tag
 func tagContents(token html.Token, tokenizer *html.Tokenizer) []byte {
 	// Start at a given tag and work your way down until the depth gets back to 0.
@@ -155,6 +217,11 @@ func looseMatch(expected, actual string) bool {
 	return false
 }
 
+// Name returns the name of the tag, such as "table"
+func (t *Tag) Name() string {
+	return t.token.Data
+}
+
 // GetAttr will return the value of a specific attribute for the current Tag
 func (t *Tag) GetAttr(attr string) (string, bool) {
 	for _, attribute := range t.token.Attr {
@@ -3,6 +3,7 @@ package scrape
 import (
 	"fmt"
 	"io/ioutil"
+	"strings"
 	"testing"
 )
 
@@ -37,3 +38,54 @@ func TestLinkGathering(t *testing.T) {
 		}
 	}
 }
+
+func TestFindAllOf(t *testing.T) {
+	bytes, err := ioutil.ReadFile("testdata/table-test.html")
+	if err != nil {
+		t.Fatalf("Unable to read file; %v", err)
+	}
+
+	scraper, err := Create(bytes)
+	if err != nil {
+		t.Fatalf("Unable to create scraper; %v", err)
+	}
+
+	cells := scraper.FindFirst("tr", nil).FindAllOf([]string{"th", "td"}, nil)
+	expected := []string{"1", "Title", "2020"}
+	if len(cells) != len(expected) {
+		t.Fatalf("Expected to match %d cells. Instead found %d", len(expected), len(cells))
+	}
+
+	for i, cell := range cells {
+		if name := cell.Name(); name != "th" && name != "td" {
+			t.Fatalf("Expected cell %d to be a th or td but found '%s'", i, name)
+		}
+		if actual := strings.TrimSpace(cell.Text()); actual != expected[i] {
+			t.Fatalf("Expected cell %d to equal '%s' but found '%s'", i, expected[i], actual)
+		}
+	}
+}
+
+func TestLines(t *testing.T) {
+	bytes, err := ioutil.ReadFile("testdata/lines-test.html")
+	if err != nil {
+		t.Fatalf("Unable to read file; %v", err)
+	}
+
+	scraper, err := Create(bytes)
+	if err != nil {
+		t.Fatalf("Unable to create scraper; %v", err)
+	}
+
+	lines := scraper.FindFirst("div", attrs{"class": "release"}).Lines()
+	expected := []string{"Windows, PS4", "WW: May 1, 2020"}
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines. Instead found %d: %q", len(expected), len(lines), lines)
+	}
+
+	for i, line := range lines {
+		if line != expected[i] {
+			t.Fatalf("Expected line %d to equal '%s' but found '%s'", i, expected[i], line)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 
+	types "tracker/internal/types/user"
 	"tracker/server/host"
 	"tracker/server/page"
 
@@ -101,15 +102,7 @@ func loginRequest(w http.ResponseWriter, r *http.Request) {
 }
 
 func logoutRequest(w http.ResponseWriter, r *http.Request) {
-	session, err := store.Get(r, "tracker")
-	if err != nil {
-		serveError(err, w)
-		return
-	}
-
-	session.Options.MaxAge = -1
-	err = session.Save(r, w)
-	if err != nil {
+	if err := EndSession(w, r); err != nil {
 		serveError(err, w)
 		return
 	}
@@ -165,6 +158,18 @@ func authRequest(w http.ResponseWriter, r *http.Request) {
 	session.Values["user-id"] = user.Email
 	session.Save(r, w)
 
+	if sessionRecorder != nil {
+		err := sessionRecorder.Record(r.Context(), &types.Session{
+			ID:        randomString(),
+			Email:     user.Email,
+			UserAgent: r.UserAgent(),
+			Address:   r.RemoteAddr,
+		})
+		if err != nil {
+			fmt.Printf("Unable to record session: %v\n", err)
+		}
+	}
+
 	http.Redirect(w, r, fmt.Sprintf("%s/show", api.host.Address()), http.StatusSeeOther)
 }
 
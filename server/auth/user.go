@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"strings"
 
 	"tracker/database"
+	types "tracker/internal/types/user"
 )
 
 var (
@@ -40,6 +42,45 @@ var createUserStmt *sql.Stmt
 type User struct {
 	Username string
 	Email    string
+
+	// Profile is the active profile of the user, and Profiles are all
+	// profiles belonging to the account. These are only set when a
+	// ProfileResolver is in use.
+	Profile  *types.Profile
+	Profiles []*types.Profile
+}
+
+// ProfileID returns the ID of the active profile, or 0 if there is none.
+func (u User) ProfileID() int64 {
+	if u.Profile == nil {
+		return 0
+	}
+	return u.Profile.ID
+}
+
+// ProfileResolver resolves the active profile of an account.
+type ProfileResolver interface {
+	Active(ctx context.Context, owner string, id int64) (*types.Profile, []*types.Profile, error)
+}
+
+var profiles ProfileResolver
+
+// UseProfiles sets the resolver used to fill in the profiles of the current
+// user.
+func UseProfiles(p ProfileResolver) {
+	profiles = p
+}
+
+// SessionRecorder keeps a record of every login.
+type SessionRecorder interface {
+	Record(ctx context.Context, s *types.Session) error
+}
+
+var sessionRecorder SessionRecorder
+
+// UseSessions sets the recorder used to keep track of logins.
+func UseSessions(s SessionRecorder) {
+	sessionRecorder = s
 }
 
 func (u *User) Scan(rows *sql.Row) error {
@@ -58,7 +99,40 @@ func CurrentUser(r *http.Request) (User, error) {
 		return User{}, nil
 	}
 	user, err := LoadUser(id.(string))
-	return *user, err
+	if err != nil {
+		return User{}, err
+	}
+
+	if profiles != nil {
+		profileID, _ := session.Values["profile-id"].(int64)
+		user.Profile, user.Profiles, err = profiles.Active(r.Context(), user.Email, profileID)
+		if err != nil {
+			return *user, fmt.Errorf("unable to get active profile: %w", err)
+		}
+	}
+	return *user, nil
+}
+
+// EndSession removes the session of the user, logging them out.
+func EndSession(w http.ResponseWriter, r *http.Request) error {
+	session, err := GetSession(r, "tracker")
+	if err != nil {
+		return err
+	}
+
+	session.Options.MaxAge = -1
+	return session.Save(r, w)
+}
+
+// SetActiveProfile stores the active profile in the session of the user.
+func SetActiveProfile(w http.ResponseWriter, r *http.Request, id int64) error {
+	session, err := GetSession(r, "tracker")
+	if err != nil {
+		return err
+	}
+
+	session.Values["profile-id"] = id
+	return session.Save(r, w)
 }
 
 func LoadUser(email string) (*User, error) {
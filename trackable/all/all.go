@@ -0,0 +1,12 @@
+// Package all registers every kind of trackable. Commands import it for its
+// side effects, so that new kinds only have to be added here.
+package all
+
+import (
+	_ "tracker/trackable/book"
+	_ "tracker/trackable/game"
+	_ "tracker/trackable/movie"
+	_ "tracker/trackable/music"
+	_ "tracker/trackable/podcast"
+	_ "tracker/trackable/show"
+)
@@ -0,0 +1,265 @@
+package book
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"tracker/database"
+	"tracker/trackable"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// Series struct must implement Trackable
+type Series struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	WikipediaURL string    `json:"wikipedia"`
+	Authors      []string  `json:"authors"`
+	Volumes      []*Volume `json:"volumes"`
+}
+
+// Volume is a single book of a series. Volumes without a known publication
+// date have a zero Published time.
+type Volume struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Published time.Time `json:"published"`
+	ISBN      string    `json:"isbn"`
+}
+
+// Released returns true if the volume has been published before the given time.
+func (v *Volume) Released(now time.Time) bool {
+	return !v.Published.IsZero() && !v.Published.After(now)
+}
+
+// Write persists the volumes and authors of the series in a single
+// transaction. Volumes which are no longer listed are removed.
+func (s *Series) Write() error {
+	db, err := database.Open("tracker")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stored, err := storedVolumes(tx, s.ID)
+	if err != nil {
+		return fmt.Errorf("unable to write series %d: %w", s.ID, err)
+	}
+	for _, v := range s.Volumes {
+		_, err = tx.Exec(`REPLACE INTO volumes(series_id, number, title, published, isbn)
+		                  VALUES(?, ?, ?, ?, ?)`, s.ID, v.Number, v.Title,
+			sql.NullTime{Time: v.Published, Valid: !v.Published.IsZero()}, v.ISBN)
+		if err != nil {
+			return fmt.Errorf("unable to write volume %d of series %d: %w", v.Number, s.ID, err)
+		}
+	}
+
+	// A series without volumes is more likely a bibliography which couldn't
+	// be parsed than an empty one, so nothing is removed then.
+	if len(s.Volumes) > 0 {
+		for _, n := range removedVolumes(stored, s.Volumes) {
+			if _, err := tx.Exec("DELETE FROM volumes WHERE series_id=? AND number=?", s.ID, n); err != nil {
+				return fmt.Errorf("unable to remove volume %d of series %d: %w", n, s.ID, err)
+			}
+		}
+	}
+
+	if len(s.Authors) > 0 {
+		if _, err := tx.Exec("DELETE FROM series_authors WHERE series_id=?", s.ID); err != nil {
+			return fmt.Errorf("unable to clear authors: %w", err)
+		}
+	}
+	for i, author := range s.Authors {
+		_, err = tx.Exec(`INSERT INTO series_authors(series_id, position, name)
+		                  VALUES(?, ?, ?)`, s.ID, i, author)
+		if err != nil {
+			return fmt.Errorf("unable to write author %q of series %d: %w", author, s.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("unable to commit series %d: %w", s.ID, err)
+	}
+	return nil
+}
+
+// storedVolumes returns the numbers of the stored volumes of the series,
+// which are locked until the transaction ends.
+func storedVolumes(tx *sql.Tx, seriesID int) ([]int, error) {
+	rows, err := tx.Query("SELECT number FROM volumes WHERE series_id=? FOR UPDATE", seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query volumes: %w", err)
+	}
+	defer rows.Close()
+
+	var numbers []int
+	for rows.Next() {
+		var n int
+		if err := rows.Scan(&n); err != nil {
+			return nil, fmt.Errorf("unable to scan volume: %w", err)
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, rows.Err()
+}
+
+// removedVolumes returns the numbers of the stored volumes which are no
+// longer listed.
+func removedVolumes(stored []int, volumes []*Volume) []int {
+	listed := map[int]bool{}
+	for _, v := range volumes {
+		listed[v.Number] = true
+	}
+	var removed []int
+	for _, n := range stored {
+		if !listed[n] {
+			removed = append(removed, n)
+		}
+	}
+	return removed
+}
+
+func (s *Series) Ref() trackable.Ref {
+	return trackable.Ref{Kind: Kind, ID: s.ID}
+}
+
+// Releases returns the volumes published in the range.
+func (s *Series) Releases(start, end time.Time) []*trackable.Release {
+	releases := make([]*trackable.Release, 0)
+	for _, v := range s.Volumes {
+		if v.Published.Before(start) || !v.Published.Before(end) {
+			continue
+		}
+		releases = append(releases, &trackable.Release{
+			Ref:   s.Ref(),
+			Name:  s.Name,
+			Title: fmt.Sprintf("Vol. %d %s", v.Number, v.Title),
+			Date:  v.Published,
+		})
+	}
+	return releases
+}
+
+// LatestVolume returns the most recently published volume.
+func (s *Series) LatestVolume(now time.Time) *Volume {
+	var latest *Volume
+	for _, v := range s.Volumes {
+		if v.Released(now) {
+			latest = v
+		}
+	}
+	return latest
+}
+
+// NextVolume returns the first volume which hasn't been published yet.
+func (s *Series) NextVolume(now time.Time) *Volume {
+	for _, v := range s.Volumes {
+		if !v.Released(now) {
+			return v
+		}
+	}
+	return nil
+}
+
+// sortVolumes orders the volumes by their number.
+func sortVolumes(volumes []*Volume) {
+	sort.SliceStable(volumes, func(i, j int) bool {
+		return volumes[i].Number < volumes[j].Number
+	})
+}
+
+func (s *Series) String() string {
+	volumeString := ""
+	for _, v := range s.Volumes {
+		volumeString += "\t" + v.String() + "\n"
+	}
+
+	return fmt.Sprintf("%-2d - %-30s - %3d Volumes, WikipediaURL='%s'\n%s", s.ID, s.Name,
+		len(s.Volumes), s.WikipediaURL, volumeString)
+}
+
+func (v *Volume) String() string {
+	return fmt.Sprintf("%3d: %s - '%s'", v.Number, v.Published.Format("2006-01-02"), v.Title)
+}
+
+func loadAllSeries() ([]*Series, error) {
+	series := make([]*Series, 0)
+
+	db, err := database.Open("tracker")
+	if err != nil {
+		return series, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT id,title,wikipedia FROM series")
+	if err != nil {
+		return series, err
+	}
+	defer rows.Close()
+
+	byID := map[int]*Series{}
+	for rows.Next() {
+		s := &Series{Authors: make([]string, 0), Volumes: make([]*Volume, 0)}
+		var wikipedia sql.NullString
+		if err := rows.Scan(&s.ID, &s.Name, &wikipedia); err != nil {
+			return series, fmt.Errorf("Unable to scan series: %v", err)
+		}
+		s.WikipediaURL = wikipedia.String
+		series = append(series, s)
+		byID[s.ID] = s
+	}
+	if err := rows.Err(); err != nil {
+		return series, err
+	}
+
+	rows, err = db.Query(`SELECT series_id,number,title,published,isbn FROM volumes
+	                      ORDER BY series_id,number`)
+	if err != nil {
+		return series, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var published sql.NullTime
+		v := &Volume{}
+		if err := rows.Scan(&id, &v.Number, &v.Title, &published, &v.ISBN); err != nil {
+			return series, fmt.Errorf("Unable to scan volume: %v", err)
+		}
+		v.Published = published.Time
+		if s, ok := byID[id]; ok {
+			s.Volumes = append(s.Volumes, v)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return series, err
+	}
+
+	rows, err = db.Query("SELECT series_id,name FROM series_authors ORDER BY series_id,position")
+	if err != nil {
+		return series, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return series, fmt.Errorf("Unable to scan author: %v", err)
+		}
+		if s, ok := byID[id]; ok {
+			s.Authors = append(s.Authors, name)
+		}
+	}
+
+	return series, rows.Err()
+}
@@ -0,0 +1,250 @@
+package book
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"tracker/internal/timeutil"
+	"tracker/scrape"
+	"tracker/trackable"
+)
+
+type attr = map[string]string
+
+// Scrape the authors and the volumes of the series from Wikipedia.
+func (s *Series) Scrape(ctx context.Context) error {
+	url := fmt.Sprintf("https://en.wikipedia.org/wiki/%s", s.WikipediaURL)
+	return trackable.ScrapePage(ctx, url, s.parse)
+}
+
+// parse the article of a series, or the bibliography of an author.
+func (s *Series) parse(body []byte, report *trackable.Report) error {
+	scraper, err := scrape.Create(body)
+	if err != nil {
+		return fmt.Errorf("Unable to create scraper; %v\n", err)
+	}
+
+	infobox := scraper.FindFirst("table", attr{"class": "infobox"})
+	if infobox.Valid {
+		if authors := parseAuthors(infobox); len(authors) > 0 {
+			s.Authors = authors
+		}
+	}
+
+	volumes := make([]*Volume, 0)
+	for _, table := range scraper.FindAll("table", attr{"class": "wikitable"}) {
+		volumes = append(volumes, parseBibliography(table, report)...)
+	}
+
+	sortVolumes(volumes)
+	s.Volumes = uniqueVolumes(volumes)
+	return nil
+}
+
+// parseAuthors returns the authors listed in the infobox.
+func parseAuthors(infobox *scrape.Tag) []string {
+	for _, row := range infobox.FindAll("tr", nil) {
+		label := row.FindFirst("th", nil)
+		if !label.Valid || !strings.HasPrefix(strings.ToLower(parseString(label.Text())), "author") {
+			continue
+		}
+
+		data := row.FindFirst("td", nil)
+		if !data.Valid {
+			continue
+		}
+
+		entries := data.FindAll("li", nil)
+		if len(entries) == 0 {
+			entries = []*scrape.Tag{data}
+		}
+
+		authors := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			for _, name := range authorSeparator.Split(cleanText(entry.Text()), -1) {
+				if name = strings.TrimSpace(name); name != "" {
+					authors = append(authors, name)
+				}
+			}
+		}
+		return authors
+	}
+	return nil
+}
+
+var authorSeparator = regexp.MustCompile(`,| and `)
+
+// column is the meaning of a column of a bibliography table.
+type column int
+
+const (
+	columnUnknown column = iota
+	columnNumber
+	columnTitle
+	columnDate
+	columnISBN
+)
+
+// numberHeaders are the headers of columns containing the volume number.
+var numberHeaders = map[string]bool{
+	"#": true, "no": true, "no.": true, "vol": true, "vol.": true,
+	"volume": true, "book": true, "number": true,
+}
+
+// columnHeaders maps the start of the other headers used in bibliography
+// tables to columns.
+var columnHeaders = []struct {
+	prefix string
+	column column
+}{
+	{"title", columnTitle},
+	{"novel", columnTitle},
+	{"publication date", columnDate},
+	{"published", columnDate},
+	{"release date", columnDate},
+	{"date", columnDate},
+	{"year", columnDate},
+	{"isbn", columnISBN},
+}
+
+// parseBibliography parses a table listing the volumes of a series. Tables
+// without a title and a date column are ignored. When a table has no column
+// with the number of the volume, volumes are numbered by their row. Rows
+// which can't be parsed are skipped, and recorded in the report.
+func parseBibliography(table *scrape.Tag, report *trackable.Report) []*Volume {
+	rows := table.FindAll("tr", nil)
+	if len(rows) == 0 {
+		return nil
+	}
+
+	columns := parseHeader(rows[0])
+	if !hasColumn(columns, columnTitle) || !hasColumn(columns, columnDate) {
+		return nil
+	}
+	numbered := hasColumn(columns, columnNumber)
+
+	volumes := make([]*Volume, 0)
+	for r, row := range rows[1:] {
+		cells := rowCells(row)
+		if len(cells) < len(columns) {
+			continue
+		}
+
+		v := &Volume{Number: len(volumes) + 1}
+		for i, c := range columns {
+			text := cleanText(cells[i].Text())
+			switch c {
+			case columnNumber:
+				n, err := strconv.Atoi(strings.TrimSuffix(text, "."))
+				if err != nil {
+					report.Skip(fmt.Sprintf("row %d", r+1), "volume number %q isn't a number", text)
+					v = nil
+				} else {
+					v.Number = n
+				}
+			case columnTitle:
+				v.Title = strings.Trim(text, `"“”`)
+			case columnDate:
+				v.Published = parsePublished(text, report)
+			case columnISBN:
+				v.ISBN = strings.TrimSpace(strings.TrimPrefix(text, "ISBN"))
+			}
+			if v == nil {
+				break
+			}
+		}
+
+		if v != nil && v.Title != "" {
+			volumes = append(volumes, v)
+		}
+	}
+
+	if !numbered {
+		for i, v := range volumes {
+			v.Number = i + 1
+		}
+	}
+	return volumes
+}
+
+func parseHeader(row *scrape.Tag) []column {
+	headers := row.FindAll("th", nil)
+	columns := make([]column, len(headers))
+	for i, header := range headers {
+		text := strings.ToLower(cleanText(header.Text()))
+		if numberHeaders[text] {
+			columns[i] = columnNumber
+			continue
+		}
+		for _, h := range columnHeaders {
+			if strings.HasPrefix(text, h.prefix) {
+				columns[i] = h.column
+				break
+			}
+		}
+	}
+	return columns
+}
+
+func hasColumn(columns []column, c column) bool {
+	for _, column := range columns {
+		if column == c {
+			return true
+		}
+	}
+	return false
+}
+
+// rowCells returns the header and data cells of the row in order. Titles are
+// often the header of a row, so both kinds are needed.
+func rowCells(row *scrape.Tag) []*scrape.Tag {
+	return row.FindAllOf([]string{"th", "td"}, nil)
+}
+
+var yearRegexp = regexp.MustCompile(`^[0-9]{4}$`)
+
+// parsePublished parses the publication date of a volume, which is either a
+// full date or only the year of publication. Dates which can't be parsed are
+// recorded in the report.
+func parsePublished(text string, report *trackable.Report) time.Time {
+	if yearRegexp.MatchString(text) {
+		year, _ := strconv.Atoi(text)
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	date, err := timeutil.Parse(text)
+	if err != nil {
+		report.Warn("publication date %q isn't a date", text)
+	}
+	return date
+}
+
+// uniqueVolumes keeps the first volume of each number, as series are often
+// listed in multiple tables, for example one per edition.
+func uniqueVolumes(volumes []*Volume) []*Volume {
+	seen := map[int]bool{}
+	unique := make([]*Volume, 0, len(volumes))
+	for _, v := range volumes {
+		if seen[v.Number] {
+			continue
+		}
+		seen[v.Number] = true
+		unique = append(unique, v)
+	}
+	return unique
+}
+
+var referenceRegexp = regexp.MustCompile(`\[[^\]]*\]`)
+
+// cleanText removes references and redundant whitespace.
+func cleanText(str string) string {
+	return parseString(referenceRegexp.ReplaceAllString(str, ""))
+}
+
+func parseString(str string) string {
+	return strings.Join(strings.Fields(str), " ")
+}
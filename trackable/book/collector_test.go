@@ -0,0 +1,62 @@
+package book
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+func TestParse(t *testing.T) {
+	body, err := ioutil.ReadFile("testdata/series.html")
+	if err != nil {
+		t.Fatalf("unable to read file: %v", err)
+	}
+
+	s := &Series{}
+	if err := s.parse(body, nil); err != nil {
+		t.Fatalf("parse() err = %v, want %v", err, nil)
+	}
+
+	want := &Series{
+		Authors: []string{"Jane Writer", "John Writer"},
+		Volumes: []*Volume{
+			{Number: 1, Title: "The First Book", ISBN: "978-0-00-000001-1",
+				Published: time.Date(2015, time.May, 1, 0, 0, 0, 0, time.UTC)},
+			{Number: 2, Title: "The Second Book", ISBN: "978-0-00-000002-8",
+				Published: time.Date(2017, time.June, 2, 0, 0, 0, 0, time.UTC)},
+			{Number: 3, Title: "The Third Book"},
+		},
+	}
+	if diff := deep.Equal(s, want); diff != nil {
+		t.Errorf("parse() diff = %v", diff)
+	}
+}
+
+func TestNextVolume(t *testing.T) {
+	now := time.Date(2021, time.March, 6, 0, 0, 0, 0, time.UTC)
+	s := &Series{Volumes: []*Volume{
+		{Number: 1, Published: now.AddDate(-1, 0, 0)},
+		{Number: 2, Published: now.AddDate(0, 1, 0)},
+		{Number: 3},
+	}}
+
+	if got := s.LatestVolume(now); got != s.Volumes[0] {
+		t.Errorf("LatestVolume() = %v, want %v", got, s.Volumes[0])
+	}
+	if got := s.NextVolume(now); got != s.Volumes[1] {
+		t.Errorf("NextVolume() = %v, want %v", got, s.Volumes[1])
+	}
+	if got := len(s.Releases(now, now.AddDate(1, 0, 0))); got != 1 {
+		t.Errorf("Releases() returned %d releases, want %d", got, 1)
+	}
+}
+
+func TestRemovedVolumes(t *testing.T) {
+	volumes := []*Volume{{Number: 1, Title: "Dawn"}, {Number: 2, Title: "Dusk"}, {Number: 4, Title: "Night"}}
+
+	if diff := deep.Equal(removedVolumes([]int{1, 2, 3, 5}, volumes), []int{3, 5}); diff != nil {
+		t.Errorf("removedVolumes() diff = %v", diff)
+	}
+}
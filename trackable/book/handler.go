@@ -0,0 +1,112 @@
+package book
+
+import (
+	"fmt"
+	"time"
+
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+
+	"go.uber.org/zap"
+)
+
+// Handler will take care of database loading and API prepping for Series.
+type Handler struct {
+	series []*Series
+}
+
+func (h *Handler) Init() {
+	series, err := loadAllSeries()
+	if err != nil {
+		h.series = make([]*Series, 0)
+		zap.L().Error("unable to load series", zap.Error(err))
+	} else {
+		h.series = series
+	}
+}
+
+type SeriesSimple struct {
+	ID      int
+	Name    string
+	Authors []string
+}
+
+type SeriesList struct {
+	Count  int
+	Series []*SeriesSimple
+}
+
+type SeriesFull struct {
+	*Series
+
+	LatestVolume *Volume `json:"latest_volume"`
+	NextVolume   *Volume `json:"next_volume"`
+}
+
+var listFilters = map[string]func(*Series, time.Time) bool{
+	"all":      listFilterAll,
+	"upcoming": listFilterUpcoming,
+}
+
+func (h *Handler) Get(id int) (*SeriesFull, error) {
+	for _, s := range h.series {
+		if s.ID == id {
+			now := time.Now()
+			return &SeriesFull{
+				Series:       s,
+				LatestVolume: s.LatestVolume(now),
+				NextVolume:   s.NextVolume(now),
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("Invalid series ID")
+}
+
+func (h *Handler) GetList(listType string) (*SeriesList, error) {
+	filter, ok := listFilters[listType]
+	if !ok {
+		return nil, fmt.Errorf("Unknown list type: %s", listType)
+	}
+
+	now := time.Now()
+	series := make([]*SeriesSimple, 0)
+	for _, s := range h.series {
+		if filter(s, now) {
+			series = append(series, &SeriesSimple{ID: s.ID, Name: s.Name, Authors: s.Authors})
+		}
+	}
+	return &SeriesList{
+		Count:  len(series),
+		Series: series,
+	}, nil
+}
+
+// GetSchedule returns every volume published between start and end.
+func (h *Handler) GetSchedule(start, end string) (*trackable.Schedule, error) {
+	startDate, endDate, err := trackable.ParseRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]*trackable.Release, 0)
+	for _, s := range h.series {
+		releases = append(releases, s.Releases(startDate, endDate)...)
+	}
+
+	return &trackable.Schedule{
+		StartDate: timeutil.JSONTime(startDate),
+		EndDate:   timeutil.JSONTime(endDate),
+		Releases:  releases,
+	}, nil
+}
+
+// listFilterAll will always return true.
+func listFilterAll(*Series, time.Time) bool {
+	return true
+}
+
+// listFilterUpcoming will return true for series with an announced volume.
+func listFilterUpcoming(s *Series, now time.Time) bool {
+	next := s.NextVolume(now)
+	return next != nil && !next.Published.IsZero()
+}
@@ -0,0 +1,35 @@
+package book
+
+import (
+	"context"
+
+	"tracker/trackable"
+)
+
+// Kind of the book trackable.
+const Kind trackable.Kind = "book"
+
+var _ trackable.Trackable = &Series{}
+
+func init() {
+	trackable.Register(&trackable.Module{
+		Kind: Kind,
+		Name: "Books",
+		API:  &API{},
+		Load: load,
+	})
+}
+
+// load all series as trackables.
+func load(context.Context) ([]trackable.Trackable, error) {
+	items, err := loadAllSeries()
+	if err != nil {
+		return nil, err
+	}
+
+	trackables := make([]trackable.Trackable, len(items))
+	for i, item := range items {
+		trackables[i] = item
+	}
+	return trackables, nil
+}
@@ -0,0 +1,91 @@
+package calendar
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"tracker/server/host"
+	"tracker/server/page"
+	"tracker/trackable"
+
+	"github.com/gorilla/mux"
+)
+
+// API implements server.API, serving the calendar of all registered kinds.
+type API struct {
+	handler Handler
+}
+
+func (a *API) RegisterHandlers(subdomain string) {
+	rtr := mux.NewRouter()
+	rtr.HandleFunc(fmt.Sprintf("/%s/", subdomain), a.defaultRequest)
+	rtr.HandleFunc(fmt.Sprintf("/%s/get/{start:[0-9-]+}/{end:[0-9-]+}.ics", subdomain),
+		a.icalRequest)
+	rtr.HandleFunc(fmt.Sprintf("/%s/get/{start:[0-9-]+}/{end:[0-9-]+}", subdomain),
+		a.calendarRequest)
+
+	http.Handle(fmt.Sprintf("/%s/", subdomain), rtr)
+}
+
+func (a *API) Init(*host.Host) error {
+	fmt.Println("Calendar API Initialised")
+	return nil
+}
+
+func (a *API) defaultRequest(w http.ResponseWriter, r *http.Request) {
+	p := page.Page{Body: []byte("Calendar API landing page - Perhaps serve a README here?")}
+	p.ServePage(w)
+}
+
+// calendarRequest serves the calendar as JSON. The kinds included can be
+// limited with any number of kind query parameters.
+func (a *API) calendarRequest(w http.ResponseWriter, r *http.Request) {
+	c, err := a.calendar(r)
+	if err != nil {
+		serveError(err, w, r)
+		return
+	}
+
+	body, err := json.Marshal(c)
+	if err != nil {
+		serveError(err, w, r)
+		return
+	}
+	p := page.Page{Body: body}
+	p.ServePage(w)
+}
+
+// icalRequest serves the calendar as an iCalendar feed.
+func (a *API) icalRequest(w http.ResponseWriter, r *http.Request) {
+	c, err := a.calendar(r)
+	if err != nil {
+		serveError(err, w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	if err := WriteICal(w, c, time.Now()); err != nil {
+		fmt.Printf("Error writing calendar: %v\n", err)
+	}
+}
+
+func (a *API) calendar(r *http.Request) (*Calendar, error) {
+	params := mux.Vars(r)
+	return a.handler.GetCalendar(r.Context(), params["start"], params["end"], Kinds(r))
+}
+
+// Kinds returns the kinds requested with kind query parameters.
+func Kinds(r *http.Request) []trackable.Kind {
+	kinds := make([]trackable.Kind, 0)
+	for _, k := range r.URL.Query()["kind"] {
+		kinds = append(kinds, trackable.Kind(k))
+	}
+	return kinds
+}
+
+func serveError(err error, w http.ResponseWriter, r *http.Request) {
+	p := page.Page{Body: []byte(fmt.Sprintf("Error occured: %v", err.Error()))}
+	p.ServePage(w)
+}
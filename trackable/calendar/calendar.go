@@ -0,0 +1,125 @@
+// Package calendar merges the releases of every kind of trackable into a
+// single schedule.
+package calendar
+
+import (
+	"sort"
+	"time"
+
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+)
+
+// Calendar contains every release within a range of days, grouped by day.
+type Calendar struct {
+	StartDate timeutil.JSONTime `json:"start_date"`
+	EndDate   timeutil.JSONTime `json:"end_date"`
+
+	// Kinds lists every kind which can be shown on the calendar, whether or
+	// not it has been filtered out.
+	Kinds []*Kind `json:"kinds"`
+	Days  []*Day  `json:"days"`
+
+	// Unscheduled are the releases which may be released within the range,
+	// but whose date isn't known to the day.
+	Unscheduled []*trackable.Release `json:"unscheduled"`
+}
+
+// Kind of trackable on the calendar, along with its name shown to users.
+type Kind struct {
+	Kind     trackable.Kind `json:"kind"`
+	Name     string         `json:"name"`
+	Selected bool           `json:"selected"`
+}
+
+// Day of the calendar, and all releases on it.
+type Day struct {
+	Date     timeutil.JSONTime    `json:"date"`
+	Releases []*trackable.Release `json:"releases"`
+}
+
+// Count returns the number of releases on the calendar.
+func (c *Calendar) Count() int {
+	count := 0
+	for _, d := range c.Days {
+		count += len(d.Releases)
+	}
+	return count
+}
+
+// Build the calendar of the releases of the trackables from start to end,
+// both included. Only the given kinds are included, or every kind if none are
+// given.
+func Build(items []trackable.Trackable, start, end time.Time, kinds ...trackable.Kind) *Calendar {
+	selected := make(map[trackable.Kind]bool, len(kinds))
+	for _, k := range kinds {
+		selected[k] = true
+	}
+
+	c := &Calendar{
+		StartDate: timeutil.JSONTime(start),
+		EndDate:   timeutil.JSONTime(end),
+		Kinds:     make([]*Kind, 0),
+		Days:      make([]*Day, 0),
+
+		Unscheduled: make([]*trackable.Release, 0),
+	}
+
+	byDate := map[string]*Day{}
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		day := &Day{Date: timeutil.JSONTime(d), Releases: make([]*trackable.Release, 0)}
+		c.Days = append(c.Days, day)
+		byDate[timeutil.String(d)] = day
+	}
+
+	for _, m := range trackable.Modules() {
+		c.Kinds = append(c.Kinds, &Kind{
+			Kind:     m.Kind,
+			Name:     m.Name,
+			Selected: len(selected) == 0 || selected[m.Kind],
+		})
+	}
+
+	// Releases are in [start, end), but the calendar includes its last day.
+	until := end.AddDate(0, 0, 1)
+	for _, item := range items {
+		if len(selected) > 0 && !selected[item.Ref().Kind] {
+			continue
+		}
+		for _, r := range item.Releases(start, until) {
+			if day, ok := byDate[timeutil.String(r.Date)]; ok {
+				day.Releases = append(day.Releases, r)
+			}
+		}
+		if u, ok := item.(trackable.Unscheduler); ok {
+			c.Unscheduled = append(c.Unscheduled, u.Unscheduled(start, until)...)
+		}
+	}
+
+	for _, day := range c.Days {
+		sortReleases(day.Releases)
+	}
+	sortReleases(c.Unscheduled)
+	return c
+}
+
+// sortReleases orders the releases of a day by kind, then by the name of the
+// trackable and the order of its releases.
+func sortReleases(releases []*trackable.Release) {
+	sort.SliceStable(releases, func(i, j int) bool {
+		a, b := releases[i], releases[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Season != b.Season {
+			return a.Season < b.Season
+		}
+		if a.Number != b.Number {
+			return a.Number < b.Number
+		}
+		return a.Date.Before(b.Date)
+	})
+}
@@ -0,0 +1,223 @@
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+)
+
+func init() {
+	trackable.Register(&trackable.Module{Kind: "movie", Name: "Movies", Load: load("movie")})
+	trackable.Register(&trackable.Module{Kind: "show", Name: "Shows", Load: load("show")})
+}
+
+// stored are the trackables loaded by the registered kinds.
+var stored []trackable.Trackable
+
+func load(kind trackable.Kind) func(context.Context) ([]trackable.Trackable, error) {
+	return func(context.Context) ([]trackable.Trackable, error) {
+		loaded := make([]trackable.Trackable, 0)
+		for _, item := range stored {
+			if item.Ref().Kind == kind {
+				loaded = append(loaded, item)
+			}
+		}
+		return loaded, nil
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// testTrackable releases on every date it is given.
+type testTrackable struct {
+	ref      trackable.Ref
+	releases []*trackable.Release
+}
+
+func (t *testTrackable) Ref() trackable.Ref               { return t.ref }
+func (t *testTrackable) Scrape(ctx context.Context) error { return nil }
+func (t *testTrackable) Write() error                     { return nil }
+
+func (t *testTrackable) Releases(start, end time.Time) []*trackable.Release {
+	releases := make([]*trackable.Release, 0)
+	for _, r := range t.releases {
+		if !r.Date.Before(start) && r.Date.Before(end) {
+			releases = append(releases, r)
+		}
+	}
+	return releases
+}
+
+var (
+	showRef  = trackable.Ref{Kind: "show", ID: 1}
+	movieRef = trackable.Ref{Kind: "movie", ID: 2}
+
+	pilot    = &trackable.Release{Ref: showRef, Name: "Show", Title: "Pilot", Date: date(2021, time.March, 1), Season: 1, Number: 1}
+	second   = &trackable.Release{Ref: showRef, Name: "Show", Title: "Second", Date: date(2021, time.March, 3), Season: 1, Number: 2}
+	finale   = &trackable.Release{Ref: showRef, Name: "Show", Title: "Finale", Date: date(2021, time.March, 10), Season: 1, Number: 3}
+	premiere = &trackable.Release{Ref: movieRef, Name: "Movie", Title: "Release (US)", Date: date(2021, time.March, 3)}
+
+	items = []trackable.Trackable{
+		&testTrackable{ref: showRef, releases: []*trackable.Release{pilot, second, finale}},
+		&testTrackable{ref: movieRef, releases: []*trackable.Release{premiere}},
+	}
+)
+
+func TestBuild(t *testing.T) {
+	testCases := map[string]struct {
+		kinds     []trackable.Kind
+		releases  map[string][]*trackable.Release
+		showsOnly bool
+	}{
+		"all kinds": {
+			releases: map[string][]*trackable.Release{
+				"2021-03-01": {pilot},
+				"2021-03-03": {premiere, second},
+			},
+		},
+		"shows": {
+			kinds: []trackable.Kind{"show"},
+			releases: map[string][]*trackable.Release{
+				"2021-03-01": {pilot},
+				"2021-03-03": {second},
+			},
+			showsOnly: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := Build(items, date(2021, time.March, 1), date(2021, time.March, 3), tc.kinds...)
+
+			if len(c.Days) != 3 {
+				t.Fatalf("Build() days = %d, want %d", len(c.Days), 3)
+			}
+			got := map[string][]*trackable.Release{}
+			for _, d := range c.Days {
+				if len(d.Releases) > 0 {
+					got[time.Time(d.Date).Format("2006-01-02")] = d.Releases
+				}
+			}
+			if diff := deep.Equal(got, tc.releases); diff != nil {
+				t.Errorf("Build() diff = %v", diff)
+			}
+
+			wantKinds := []*Kind{
+				{Kind: "movie", Name: "Movies", Selected: !tc.showsOnly},
+				{Kind: "show", Name: "Shows", Selected: true},
+			}
+			if diff := deep.Equal(c.Kinds, wantKinds); diff != nil {
+				t.Errorf("Build() kinds diff = %v", diff)
+			}
+		})
+	}
+}
+
+// unscheduledTrackable has releases whose date isn't known to the day.
+type unscheduledTrackable struct {
+	testTrackable
+	unscheduled []*trackable.Release
+}
+
+func (t *unscheduledTrackable) Unscheduled(start, end time.Time) []*trackable.Release {
+	releases := make([]*trackable.Release, 0)
+	for _, r := range t.unscheduled {
+		if r.PartialDate().Overlaps(start, end) {
+			releases = append(releases, r)
+		}
+	}
+	return releases
+}
+
+func TestBuildUnscheduled(t *testing.T) {
+	spring := &trackable.Release{Ref: showRef, Name: "Show", Title: "TBA", Date: date(2021, time.April, 1),
+		Season: 2, Number: 1, Precision: timeutil.PrecisionQuarter}
+	march := &trackable.Release{Ref: showRef, Name: "Show", Title: "Special", Date: date(2021, time.March, 1),
+		Precision: timeutil.PrecisionMonth, Tentative: true}
+	items := []trackable.Trackable{&unscheduledTrackable{
+		testTrackable: testTrackable{ref: showRef, releases: []*trackable.Release{pilot}},
+		unscheduled:   []*trackable.Release{spring, march},
+	}}
+
+	c := Build(items, date(2021, time.March, 1), date(2021, time.March, 31))
+	if c.Count() != 1 {
+		t.Errorf("Build() count = %d, want %d", c.Count(), 1)
+	}
+	if diff := deep.Equal(c.Unscheduled, []*trackable.Release{march}); diff != nil {
+		t.Errorf("Build() unscheduled diff = %v", diff)
+	}
+}
+
+func TestWriteICal(t *testing.T) {
+	c := Build(items, date(2021, time.March, 3), date(2021, time.March, 3))
+
+	var buf bytes.Buffer
+	if err := WriteICal(&buf, c, date(2021, time.March, 1)); err != nil {
+		t.Fatalf("WriteICal() err = %v, want %v", err, nil)
+	}
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//tracker//calendar//EN",
+		"X-WR-CALNAME:Tracker",
+		"BEGIN:VEVENT",
+		"UID:movie-2-20210303-releaseus@tracker",
+		"DTSTAMP:20210301T000000Z",
+		"DTSTART;VALUE=DATE:20210303",
+		"SUMMARY:Movie Release (US)",
+		"CATEGORIES:movie",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:show-1-20210303-s1e2@tracker",
+		"DTSTAMP:20210301T000000Z",
+		"DTSTART;VALUE=DATE:20210303",
+		"SUMMARY:Show S01E02 Second",
+		"CATEGORIES:show",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	if got := buf.String(); got != want {
+		t.Errorf("WriteICal() = %q, want %q", got, want)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	r := &trackable.Release{Name: "Band", Title: "Album; Deluxe, Edition"}
+	if got, want := icalEscaper.Replace(Summary(r)), `Band Album\; Deluxe\, Edition`; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+}
+
+func TestHandlerGetCalendar(t *testing.T) {
+	t.Cleanup(func() { stored = nil })
+	h := &Handler{}
+
+	stored = items[:1]
+	c, err := h.GetCalendar(context.Background(), "2021-03-01", "2021-03-08", nil)
+	if err != nil {
+		t.Fatalf("GetCalendar() err = %v, want %v", err, nil)
+	}
+	if c.Count() != 2 {
+		t.Errorf("GetCalendar() count = %d, want %d", c.Count(), 2)
+	}
+
+	// The movie is scraped after the first calendar was built.
+	stored = items
+	if c, err = h.GetCalendar(context.Background(), "2021-03-01", "2021-03-08", nil); err != nil {
+		t.Fatalf("GetCalendar() err = %v, want %v", err, nil)
+	}
+	if c.Count() != 3 {
+		t.Errorf("GetCalendar() count = %d, want %d", c.Count(), 3)
+	}
+}
@@ -0,0 +1,49 @@
+package calendar
+
+import (
+	"context"
+
+	"tracker/trackable"
+
+	"go.uber.org/zap"
+)
+
+// Handler builds calendars of the releases of every registered trackable.
+// The trackables are loaded for every calendar, so that it includes what was
+// scraped since the server started.
+type Handler struct{}
+
+// load the trackables of the kinds, or of every registered kind if none is
+// given. A kind which fails to load is left off the calendar.
+func (h *Handler) load(ctx context.Context, kinds []trackable.Kind) []trackable.Trackable {
+	modules := trackable.Modules()
+	if len(kinds) > 0 {
+		modules = make([]*trackable.Module, 0, len(kinds))
+		for _, k := range kinds {
+			if m, ok := trackable.Lookup(k); ok {
+				modules = append(modules, m)
+			}
+		}
+	}
+
+	items := make([]trackable.Trackable, 0)
+	for _, m := range modules {
+		loaded, err := m.Load(ctx)
+		if err != nil {
+			zap.L().Error("unable to load trackables", zap.String("kind", string(m.Kind)), zap.Error(err))
+			continue
+		}
+		items = append(items, loaded...)
+	}
+	return items
+}
+
+// GetCalendar returns the calendar of the given kinds between start and end.
+func (h *Handler) GetCalendar(ctx context.Context, start, end string, kinds []trackable.Kind) (*Calendar, error) {
+	startDate, endDate, err := trackable.ParseRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return Build(h.load(ctx, kinds), startDate, endDate, kinds...), nil
+}
@@ -0,0 +1,80 @@
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"tracker/trackable"
+)
+
+// icalEscaper escapes text values as required by RFC 5545.
+var icalEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\n", `\n`,
+)
+
+// WriteICal writes the calendar as an iCalendar feed, with each release as
+// an all day event. The stamp is the time the feed was generated.
+func WriteICal(w io.Writer, c *Calendar, stamp time.Time) error {
+	b := bufio.NewWriter(w)
+	line := func(format string, a ...interface{}) {
+		fmt.Fprintf(b, format+"\r\n", a...)
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//tracker//calendar//EN")
+	line("X-WR-CALNAME:Tracker")
+	for _, day := range c.Days {
+		for _, r := range day.Releases {
+			date := r.Date.Format("20060102")
+			line("BEGIN:VEVENT")
+			line("UID:%s", releaseUID(r))
+			line("DTSTAMP:%s", stamp.UTC().Format("20060102T150405Z"))
+			line("DTSTART;VALUE=DATE:%s", date)
+			line("SUMMARY:%s", icalEscaper.Replace(Summary(r)))
+			line("CATEGORIES:%s", icalEscaper.Replace(string(r.Kind)))
+			line("END:VEVENT")
+		}
+	}
+	line("END:VCALENDAR")
+
+	return b.Flush()
+}
+
+// Summary describes the release in a single line, e.g. "Show S01E02 Title".
+func Summary(r *trackable.Release) string {
+	summary := r.Name
+	if r.Season > 0 || r.Number > 0 {
+		summary += fmt.Sprintf(" S%02dE%02d", r.Season, r.Number)
+	}
+	if r.Title != "" {
+		summary += " " + r.Title
+	}
+	return summary
+}
+
+// releaseUID identifies the release across feeds, so that calendar clients
+// update events rather than duplicate them.
+func releaseUID(r *trackable.Release) string {
+	id := fmt.Sprintf("%s-%d-%s", r.Kind, r.ID, r.Date.Format("20060102"))
+	if r.Season > 0 || r.Number > 0 {
+		id += fmt.Sprintf("-s%de%d", r.Season, r.Number)
+	} else if r.Title != "" {
+		id += "-" + strings.Map(func(c rune) rune {
+			switch {
+			case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+				return c
+			case c >= 'A' && c <= 'Z':
+				return c + 'a' - 'A'
+			}
+			return -1
+		}, r.Title)
+	}
+	return id + "@tracker"
+}
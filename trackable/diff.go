@@ -0,0 +1,46 @@
+package trackable
+
+import (
+	"context"
+	"fmt"
+)
+
+// Change is a release which was already known, but changed since.
+type Change struct {
+	Old *Release `json:"old"`
+	New *Release `json:"new"`
+}
+
+// Diff is what changed in the releases of a trackable when it was written.
+type Diff struct {
+	Ref
+
+	Added   []*Release `json:"added,omitempty"`
+	Changed []*Change  `json:"changed,omitempty"`
+	Removed []*Release `json:"removed,omitempty"`
+}
+
+// Empty returns true if nothing changed.
+func (d *Diff) Empty() bool {
+	return d == nil || len(d.Added)+len(d.Changed)+len(d.Removed) == 0
+}
+
+func (d *Diff) String() string {
+	return fmt.Sprintf("%s: %d added, %d changed, %d removed", d.Ref,
+		len(d.Added), len(d.Changed), len(d.Removed))
+}
+
+// Differ is implemented by trackables which only write what changed since
+// they were last written, and report what that was.
+type Differ interface {
+	WriteDiff(ctx context.Context) (*Diff, error)
+}
+
+// Write persists the trackable. The diff is returned for trackables which
+// report it, and is nil otherwise.
+func Write(ctx context.Context, t Trackable) (*Diff, error) {
+	if d, ok := t.(Differ); ok {
+		return d.WriteDiff(ctx)
+	}
+	return nil, t.Write()
+}
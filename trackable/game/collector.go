@@ -0,0 +1,214 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"tracker/internal/timeutil"
+	"tracker/scrape"
+	"tracker/trackable"
+)
+
+type attr = map[string]string
+
+// Scrape the platforms and the releases of the game from Wikipedia.
+func (g *Game) Scrape(ctx context.Context) error {
+	url := fmt.Sprintf("https://en.wikipedia.org/wiki/%s", g.WikipediaURL)
+	return trackable.ScrapePage(ctx, url, g.parse)
+}
+
+// parse the article of the game. The releases of the game are taken from the
+// infobox, and the releases of DLCs from tables listing them.
+func (g *Game) parse(body []byte, report *trackable.Report) error {
+	scraper, err := scrape.Create(body)
+	if err != nil {
+		return fmt.Errorf("Unable to create scraper; %v\n", err)
+	}
+
+	dates := make([]*ReleaseDate, 0)
+	infobox := scraper.FindFirst("table", attr{"class": "infobox"})
+	if infobox.Valid {
+		if title := infobox.FindFirst("th", attr{"class": "summary"}); title.Valid {
+			g.Name = cleanText(title.Text())
+		}
+
+		for _, row := range infobox.FindAll("tr", nil) {
+			label := row.FindFirst("th", nil)
+			data := row.FindFirst("td", nil)
+			if !label.Valid || !data.Valid {
+				continue
+			}
+
+			switch l := strings.ToLower(cleanText(label.Text())); {
+			case strings.HasPrefix(l, "platform"):
+				g.Platforms = splitPlatforms(strings.Join(data.Lines(), ","))
+			case strings.HasPrefix(l, "release"):
+				dates = append(dates, parseReleases(data.Lines(), report)...)
+			}
+		}
+	}
+
+	for _, table := range scraper.FindAll("table", attr{"class": "wikitable"}) {
+		dates = append(dates, parseDLCTable(table)...)
+	}
+
+	sortDates(dates)
+	g.Dates = uniqueDates(dates)
+	return nil
+}
+
+var (
+	months        = `(?:January|February|March|April|May|June|July|August|September|October|November|December)`
+	dateRegexp    = regexp.MustCompile(months + ` [0-9]{1,2}, [0-9]{4}|[0-9]{1,2} ` + months + ` [0-9]{4}`)
+	unknownRegexp = regexp.MustCompile(`(?i)\bTBA\b|\bTBD\b|^(?:[A-Z]{2,3}: )?(?:Q[1-4] )?[0-9]{4}$`)
+	regionRegexp  = regexp.MustCompile(`\b(?:WW|NA|EU|PAL|JP|AU|KOR|AS|UK)\b:?`)
+	earlyRegexp   = regexp.MustCompile(`(?i)\(?early access\)?`)
+)
+
+// parseReleases parses the release row of an infobox. The row is a list of
+// platforms, each followed by the dates the game was released on them in
+// every region. Only the earliest date of each platform is kept. Dates which
+// can't be parsed are skipped, and recorded in the report.
+func parseReleases(lines []string, report *trackable.Report) []*ReleaseDate {
+	dates := make([]*ReleaseDate, 0)
+
+	var platforms []string
+	early := false
+	for _, line := range lines {
+		line = cleanText(line)
+		if line == "" {
+			continue
+		}
+
+		loc := dateRegexp.FindStringIndex(line)
+		if loc == nil && !unknownRegexp.MatchString(line) {
+			// A line without a date lists the platforms of the dates below.
+			early = earlyRegexp.MatchString(line)
+			platforms = splitPlatforms(earlyRegexp.ReplaceAllString(line, ""))
+			continue
+		}
+
+		kind := Full
+		if early || earlyRegexp.MatchString(line) {
+			kind = EarlyAccess
+		}
+
+		d := &ReleaseDate{Kind: kind}
+		if loc != nil {
+			date, err := timeutil.Parse(line[loc[0]:loc[1]])
+			if err != nil {
+				report.Skip(line, "release date isn't a date")
+				continue
+			}
+			d.Date = date
+
+			// Platforms can also be given on the same line as the date.
+			prefix := earlyRegexp.ReplaceAllString(regionRegexp.ReplaceAllString(line[:loc[0]], ""), "")
+			if p := splitPlatforms(prefix); len(p) > 0 {
+				dates = append(dates, forPlatforms(d, p)...)
+				continue
+			}
+		}
+		dates = append(dates, forPlatforms(d, platforms)...)
+	}
+
+	return dates
+}
+
+// forPlatforms copies the release for every platform. Without platforms the
+// release applies to all platforms.
+func forPlatforms(d *ReleaseDate, platforms []string) []*ReleaseDate {
+	if len(platforms) == 0 {
+		return []*ReleaseDate{d}
+	}
+
+	dates := make([]*ReleaseDate, len(platforms))
+	for i, p := range platforms {
+		dates[i] = &ReleaseDate{Kind: d.Kind, Platform: p, Title: d.Title, Date: d.Date}
+	}
+	return dates
+}
+
+var platformSeparator = regexp.MustCompile(`[,/]| and `)
+
+// splitPlatforms splits a list of platforms separated by commas.
+func splitPlatforms(text string) []string {
+	platforms := make([]string, 0)
+	for _, p := range platformSeparator.Split(text, -1) {
+		p = strings.Trim(cleanText(p), ":; ")
+		if p != "" {
+			platforms = append(platforms, p)
+		}
+	}
+	return platforms
+}
+
+// parseDLCTable parses a table listing downloadable content, which must have
+// a column with the name and a column with the release date of each DLC.
+func parseDLCTable(table *scrape.Tag) []*ReleaseDate {
+	rows := table.FindAll("tr", nil)
+	if len(rows) == 0 {
+		return nil
+	}
+
+	title, date := -1, -1
+	for i, header := range rows[0].FindAll("th", nil) {
+		text := strings.ToLower(cleanText(header.Text()))
+		switch {
+		case title < 0 && (strings.HasPrefix(text, "title") || strings.HasPrefix(text, "name")):
+			title = i
+		case date < 0 && strings.Contains(text, "release"):
+			date = i
+		}
+	}
+	if title < 0 || date < 0 {
+		return nil
+	}
+
+	dates := make([]*ReleaseDate, 0)
+	for _, row := range rows[1:] {
+		cells := row.FindAllOf([]string{"th", "td"}, nil)
+		if len(cells) <= title || len(cells) <= date {
+			continue
+		}
+
+		d := &ReleaseDate{Kind: DLC, Title: strings.Trim(cleanText(cells[title].Text()), `"“”`)}
+		if d.Title == "" {
+			continue
+		}
+		if m := dateRegexp.FindString(cleanText(cells[date].Text())); m != "" {
+			d.Date, _ = timeutil.Parse(m)
+		}
+		dates = append(dates, d)
+	}
+	return dates
+}
+
+// uniqueDates keeps the first release of every kind, platform and title.
+func uniqueDates(dates []*ReleaseDate) []*ReleaseDate {
+	type key struct {
+		kind            ReleaseKind
+		platform, title string
+	}
+
+	seen := map[key]bool{}
+	unique := make([]*ReleaseDate, 0, len(dates))
+	for _, d := range dates {
+		k := key{d.Kind, d.Platform, d.Title}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		unique = append(unique, d)
+	}
+	return unique
+}
+
+var referenceRegexp = regexp.MustCompile(`\[[^\]]*\]`)
+
+// cleanText removes references and redundant whitespace.
+func cleanText(str string) string {
+	return strings.Join(strings.Fields(referenceRegexp.ReplaceAllString(str, "")), " ")
+}
@@ -0,0 +1,105 @@
+package game
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	body, err := ioutil.ReadFile("testdata/game.html")
+	if err != nil {
+		t.Fatalf("unable to read file: %v", err)
+	}
+
+	g := &Game{}
+	if err := g.parse(body, nil); err != nil {
+		t.Fatalf("parse() err = %v, want %v", err, nil)
+	}
+
+	want := &Game{
+		Name:      "Example Quest",
+		Platforms: []string{"Windows", "PlayStation 5", "Nintendo Switch"},
+		Dates: []*ReleaseDate{
+			{Kind: EarlyAccess, Platform: "Windows", Date: date(2020, time.March, 3)},
+			{Kind: Full, Platform: "Windows", Date: date(2021, time.November, 10)},
+			{Kind: Full, Platform: "PS5", Date: date(2021, time.November, 10)},
+			{Kind: DLC, Title: "The Frozen North", Date: date(2022, time.June, 1)},
+			{Kind: Full, Platform: "Nintendo Switch"},
+			{Kind: DLC, Title: "Second Expansion"},
+		},
+	}
+	if diff := deep.Equal(g, want); diff != nil {
+		t.Errorf("parse() diff = %v", diff)
+	}
+}
+
+func TestListFilters(t *testing.T) {
+	now := date(2021, time.March, 6)
+	games := map[string]*Game{
+		"released": {Platforms: []string{"PlayStation 5"}, Dates: []*ReleaseDate{
+			{Kind: Full, Date: now.AddDate(0, -1, 0)},
+		}},
+		"early access": {Platforms: []string{"Windows"}, Dates: []*ReleaseDate{
+			{Kind: EarlyAccess, Date: now.AddDate(0, -1, 0)},
+			{Kind: Full, Date: now.AddDate(1, 0, 0)},
+		}},
+		"announced": {Platforms: []string{"Windows", "PlayStation 5"}, Dates: []*ReleaseDate{
+			{Kind: Full},
+		}},
+	}
+
+	testCases := map[string]struct {
+		filter func(*Game) bool
+		want   []string
+	}{
+		"released": {func(g *Game) bool { return listFilterReleased(g, now) },
+			[]string{"released"}},
+		"upcoming": {func(g *Game) bool { return listFilterUpcoming(g, now) },
+			[]string{"early access", "announced"}},
+		"early-access": {func(g *Game) bool { return listFilterEarlyAccess(g, now) },
+			[]string{"early access"}},
+		"platform": {func(g *Game) bool { return g.OnPlatform("playstation-5") },
+			[]string{"released", "announced"}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := map[string]bool{}
+			for name, g := range games {
+				if tc.filter(g) {
+					got[name] = true
+				}
+			}
+
+			want := map[string]bool{}
+			for _, name := range tc.want {
+				want[name] = true
+			}
+			if diff := deep.Equal(got, want); diff != nil {
+				t.Errorf("filter diff = %v", diff)
+			}
+		})
+	}
+}
+
+func TestRemovedReleases(t *testing.T) {
+	pc := &ReleaseDate{Kind: Full, Platform: "Windows", Date: date(2021, time.May, 7)}
+	switchRelease := &ReleaseDate{Kind: Full, Platform: "Nintendo Switch"}
+	dlc := &ReleaseDate{Kind: DLC, Title: "Frozen Wastes", Date: date(2021, time.October, 1)}
+	dates := []*ReleaseDate{
+		{Kind: Full, Platform: "Windows", Date: date(2021, time.May, 14)},
+		{Kind: DLC, Title: "Sunken Isles"},
+	}
+
+	got := removedReleases([]*ReleaseDate{pc, switchRelease, dlc}, dates)
+	if diff := deep.Equal(got, []*ReleaseDate{switchRelease, dlc}); diff != nil {
+		t.Errorf("removedReleases() diff = %v", diff)
+	}
+}
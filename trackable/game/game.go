@@ -0,0 +1,304 @@
+package game
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"tracker/database"
+	"tracker/trackable"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// Game struct must implement Trackable
+type Game struct {
+	ID           int            `json:"id"`
+	Name         string         `json:"name"`
+	WikipediaURL string         `json:"wikipedia"`
+	Platforms    []string       `json:"platforms"`
+	Dates        []*ReleaseDate `json:"dates"`
+}
+
+// ReleaseKind is the type of release of a game.
+type ReleaseKind string
+
+const (
+	Full        ReleaseKind = "full"
+	EarlyAccess ReleaseKind = "early-access"
+	DLC         ReleaseKind = "dlc"
+)
+
+// ReleaseDate is the release of the game, or one of its DLCs, on a platform.
+// An empty platform means the release is on all platforms, and a zero date
+// means the release has been announced without a date.
+type ReleaseDate struct {
+	Kind     ReleaseKind `json:"kind"`
+	Platform string      `json:"platform"`
+	Title    string      `json:"title"`
+	Date     time.Time   `json:"date"`
+}
+
+// Released returns true if the release happened before the given time.
+func (d *ReleaseDate) Released(now time.Time) bool {
+	return !d.Date.IsZero() && !d.Date.After(now)
+}
+
+func (d *ReleaseDate) String() string {
+	name := "Release"
+	switch d.Kind {
+	case EarlyAccess:
+		name = "Early access"
+	case DLC:
+		name = d.Title
+	}
+
+	if d.Platform == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, d.Platform)
+}
+
+// Write persists the release dates and platforms of the game in a single
+// transaction. Releases and DLCs which are no longer listed are removed.
+func (g *Game) Write() error {
+	db, err := database.Open("tracker")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stored, err := storedReleases(tx, g.ID)
+	if err != nil {
+		return fmt.Errorf("unable to write game %d: %w", g.ID, err)
+	}
+	for _, d := range g.Dates {
+		_, err = tx.Exec(`REPLACE INTO game_releases(game_id, kind, platform, title, release_date)
+		                  VALUES(?, ?, ?, ?, ?)`, g.ID, d.Kind, d.Platform, d.Title,
+			sql.NullTime{Time: d.Date, Valid: !d.Date.IsZero()})
+		if err != nil {
+			return fmt.Errorf("unable to write %s release of game %d on %q: %w", d.Kind, g.ID, d.Platform, err)
+		}
+	}
+
+	// A game without any release is more likely an article which couldn't be
+	// parsed than one whose releases were withdrawn, so nothing is removed then.
+	if len(g.Dates) > 0 {
+		for _, d := range removedReleases(stored, g.Dates) {
+			if _, err := tx.Exec("DELETE FROM game_releases WHERE game_id=? AND kind=? AND platform=? AND title=?",
+				g.ID, d.Kind, d.Platform, d.Title); err != nil {
+				return fmt.Errorf("unable to remove %s release of game %d on %q: %w", d.Kind, g.ID, d.Platform, err)
+			}
+		}
+	}
+
+	if len(g.Platforms) > 0 {
+		_, err = tx.Exec("UPDATE games SET platforms=? WHERE id=?",
+			strings.Join(g.Platforms, ","), g.ID)
+		if err != nil {
+			return fmt.Errorf("unable to write platforms of game %d: %w", g.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("unable to commit game %d: %w", g.ID, err)
+	}
+	return nil
+}
+
+// storedReleases returns the stored releases of the game, which are locked
+// until the transaction ends.
+func storedReleases(tx *sql.Tx, gameID int) ([]*ReleaseDate, error) {
+	rows, err := tx.Query(`SELECT kind, platform, title, release_date FROM game_releases
+	                       WHERE game_id=? FOR UPDATE`, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query releases: %w", err)
+	}
+	defer rows.Close()
+
+	var dates []*ReleaseDate
+	for rows.Next() {
+		d := &ReleaseDate{}
+		var released sql.NullTime
+		if err := rows.Scan(&d.Kind, &d.Platform, &d.Title, &released); err != nil {
+			return nil, fmt.Errorf("unable to scan release: %w", err)
+		}
+		d.Date = released.Time
+		dates = append(dates, d)
+	}
+	return dates, rows.Err()
+}
+
+// removedReleases returns the stored releases whose kind, platform and title
+// are no longer listed.
+func removedReleases(stored, dates []*ReleaseDate) []*ReleaseDate {
+	type key struct {
+		kind            ReleaseKind
+		platform, title string
+	}
+	listed := map[key]bool{}
+	for _, d := range dates {
+		listed[key{d.Kind, d.Platform, d.Title}] = true
+	}
+	var removed []*ReleaseDate
+	for _, d := range stored {
+		if !listed[key{d.Kind, d.Platform, d.Title}] {
+			removed = append(removed, d)
+		}
+	}
+	return removed
+}
+
+func (g *Game) Ref() trackable.Ref {
+	return trackable.Ref{Kind: Kind, ID: g.ID}
+}
+
+// Releases returns the releases of the game and its DLCs in the range.
+func (g *Game) Releases(start, end time.Time) []*trackable.Release {
+	releases := make([]*trackable.Release, 0)
+	for _, d := range g.Dates {
+		if d.Date.Before(start) || !d.Date.Before(end) {
+			continue
+		}
+		releases = append(releases, &trackable.Release{
+			Ref:   g.Ref(),
+			Name:  g.Name,
+			Title: d.String(),
+			Date:  d.Date,
+		})
+	}
+	return releases
+}
+
+// Released returns true if the full game is out on any platform.
+func (g *Game) Released(now time.Time) bool {
+	for _, d := range g.Dates {
+		if d.Kind == Full && d.Released(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// InEarlyAccess returns true if the game can be played in early access, but
+// hasn't been fully released yet.
+func (g *Game) InEarlyAccess(now time.Time) bool {
+	if g.Released(now) {
+		return false
+	}
+	for _, d := range g.Dates {
+		if d.Kind == EarlyAccess && d.Released(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// OnPlatform returns true if the game is released on the platform, given as
+// a slug such as "playstation-5".
+func (g *Game) OnPlatform(platform string) bool {
+	for _, p := range g.Platforms {
+		if Slug(p) == platform {
+			return true
+		}
+	}
+	return false
+}
+
+var slugRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slug converts the name of a platform into the form used in URLs.
+func Slug(platform string) string {
+	return strings.Trim(slugRegexp.ReplaceAllString(strings.ToLower(platform), "-"), "-")
+}
+
+// sortDates orders the releases chronologically, with unknown dates last.
+func sortDates(dates []*ReleaseDate) {
+	sort.SliceStable(dates, func(i, j int) bool {
+		a, b := dates[i].Date, dates[j].Date
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero() && b.IsZero()
+		}
+		return a.Before(b)
+	})
+}
+
+func (g *Game) String() string {
+	dateString := ""
+	for _, d := range g.Dates {
+		dateString += fmt.Sprintf("\t%s - %s\n", d.Date.Format("2006-01-02"), d)
+	}
+
+	return fmt.Sprintf("%-2d - %-30s - %3d Releases, WikipediaURL='%s'\n%s", g.ID, g.Name,
+		len(g.Dates), g.WikipediaURL, dateString)
+}
+
+func loadAllGames() ([]*Game, error) {
+	games := make([]*Game, 0)
+
+	db, err := database.Open("tracker")
+	if err != nil {
+		return games, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT id,title,wikipedia,platforms FROM games")
+	if err != nil {
+		return games, err
+	}
+	defer rows.Close()
+
+	byID := map[int]*Game{}
+	for rows.Next() {
+		g := &Game{Platforms: make([]string, 0), Dates: make([]*ReleaseDate, 0)}
+		var wikipedia, platforms sql.NullString
+		if err := rows.Scan(&g.ID, &g.Name, &wikipedia, &platforms); err != nil {
+			return games, fmt.Errorf("Unable to scan game: %v", err)
+		}
+		g.WikipediaURL = wikipedia.String
+		if platforms.String != "" {
+			g.Platforms = strings.Split(platforms.String, ",")
+		}
+		games = append(games, g)
+		byID[g.ID] = g
+	}
+	if err := rows.Err(); err != nil {
+		return games, err
+	}
+
+	rows, err = db.Query("SELECT game_id,kind,platform,title,release_date FROM game_releases")
+	if err != nil {
+		return games, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var released sql.NullTime
+		d := &ReleaseDate{}
+		if err := rows.Scan(&id, &d.Kind, &d.Platform, &d.Title, &released); err != nil {
+			return games, fmt.Errorf("Unable to scan release: %v", err)
+		}
+		d.Date = released.Time
+		if g, ok := byID[id]; ok {
+			g.Dates = append(g.Dates, d)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return games, err
+	}
+
+	for _, g := range games {
+		sortDates(g.Dates)
+	}
+	return games, nil
+}
@@ -0,0 +1,165 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+
+	"go.uber.org/zap"
+)
+
+// Handler will take care of database loading and API prepping for Games.
+type Handler struct {
+	games []*Game
+}
+
+func (h *Handler) Init() {
+	games, err := loadAllGames()
+	if err != nil {
+		h.games = make([]*Game, 0)
+		zap.L().Error("unable to load games", zap.Error(err))
+	} else {
+		h.games = games
+	}
+}
+
+type GameSimple struct {
+	ID        int
+	Name      string
+	Platforms []string
+}
+
+type GameList struct {
+	Count int
+	Games []*GameSimple
+}
+
+type GameFull struct {
+	*Game
+
+	Released      bool `json:"released"`
+	InEarlyAccess bool `json:"in_early_access"`
+}
+
+// Platform is a platform games are released on, along with its slug.
+type Platform struct {
+	Name string
+	Slug string
+}
+
+var listFilters = map[string]func(*Game, time.Time) bool{
+	"all":          listFilterAll,
+	"released":     listFilterReleased,
+	"upcoming":     listFilterUpcoming,
+	"early-access": listFilterEarlyAccess,
+}
+
+func (h *Handler) Get(id int) (*GameFull, error) {
+	for _, g := range h.games {
+		if g.ID == id {
+			now := time.Now()
+			return &GameFull{
+				Game:          g,
+				Released:      g.Released(now),
+				InEarlyAccess: g.InEarlyAccess(now),
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("Invalid game ID")
+}
+
+func (h *Handler) GetList(listType string) (*GameList, error) {
+	filter, ok := listFilters[listType]
+	if !ok {
+		return nil, fmt.Errorf("Unknown list type: %s", listType)
+	}
+
+	now := time.Now()
+	return h.list(func(g *Game) bool {
+		return filter(g, now)
+	}), nil
+}
+
+// GetPlatformList lists the games released on the platform, given as a slug.
+func (h *Handler) GetPlatformList(platform string) *GameList {
+	return h.list(func(g *Game) bool {
+		return g.OnPlatform(platform)
+	})
+}
+
+// GetPlatforms lists every platform of any game.
+func (h *Handler) GetPlatforms() []*Platform {
+	seen := map[string]bool{}
+	platforms := make([]*Platform, 0)
+	for _, g := range h.games {
+		for _, name := range g.Platforms {
+			slug := Slug(name)
+			if seen[slug] {
+				continue
+			}
+			seen[slug] = true
+			platforms = append(platforms, &Platform{Name: name, Slug: slug})
+		}
+	}
+
+	sort.Slice(platforms, func(i, j int) bool {
+		return platforms[i].Slug < platforms[j].Slug
+	})
+	return platforms
+}
+
+// GetSchedule returns every release of every game between start and end.
+func (h *Handler) GetSchedule(start, end string) (*trackable.Schedule, error) {
+	startDate, endDate, err := trackable.ParseRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]*trackable.Release, 0)
+	for _, g := range h.games {
+		releases = append(releases, g.Releases(startDate, endDate)...)
+	}
+
+	return &trackable.Schedule{
+		StartDate: timeutil.JSONTime(startDate),
+		EndDate:   timeutil.JSONTime(endDate),
+		Releases:  releases,
+	}, nil
+}
+
+func (h *Handler) list(filter func(*Game) bool) *GameList {
+	games := make([]*GameSimple, 0)
+	for _, g := range h.games {
+		if filter(g) {
+			games = append(games, &GameSimple{ID: g.ID, Name: g.Name, Platforms: g.Platforms})
+		}
+	}
+	return &GameList{
+		Count: len(games),
+		Games: games,
+	}
+}
+
+// listFilterAll will always return true.
+func listFilterAll(*Game, time.Time) bool {
+	return true
+}
+
+// listFilterReleased will return true for games which are out on any platform.
+func listFilterReleased(g *Game, now time.Time) bool {
+	return g.Released(now)
+}
+
+// listFilterUpcoming will return true for games which haven't been released,
+// including those playable in early access.
+func listFilterUpcoming(g *Game, now time.Time) bool {
+	return !g.Released(now)
+}
+
+// listFilterEarlyAccess will return true for games playable in early access.
+func listFilterEarlyAccess(g *Game, now time.Time) bool {
+	return g.InEarlyAccess(now)
+}
@@ -0,0 +1,35 @@
+package game
+
+import (
+	"context"
+
+	"tracker/trackable"
+)
+
+// Kind of the game trackable.
+const Kind trackable.Kind = "game"
+
+var _ trackable.Trackable = &Game{}
+
+func init() {
+	trackable.Register(&trackable.Module{
+		Kind: Kind,
+		Name: "Games",
+		API:  &API{},
+		Load: load,
+	})
+}
+
+// load all games as trackables.
+func load(context.Context) ([]trackable.Trackable, error) {
+	items, err := loadAllGames()
+	if err != nil {
+		return nil, err
+	}
+
+	trackables := make([]trackable.Trackable, len(items))
+	for i, item := range items {
+		trackables[i] = item
+	}
+	return trackables, nil
+}
@@ -0,0 +1,216 @@
+package movie
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"tracker/internal/timeutil"
+	"tracker/scrape"
+	"tracker/trackable"
+)
+
+type attr = map[string]string
+
+// Scrape the release dates of the movie from Wikipedia.
+func (m *Movie) Scrape(ctx context.Context) error {
+	url := fmt.Sprintf("https://en.wikipedia.org/wiki/%s", m.WikipediaURL)
+	return trackable.ScrapePage(ctx, url, m.parse)
+}
+
+// parse the article of the movie. Theatrical releases are taken from the
+// infobox, while digital and physical releases are only mentioned in the text
+// of the article.
+func (m *Movie) parse(body []byte, report *trackable.Report) error {
+	scraper, err := scrape.Create(body)
+	if err != nil {
+		return fmt.Errorf("Unable to create scraper; %v\n", err)
+	}
+
+	dates := make([]*ReleaseDate, 0)
+	infobox := scraper.FindFirst("table", attr{"class": "infobox"})
+	if infobox.Valid {
+		if title := infobox.FindFirst("th", attr{"class": "summary"}); title.Valid {
+			m.Name = parseString(title.Text())
+		}
+		dates = append(dates, parseInfobox(infobox, report)...)
+	}
+
+	for _, p := range scraper.FindAll("p", nil) {
+		dates = append(dates, parseParagraph(parseString(p.Text()))...)
+	}
+
+	m.Dates = uniqueDates(dates)
+	sortDates(m.Dates)
+	return nil
+}
+
+// parseInfobox parses the theatrical releases listed in the "Release date"
+// row of the infobox. Festival screenings are skipped as they aren't open to
+// the public, and releases which can't be parsed are recorded in the report.
+func parseInfobox(infobox *scrape.Tag, report *trackable.Report) []*ReleaseDate {
+	dates := make([]*ReleaseDate, 0)
+	for _, row := range infobox.FindAll("tr", nil) {
+		label := row.FindFirst("th", nil)
+		if !label.Valid || !strings.HasPrefix(strings.ToLower(parseString(label.Text())), "release date") {
+			continue
+		}
+
+		data := row.FindFirst("td", nil)
+		if !data.Valid {
+			continue
+		}
+
+		entries := data.FindAll("li", nil)
+		if len(entries) == 0 {
+			entries = []*scrape.Tag{data}
+		}
+		for _, entry := range entries {
+			if d, ok := parseInfoboxEntry(entry, report); ok {
+				dates = append(dates, d)
+			}
+		}
+	}
+	return dates
+}
+
+var (
+	referenceRegexp   = regexp.MustCompile(`\[[^\]]*\]`)
+	parentheticRegexp = regexp.MustCompile(`\(([^()]+)\)`)
+	isoDateRegexp     = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+)
+
+// parseInfoboxEntry parses a single release such as
+// "May 12, 2020 (2020-05-12) (United States)".
+func parseInfoboxEntry(entry *scrape.Tag, report *trackable.Report) (*ReleaseDate, bool) {
+	text := referenceRegexp.ReplaceAllString(parseString(entry.Text()), "")
+
+	d := &ReleaseDate{Kind: Theatrical}
+	for _, m := range parentheticRegexp.FindAllStringSubmatch(text, -1) {
+		inner := strings.TrimSpace(m[1])
+		if isoDateRegexp.MatchString(inner) {
+			d.Date, _ = time.Parse(timeutil.Format, inner)
+			continue
+		}
+		d.Region = inner
+	}
+
+	lower := strings.ToLower(d.Region)
+	if strings.Contains(lower, "festival") || strings.Contains(lower, "premiere") {
+		return nil, false
+	}
+
+	if d.Date.IsZero() {
+		date, err := timeutil.Parse(parentheticRegexp.ReplaceAllString(text, ""))
+		if err != nil {
+			report.Skip(text, "release date isn't a date")
+			return nil, false
+		}
+		d.Date = date
+	}
+
+	return d, true
+}
+
+// releaseKeywords are the words used in articles to describe a release.
+var releaseKeywords = []struct {
+	keyword string
+	kind    ReleaseKind
+}{
+	{"digital", Digital},
+	{"video on demand", Digital},
+	{"streaming", Digital},
+	{"blu-ray", Physical},
+	{"dvd", Physical},
+	{"ultra hd", Physical},
+}
+
+// regions are the regions recognised in the text of the article.
+var regions = []string{
+	"United States", "United Kingdom", "Canada", "Australia", "Ireland",
+	"India", "Japan", "Germany", "France", "Spain", "Italy",
+}
+
+var (
+	months          = `(?:January|February|March|April|May|June|July|August|September|October|November|December)`
+	proseDateRegexp = regexp.MustCompile(months + ` [0-9]{1,2}, [0-9]{4}|[0-9]{1,2} ` + months + ` [0-9]{4}`)
+)
+
+// parseParagraph finds digital and physical releases in the text of the
+// article. Every date is attributed to the closest release keyword before it
+// in the same sentence.
+func parseParagraph(text string) []*ReleaseDate {
+	text = referenceRegexp.ReplaceAllString(text, "")
+
+	dates := make([]*ReleaseDate, 0)
+	for _, sentence := range strings.SplitAfter(text, ". ") {
+		lower := strings.ToLower(sentence)
+		region := sentenceRegion(sentence)
+
+		for _, loc := range proseDateRegexp.FindAllStringIndex(sentence, -1) {
+			kind, ok := closestKeyword(lower[:loc[0]])
+			if !ok {
+				continue
+			}
+
+			date, err := timeutil.Parse(sentence[loc[0]:loc[1]])
+			if err != nil {
+				continue
+			}
+			dates = append(dates, &ReleaseDate{Kind: kind, Region: region, Date: date})
+		}
+	}
+	return dates
+}
+
+// closestKeyword returns the kind of the keyword closest to the end of text.
+func closestKeyword(text string) (ReleaseKind, bool) {
+	best, kind := -1, ReleaseKind("")
+	for _, k := range releaseKeywords {
+		if i := strings.LastIndex(text, k.keyword); i > best {
+			best, kind = i, k.kind
+		}
+	}
+	return kind, best >= 0
+}
+
+// sentenceRegion returns the region mentioned in the sentence, if exactly one
+// region is mentioned.
+func sentenceRegion(sentence string) string {
+	found := ""
+	for _, r := range regions {
+		if strings.Contains(sentence, r) {
+			if found != "" {
+				return ""
+			}
+			found = r
+		}
+	}
+	return found
+}
+
+// uniqueDates keeps the first release of every kind in each region.
+func uniqueDates(dates []*ReleaseDate) []*ReleaseDate {
+	type key struct {
+		kind   ReleaseKind
+		region string
+	}
+
+	seen := map[key]bool{}
+	unique := make([]*ReleaseDate, 0, len(dates))
+	for _, d := range dates {
+		k := key{d.Kind, d.Region}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		unique = append(unique, d)
+	}
+	return unique
+}
+
+func parseString(str string) string {
+	return strings.Join(strings.Fields(str), " ")
+}
@@ -0,0 +1,99 @@
+package movie
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	body, err := ioutil.ReadFile("testdata/film.html")
+	if err != nil {
+		t.Fatalf("unable to read file: %v", err)
+	}
+
+	m := &Movie{}
+	if err := m.parse(body, nil); err != nil {
+		t.Fatalf("parse() err = %v, want %v", err, nil)
+	}
+
+	want := &Movie{
+		Name: "Example Film",
+		Dates: []*ReleaseDate{
+			{Kind: Theatrical, Region: "United States", Date: date(2020, time.June, 5)},
+			{Kind: Theatrical, Region: "United Kingdom", Date: date(2020, time.June, 19)},
+			{Kind: Digital, Region: "", Date: date(2020, time.July, 21)},
+			{Kind: Digital, Region: "United Kingdom", Date: date(2020, time.August, 3)},
+			{Kind: Physical, Region: "", Date: date(2020, time.August, 4)},
+		},
+	}
+	if diff := deep.Equal(m, want); diff != nil {
+		t.Errorf("parse() diff = %v", diff)
+	}
+}
+
+func TestListFilters(t *testing.T) {
+	now := date(2021, time.March, 6)
+	movies := map[string]*Movie{
+		"in cinemas": {Dates: []*ReleaseDate{
+			{Kind: Theatrical, Date: now.AddDate(0, 0, -7)},
+			{Kind: Digital, Date: now.AddDate(0, 1, 0)},
+		}},
+		"coming soon": {Dates: []*ReleaseDate{
+			{Kind: Theatrical, Date: now.AddDate(0, 1, 0)},
+		}},
+		"digital": {Dates: []*ReleaseDate{
+			{Kind: Theatrical, Date: now.AddDate(0, -2, 0)},
+			{Kind: Digital, Date: now.AddDate(0, -1, 0)},
+		}},
+		"old": {Dates: []*ReleaseDate{
+			{Kind: Theatrical, Date: now.AddDate(-1, 0, 0)},
+		}},
+	}
+
+	testCases := map[string][]string{
+		"in-cinemas":  {"in cinemas"},
+		"coming-soon": {"coming soon"},
+		"digital":     {"digital"},
+	}
+
+	for filter, want := range testCases {
+		t.Run(filter, func(t *testing.T) {
+			got := make(map[string]bool)
+			for name, movie := range movies {
+				if listFilters[filter](movie, now) {
+					got[name] = true
+				}
+			}
+
+			wantSet := make(map[string]bool)
+			for _, name := range want {
+				wantSet[name] = true
+			}
+			if diff := deep.Equal(got, wantSet); diff != nil {
+				t.Errorf("%s filter diff = %v", filter, diff)
+			}
+		})
+	}
+}
+
+func TestRemovedReleases(t *testing.T) {
+	worldwide := &ReleaseDate{Kind: Theatrical, Date: date(2021, time.May, 7)}
+	uk := &ReleaseDate{Kind: Theatrical, Region: "United Kingdom", Date: date(2021, time.May, 14)}
+	digital := &ReleaseDate{Kind: Digital, Date: date(2021, time.June, 1)}
+	dates := []*ReleaseDate{
+		{Kind: Theatrical, Date: date(2021, time.May, 21)},
+		{Kind: Physical, Date: date(2021, time.August, 3)},
+	}
+
+	got := removedReleases([]*ReleaseDate{worldwide, uk, digital}, dates)
+	if diff := deep.Equal(got, []*ReleaseDate{uk, digital}); diff != nil {
+		t.Errorf("removedReleases() diff = %v", diff)
+	}
+}
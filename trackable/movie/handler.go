@@ -0,0 +1,141 @@
+package movie
+
+import (
+	"fmt"
+	"time"
+
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+
+	"go.uber.org/zap"
+)
+
+// cinemaRun is how long a movie is assumed to be in cinemas after its first
+// theatrical release, unless it is released digitally before that.
+const cinemaRun = 90 * timeutil.Day
+
+// Handler will take care of database loading and API prepping for Movies.
+type Handler struct {
+	movies []*Movie
+}
+
+func (h *Handler) Init() {
+	movies, err := loadAllMovies()
+	if err != nil {
+		h.movies = make([]*Movie, 0)
+		zap.L().Error("unable to load movies", zap.Error(err))
+	} else {
+		h.movies = movies
+	}
+}
+
+type MovieSimple struct {
+	ID   int
+	Name string
+}
+
+type MovieList struct {
+	Count  int
+	Movies []*MovieSimple
+}
+
+type MovieFull struct {
+	*Movie
+
+	Theatrical *ReleaseDate `json:"theatrical"`
+	Digital    *ReleaseDate `json:"digital"`
+	Physical   *ReleaseDate `json:"physical"`
+}
+
+var listFilters = map[string]func(*Movie, time.Time) bool{
+	"all":         listFilterAll,
+	"in-cinemas":  listFilterInCinemas,
+	"coming-soon": listFilterComingSoon,
+	"digital":     listFilterDigital,
+}
+
+func (h *Handler) Get(id int) (*MovieFull, error) {
+	for _, movie := range h.movies {
+		if movie.ID == id {
+			return &MovieFull{
+				Movie:      movie,
+				Theatrical: movie.FirstRelease(Theatrical),
+				Digital:    movie.FirstRelease(Digital),
+				Physical:   movie.FirstRelease(Physical),
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("Invalid movie ID")
+}
+
+func (h *Handler) GetList(listType string) (*MovieList, error) {
+	filter, ok := listFilters[listType]
+	if !ok {
+		return nil, fmt.Errorf("Unknown list type: %s", listType)
+	}
+
+	now := time.Now()
+	movies := make([]*MovieSimple, 0)
+	for _, movie := range h.movies {
+		if filter(movie, now) {
+			movies = append(movies, &MovieSimple{ID: movie.ID, Name: movie.Name})
+		}
+	}
+	return &MovieList{
+		Count:  len(movies),
+		Movies: movies,
+	}, nil
+}
+
+// GetSchedule returns every release of every movie between start and end.
+func (h *Handler) GetSchedule(start, end string) (*trackable.Schedule, error) {
+	startDate, endDate, err := trackable.ParseRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]*trackable.Release, 0)
+	for _, movie := range h.movies {
+		releases = append(releases, movie.Releases(startDate, endDate)...)
+	}
+
+	return &trackable.Schedule{
+		StartDate: timeutil.JSONTime(startDate),
+		EndDate:   timeutil.JSONTime(endDate),
+		Releases:  releases,
+	}, nil
+}
+
+// listFilterAll will always return true.
+func listFilterAll(*Movie, time.Time) bool {
+	return true
+}
+
+// listFilterInCinemas will return true for movies which were released in
+// cinemas recently, and can't be watched digitally yet.
+func listFilterInCinemas(movie *Movie, now time.Time) bool {
+	first := movie.FirstRelease(Theatrical)
+	if first == nil || first.Date.After(now) || first.Date.Add(cinemaRun).Before(now) {
+		return false
+	}
+	return !movie.released(Digital, now)
+}
+
+// listFilterComingSoon will return true for movies which haven't been
+// released in any way yet.
+func listFilterComingSoon(movie *Movie, now time.Time) bool {
+	if len(movie.Dates) == 0 {
+		return false
+	}
+	for _, d := range movie.Dates {
+		if !d.Date.After(now) {
+			return false
+		}
+	}
+	return true
+}
+
+// listFilterDigital will return true for movies which can be watched digitally.
+func listFilterDigital(movie *Movie, now time.Time) bool {
+	return movie.released(Digital, now)
+}
@@ -0,0 +1,35 @@
+package movie
+
+import (
+	"context"
+
+	"tracker/trackable"
+)
+
+// Kind of the movie trackable.
+const Kind trackable.Kind = "movie"
+
+var _ trackable.Trackable = &Movie{}
+
+func init() {
+	trackable.Register(&trackable.Module{
+		Kind: Kind,
+		Name: "Movies",
+		API:  &API{},
+		Load: load,
+	})
+}
+
+// load all movies as trackables.
+func load(context.Context) ([]trackable.Trackable, error) {
+	items, err := loadAllMovies()
+	if err != nil {
+		return nil, err
+	}
+
+	trackables := make([]trackable.Trackable, len(items))
+	for i, item := range items {
+		trackables[i] = item
+	}
+	return trackables, nil
+}
@@ -0,0 +1,255 @@
+package movie
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"tracker/database"
+	"tracker/trackable"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// Movie struct must implement Trackable
+type Movie struct {
+	ID           int            `json:"id"`
+	Name         string         `json:"name"`
+	WikipediaURL string         `json:"wikipedia"`
+	TrailerURL   string         `json:"trailer"`
+	Dates        []*ReleaseDate `json:"dates"`
+}
+
+// ReleaseKind is the way a movie is released.
+type ReleaseKind string
+
+const (
+	Theatrical ReleaseKind = "theatrical"
+	Digital    ReleaseKind = "digital"
+	Physical   ReleaseKind = "physical"
+)
+
+// ReleaseDate is the date a movie is released in a region. An empty region
+// means the region is unknown, which is usually a worldwide release.
+type ReleaseDate struct {
+	Kind   ReleaseKind `json:"kind"`
+	Region string      `json:"region"`
+	Date   time.Time   `json:"date"`
+}
+
+func (s *ReleaseDate) String() string {
+	if s.Region == "" {
+		return fmt.Sprintf("%s release", s.Kind)
+	}
+	return fmt.Sprintf("%s release (%s)", s.Kind, s.Region)
+}
+
+// Write persists the release dates of the movie in a single transaction.
+// Release dates which are no longer listed are removed.
+func (m *Movie) Write() error {
+	db, err := database.Open("tracker")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stored, err := storedReleases(tx, m.ID)
+	if err != nil {
+		return fmt.Errorf("unable to write movie %d: %w", m.ID, err)
+	}
+	for _, d := range m.Dates {
+		_, err = tx.Exec(`REPLACE INTO movie_releases(movie_id, kind, region, release_date)
+		                  VALUES(?, ?, ?, ?)`, m.ID, d.Kind, d.Region, d.Date)
+		if err != nil {
+			return fmt.Errorf("unable to write %s of movie %d: %w", d, m.ID, err)
+		}
+	}
+
+	// A movie without any date is more likely an infobox which couldn't be
+	// parsed than one whose dates were withdrawn, so nothing is removed then.
+	if len(m.Dates) > 0 {
+		for _, d := range removedReleases(stored, m.Dates) {
+			if _, err := tx.Exec("DELETE FROM movie_releases WHERE movie_id=? AND kind=? AND region=?",
+				m.ID, d.Kind, d.Region); err != nil {
+				return fmt.Errorf("unable to remove %s of movie %d: %w", d, m.ID, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("unable to commit movie %d: %w", m.ID, err)
+	}
+	return nil
+}
+
+// storedReleases returns the stored release dates of the movie, which are
+// locked until the transaction ends.
+func storedReleases(tx *sql.Tx, movieID int) ([]*ReleaseDate, error) {
+	rows, err := tx.Query("SELECT kind, region, release_date FROM movie_releases WHERE movie_id=? FOR UPDATE",
+		movieID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query release dates: %w", err)
+	}
+	defer rows.Close()
+
+	var dates []*ReleaseDate
+	for rows.Next() {
+		d := &ReleaseDate{}
+		if err := rows.Scan(&d.Kind, &d.Region, &d.Date); err != nil {
+			return nil, fmt.Errorf("unable to scan release date: %w", err)
+		}
+		dates = append(dates, d)
+	}
+	return dates, rows.Err()
+}
+
+// removedReleases returns the stored release dates whose kind and region are
+// no longer listed.
+func removedReleases(stored, dates []*ReleaseDate) []*ReleaseDate {
+	type key struct {
+		kind   ReleaseKind
+		region string
+	}
+	listed := map[key]bool{}
+	for _, d := range dates {
+		listed[key{d.Kind, d.Region}] = true
+	}
+	var removed []*ReleaseDate
+	for _, d := range stored {
+		if !listed[key{d.Kind, d.Region}] {
+			removed = append(removed, d)
+		}
+	}
+	return removed
+}
+
+func (m *Movie) Ref() trackable.Ref {
+	return trackable.Ref{Kind: Kind, ID: m.ID}
+}
+
+// Releases returns every release of the movie in the range.
+func (m *Movie) Releases(start, end time.Time) []*trackable.Release {
+	releases := make([]*trackable.Release, 0)
+	for _, d := range m.Dates {
+		if d.Date.Before(start) || !d.Date.Before(end) {
+			continue
+		}
+		releases = append(releases, &trackable.Release{
+			Ref:   m.Ref(),
+			Name:  m.Name,
+			Title: d.String(),
+			Date:  d.Date,
+		})
+	}
+	return releases
+}
+
+// FirstRelease returns the earliest release of the given kind in any region.
+func (m *Movie) FirstRelease(kind ReleaseKind) *ReleaseDate {
+	for _, d := range m.Dates {
+		if d.Kind == kind {
+			return d
+		}
+	}
+	return nil
+}
+
+// ReleaseIn returns the release of the given kind in the region, falling back
+// to the release without a known region.
+func (m *Movie) ReleaseIn(kind ReleaseKind, region string) *ReleaseDate {
+	var fallback *ReleaseDate
+	for _, d := range m.Dates {
+		if d.Kind != kind {
+			continue
+		}
+		if d.Region == region {
+			return d
+		}
+		if d.Region == "" && fallback == nil {
+			fallback = d
+		}
+	}
+	return fallback
+}
+
+// released returns true if the movie was released in the given way before now.
+func (m *Movie) released(kind ReleaseKind, now time.Time) bool {
+	d := m.FirstRelease(kind)
+	return d != nil && !d.Date.After(now)
+}
+
+// sortDates orders the release dates chronologically.
+func sortDates(dates []*ReleaseDate) {
+	sort.SliceStable(dates, func(i, j int) bool {
+		return dates[i].Date.Before(dates[j].Date)
+	})
+}
+
+func (m *Movie) String() string {
+	dateString := ""
+	for _, d := range m.Dates {
+		dateString += fmt.Sprintf("\t%s - %s\n", d.Date.Format("2006-01-02"), d)
+	}
+
+	return fmt.Sprintf("%-2d - %-30s - %3d Releases, WikipediaURL='%s'\n%s", m.ID, m.Name,
+		len(m.Dates), m.WikipediaURL, dateString)
+}
+
+func loadAllMovies() ([]*Movie, error) {
+	movies := make([]*Movie, 0)
+
+	db, err := database.Open("tracker")
+	if err != nil {
+		return movies, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT id,title,wikipedia,trailer FROM movies")
+	if err != nil {
+		return movies, err
+	}
+	defer rows.Close()
+
+	byID := map[int]*Movie{}
+	for rows.Next() {
+		movie := &Movie{Dates: make([]*ReleaseDate, 0)}
+		var wikipedia, trailer sql.NullString
+		if err := rows.Scan(&movie.ID, &movie.Name, &wikipedia, &trailer); err != nil {
+			return movies, fmt.Errorf("Unable to scan movie: %v", err)
+		}
+		movie.WikipediaURL = wikipedia.String
+		movie.TrailerURL = trailer.String
+		movies = append(movies, movie)
+		byID[movie.ID] = movie
+	}
+	if err := rows.Err(); err != nil {
+		return movies, err
+	}
+
+	rows, err = db.Query(`SELECT movie_id,kind,region,release_date FROM movie_releases
+	                      ORDER BY release_date`)
+	if err != nil {
+		return movies, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		d := &ReleaseDate{}
+		if err := rows.Scan(&id, &d.Kind, &d.Region, &d.Date); err != nil {
+			return movies, fmt.Errorf("Unable to scan release date: %v", err)
+		}
+		if movie, ok := byID[id]; ok {
+			movie.Dates = append(movie.Dates, d)
+		}
+	}
+
+	return movies, rows.Err()
+}
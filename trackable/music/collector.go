@@ -0,0 +1,215 @@
+package music
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"tracker/internal/fetch"
+	"tracker/internal/timeutil"
+	"tracker/scrape"
+	"tracker/trackable"
+)
+
+type attr = map[string]string
+
+// Scrape the discography of the artist from Wikipedia, followed by the track
+// list of every album which links to its own article.
+func (a *Artist) Scrape(ctx context.Context) error {
+	return a.scrape(ctx, fmt.Sprintf("https://en.wikipedia.org/wiki/%s", a.WikipediaURL))
+}
+
+func (a *Artist) scrape(ctx context.Context, url string) error {
+	body, err := fetch.Get(ctx, url)
+	if err != nil {
+		return err
+	}
+	report := trackable.ReportFrom(ctx)
+	report.Fetched(url)
+
+	albums, err := parseDiscography(body, report)
+	if err != nil {
+		return err
+	}
+
+	for _, album := range albums {
+		if album.WikipediaURL == "" {
+			continue
+		}
+
+		albumURL := fmt.Sprintf("https://en.wikipedia.org%s", album.WikipediaURL)
+		body, err := fetch.Get(ctx, albumURL)
+		if err != nil {
+			report.Warn("%s: unable to get tracks: %v", album.Title, err)
+			continue
+		}
+		report.Fetched(albumURL)
+		if album.Tracks, err = parseTracklist(body); err != nil {
+			report.Warn("%s: unable to parse tracks: %v", album.Title, err)
+		}
+	}
+
+	a.Albums = albums
+	return nil
+}
+
+// discographyCaptions maps the captions used by discography tables to the
+// kind of albums they list. Tables with other captions, such as singles, are
+// ignored.
+var discographyCaptions = []struct {
+	caption string
+	kind    AlbumKind
+}{
+	{"studio album", Studio},
+	{"live album", Live},
+	{"compilation album", Compilation},
+	{"extended play", EP},
+}
+
+// parseDiscography parses the album tables of a discography article.
+func parseDiscography(body []byte, report *trackable.Report) ([]*Album, error) {
+	scraper, err := scrape.Create(body)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to create scraper; %v\n", err)
+	}
+
+	albums := make([]*Album, 0)
+	for _, table := range scraper.FindAll("table", attr{"class": "wikitable"}) {
+		caption := table.FindFirst("caption", nil)
+		if !caption.Valid {
+			continue
+		}
+
+		kind, ok := albumKind(caption.Text())
+		if !ok {
+			continue
+		}
+		albums = append(albums, parseAlbumTable(table, kind, report)...)
+	}
+
+	sortAlbums(albums)
+	return albums, nil
+}
+
+func albumKind(caption string) (AlbumKind, bool) {
+	caption = strings.ToLower(caption)
+	for _, c := range discographyCaptions {
+		if strings.Contains(caption, c.caption) {
+			return c.kind, true
+		}
+	}
+	return "", false
+}
+
+// parseAlbumTable parses a table where every row has the title of the album
+// as row header, followed by a list of details such as the release date.
+// Release dates which can't be parsed are recorded in the report.
+func parseAlbumTable(table *scrape.Tag, kind AlbumKind, report *trackable.Report) []*Album {
+	albums := make([]*Album, 0)
+	for _, row := range table.FindAll("tr", nil) {
+		header := row.FindFirst("th", attr{"scope": "row"})
+		if !header.Valid {
+			continue
+		}
+
+		album := &Album{
+			Title:  parseString(header.Text()),
+			Kind:   kind,
+			Tracks: make([]*Track, 0),
+		}
+		if link := header.FindFirst("a", nil); link.Valid {
+			if href, ok := link.GetAttr("href"); ok && strings.HasPrefix(href, "/wiki/") {
+				album.WikipediaURL = href
+			}
+		}
+
+		details := row.FindFirst("td", nil)
+		if details.Valid {
+			for _, item := range details.FindAll("li", nil) {
+				date, ok, err := parseReleased(parseString(item.Text()))
+				if err != nil {
+					report.Warn("%s: %v", album.Title, err)
+				}
+				if ok {
+					album.ReleaseDate = date
+					break
+				}
+			}
+		}
+
+		albums = append(albums, album)
+	}
+	return albums
+}
+
+// releasedPrefixes are the labels used for release dates in album details.
+var releasedPrefixes = []string{"Released:", "Scheduled:", "To be released:"}
+
+// parseReleased parses a release detail such as "Released: 12 May 2020". It
+// returns an error if the detail is a release date which can't be parsed.
+func parseReleased(text string) (time.Time, bool, error) {
+	for _, prefix := range releasedPrefixes {
+		if !strings.HasPrefix(text, prefix) {
+			continue
+		}
+
+		date, err := timeutil.Parse(strings.TrimSpace(strings.TrimPrefix(text, prefix)))
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("release date %q isn't a date", text)
+		}
+		return date, true, nil
+	}
+	return time.Time{}, false, nil
+}
+
+var runtimeRegexp = regexp.MustCompile(`^([0-9]+):([0-9]{2})$`)
+
+// parseTracklist parses the first track listing of an album article.
+func parseTracklist(body []byte) ([]*Track, error) {
+	scraper, err := scrape.Create(body)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to create scraper; %v\n", err)
+	}
+
+	tracks := make([]*Track, 0)
+	table := scraper.FindFirst("table", attr{"class": "tracklist"})
+	if !table.Valid {
+		return tracks, nil
+	}
+
+	for _, row := range table.FindAll("tr", nil) {
+		number := row.FindFirst("th", attr{"scope": "row"})
+		columns := row.FindAll("td", nil)
+		if !number.Valid || len(columns) == 0 {
+			continue
+		}
+
+		n, err := strconv.Atoi(strings.TrimSuffix(parseString(number.Text()), "."))
+		if err != nil {
+			continue
+		}
+
+		track := &Track{
+			Number: n,
+			Title:  strings.Trim(parseString(columns[0].Text()), `"“”`),
+		}
+		for _, column := range columns[1:] {
+			if m := runtimeRegexp.FindStringSubmatch(parseString(column.Text())); m != nil {
+				minutes, _ := strconv.Atoi(m[1])
+				seconds, _ := strconv.Atoi(m[2])
+				track.Runtime = time.Duration(minutes)*time.Minute +
+					time.Duration(seconds)*time.Second
+			}
+		}
+		tracks = append(tracks, track)
+	}
+
+	return tracks, nil
+}
+
+func parseString(str string) string {
+	return strings.Join(strings.Fields(str), " ")
+}
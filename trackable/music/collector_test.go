@@ -0,0 +1,90 @@
+package music
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+func TestParseDiscography(t *testing.T) {
+	body, err := ioutil.ReadFile("testdata/discography.html")
+	if err != nil {
+		t.Fatalf("unable to read file: %v", err)
+	}
+
+	got, err := parseDiscography(body, nil)
+	if err != nil {
+		t.Fatalf("parseDiscography() err = %v, want %v", err, nil)
+	}
+
+	want := []*Album{
+		{Title: "First Light", Kind: Studio, WikipediaURL: "/wiki/First_Light_(album)",
+			ReleaseDate: time.Date(2015, time.May, 12, 0, 0, 0, 0, time.UTC), Tracks: []*Track{}},
+		{Title: "Live at the Hall", Kind: Live,
+			ReleaseDate: time.Date(2016, time.December, 1, 0, 0, 0, 0, time.UTC), Tracks: []*Track{}},
+		{Title: "Second Wind", Kind: Studio, WikipediaURL: "/wiki/Second_Wind_(album)",
+			ReleaseDate: time.Date(2018, time.March, 3, 0, 0, 0, 0, time.UTC), Tracks: []*Track{}},
+		{Title: "Third", Kind: Studio,
+			ReleaseDate: time.Date(2031, time.February, 14, 0, 0, 0, 0, time.UTC), Tracks: []*Track{}},
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("parseDiscography() diff = %v", diff)
+	}
+}
+
+func TestParseTracklist(t *testing.T) {
+	body, err := ioutil.ReadFile("testdata/album.html")
+	if err != nil {
+		t.Fatalf("unable to read file: %v", err)
+	}
+
+	got, err := parseTracklist(body)
+	if err != nil {
+		t.Fatalf("parseTracklist() err = %v, want %v", err, nil)
+	}
+
+	want := []*Track{
+		{Number: 1, Title: "Opening", Runtime: 3*time.Minute + 45*time.Second},
+		{Number: 2, Title: "Second Song", Runtime: 4*time.Minute + 2*time.Second},
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("parseTracklist() diff = %v", diff)
+	}
+}
+
+func TestUpcomingAlbums(t *testing.T) {
+	now := time.Date(2021, time.March, 6, 0, 0, 0, 0, time.UTC)
+	released := &Album{Title: "Released", ReleaseDate: now.AddDate(0, -1, 0)}
+	upcoming := &Album{Title: "Upcoming", ReleaseDate: now.AddDate(0, 1, 0)}
+	unknown := &Album{Title: "Unknown"}
+	a := &Artist{Albums: []*Album{released, upcoming, unknown}}
+
+	if got := a.LatestAlbum(now); got != released {
+		t.Errorf("LatestAlbum() = %v, want %v", got, released)
+	}
+	if diff := deep.Equal(a.UpcomingAlbums(now), []*Album{upcoming, unknown}); diff != nil {
+		t.Errorf("UpcomingAlbums() diff = %v", diff)
+	}
+	if diff := deep.Equal(a.AlbumsInRange(now, now.AddDate(1, 0, 0)), []*Album{upcoming}); diff != nil {
+		t.Errorf("AlbumsInRange() diff = %v", diff)
+	}
+}
+
+func TestRemovedAlbums(t *testing.T) {
+	stored := map[string]int{"First Light": 1, "Demo": 2, "Second Wind": 3, "Bootleg": 4}
+	albums := []*Album{{Title: "First Light"}, {Title: "Second Wind"}, {Title: "Third"}}
+
+	if diff := deep.Equal(removedAlbums(stored, albums), []int{2, 4}); diff != nil {
+		t.Errorf("removedAlbums() diff = %v", diff)
+	}
+}
+
+func TestRemovedTracks(t *testing.T) {
+	tracks := []*Track{{Number: 1, Title: "Opening"}, {Number: 3, Title: "Closing"}}
+
+	if diff := deep.Equal(removedTracks([]int{1, 2, 3, 4}, tracks), []int{2, 4}); diff != nil {
+		t.Errorf("removedTracks() diff = %v", diff)
+	}
+}
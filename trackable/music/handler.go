@@ -0,0 +1,143 @@
+package music
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"tracker/internal/timeutil"
+
+	"go.uber.org/zap"
+)
+
+// Handler will take care of database loading and API prepping for Artists.
+type Handler struct {
+	artists []*Artist
+}
+
+func (h *Handler) Init() {
+	artists, err := loadAllArtists()
+	if err != nil {
+		h.artists = make([]*Artist, 0)
+		zap.L().Error("unable to load artists", zap.Error(err))
+	} else {
+		h.artists = artists
+	}
+}
+
+type ArtistSimple struct {
+	ID   int
+	Name string
+}
+
+type ArtistList struct {
+	Count   int
+	Artists []*ArtistSimple
+}
+
+type ArtistFull struct {
+	*Artist
+
+	LatestAlbum    *Album   `json:"latest_album"`
+	UpcomingAlbums []*Album `json:"upcoming_albums"`
+}
+
+// Release is an album along with the artist releasing it.
+type Release struct {
+	ArtistID   int
+	ArtistName string
+
+	*Album
+}
+
+type Upcoming struct {
+	StartDate timeutil.JSONTime `json:"start_date"`
+	EndDate   timeutil.JSONTime `json:"end_date"`
+	Releases  []*Release        `json:"releases"`
+}
+
+var listFilters = map[string]func(*Artist, time.Time) bool{
+	"all":      listFilterAll,
+	"upcoming": listFilterUpcoming,
+}
+
+func (h *Handler) Get(id int) (*ArtistFull, error) {
+	for _, artist := range h.artists {
+		if artist.ID == id {
+			now := time.Now()
+			return &ArtistFull{
+				Artist:         artist,
+				LatestAlbum:    artist.LatestAlbum(now),
+				UpcomingAlbums: artist.UpcomingAlbums(now),
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("Invalid artist ID")
+}
+
+func (h *Handler) GetList(listType string) (*ArtistList, error) {
+	filter, ok := listFilters[listType]
+	if !ok {
+		return nil, fmt.Errorf("Unknown list type: %s", listType)
+	}
+
+	now := time.Now()
+	artists := make([]*ArtistSimple, 0)
+	for _, artist := range h.artists {
+		if filter(artist, now) {
+			artists = append(artists, &ArtistSimple{ID: artist.ID, Name: artist.Name})
+		}
+	}
+	return &ArtistList{
+		Count:   len(artists),
+		Artists: artists,
+	}, nil
+}
+
+// GetUpcoming returns all albums released between start and end, ordered by
+// their release date.
+func (h *Handler) GetUpcoming(start, end string) (*Upcoming, error) {
+	startDate, err := time.Parse(timeutil.Format, start)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse start: %w", err)
+	}
+
+	endDate, err := time.Parse(timeutil.Format, end)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse end: %w", err)
+	}
+
+	if endDate.Before(startDate) {
+		return nil, timeutil.ErrInvalidRange
+	}
+
+	return &Upcoming{
+		StartDate: timeutil.JSONTime(startDate),
+		EndDate:   timeutil.JSONTime(endDate),
+		Releases:  h.releasesInRange(startDate, endDate),
+	}, nil
+}
+
+func (h *Handler) releasesInRange(start, end time.Time) []*Release {
+	releases := make([]*Release, 0)
+	for _, artist := range h.artists {
+		for _, album := range artist.AlbumsInRange(start, end) {
+			releases = append(releases, &Release{artist.ID, artist.Name, album})
+		}
+	}
+
+	sort.SliceStable(releases, func(i, j int) bool {
+		return releases[i].ReleaseDate.Before(releases[j].ReleaseDate)
+	})
+	return releases
+}
+
+// listFilterAll will always return true.
+func listFilterAll(*Artist, time.Time) bool {
+	return true
+}
+
+// listFilterUpcoming will return true for all artists with an unreleased album.
+func listFilterUpcoming(artist *Artist, now time.Time) bool {
+	return len(artist.UpcomingAlbums(now)) > 0
+}
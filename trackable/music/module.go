@@ -0,0 +1,35 @@
+package music
+
+import (
+	"context"
+
+	"tracker/trackable"
+)
+
+// Kind of the music trackable.
+const Kind trackable.Kind = "music"
+
+var _ trackable.Trackable = &Artist{}
+
+func init() {
+	trackable.Register(&trackable.Module{
+		Kind: Kind,
+		Name: "Music",
+		API:  &API{},
+		Load: load,
+	})
+}
+
+// load all artists as trackables.
+func load(context.Context) ([]trackable.Trackable, error) {
+	items, err := loadAllArtists()
+	if err != nil {
+		return nil, err
+	}
+
+	trackables := make([]trackable.Trackable, len(items))
+	for i, item := range items {
+		trackables[i] = item
+	}
+	return trackables, nil
+}
@@ -1,26 +1,388 @@
-package track
+package music
 
 import (
+	"database/sql"
+	"fmt"
+	"sort"
 	"time"
+
+	"tracker/database"
+	"tracker/trackable"
+
+	_ "github.com/go-sql-driver/mysql"
 )
 
 // Artist struct must implement Trackable
 type Artist struct {
-	Name       string
-	Albums     []Album
-	SoloArtist bool
+	ID           int      `json:"id"`
+	Name         string   `json:"name"`
+	WikipediaURL string   `json:"wikipedia"`
+	SoloArtist   bool     `json:"solo_artist"`
+	Albums       []*Album `json:"albums"`
 }
 
+// AlbumKind is the type of release of an album.
+type AlbumKind string
+
+const (
+	Studio      AlbumKind = "studio"
+	Live        AlbumKind = "live"
+	Compilation AlbumKind = "compilation"
+	EP          AlbumKind = "ep"
+)
+
 type Album struct {
-	Name   string
-	Tracks []string
+	ID          int       `json:"id"`
+	Title       string    `json:"title"`
+	Kind        AlbumKind `json:"kind"`
+	ReleaseDate time.Time `json:"release_date"`
+	Tracks      []*Track  `json:"tracks"`
+
+	// WikipediaURL is the path of the album article, used to scrape tracks.
+	WikipediaURL string `json:"wikipedia"`
+}
+
+type Track struct {
+	Number  int           `json:"number"`
+	Title   string        `json:"title"`
+	Runtime time.Duration `json:"runtime"`
 }
 
-type Song struct {
-	Title   string
-	Runtime time.Duration
+// Released returns true if the album has been released before the given time.
+func (a *Album) Released(now time.Time) bool {
+	return !a.ReleaseDate.IsZero() && !a.ReleaseDate.After(now)
 }
 
+// Write persists the albums of the artist and their tracks in a single
+// transaction, so that the artist is either written whole or not at all.
+// Albums and tracks which are no longer listed are removed.
 func (a *Artist) Write() error {
+	db, err := database.Open("tracker")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stored, err := storedAlbums(tx, a.ID)
+	if err != nil {
+		return fmt.Errorf("unable to write artist %d: %w", a.ID, err)
+	}
+	for _, album := range a.Albums {
+		if err := writeAlbum(tx, a.ID, album); err != nil {
+			return fmt.Errorf("unable to write artist %d: %w", a.ID, err)
+		}
+	}
+
+	// An empty discography is more likely a page which couldn't be parsed
+	// than an artist without albums, so nothing is removed then.
+	if len(a.Albums) > 0 {
+		for _, id := range removedAlbums(stored, a.Albums) {
+			if _, err := tx.Exec("DELETE FROM tracks WHERE album_id=?", id); err != nil {
+				return fmt.Errorf("unable to remove tracks of album %d: %w", id, err)
+			}
+			if _, err := tx.Exec("DELETE FROM albums WHERE id=?", id); err != nil {
+				return fmt.Errorf("unable to remove album %d: %w", id, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("unable to commit artist %d: %w", a.ID, err)
+	}
 	return nil
 }
+
+// storedAlbums returns the IDs of the stored albums of the artist by title,
+// which are locked until the transaction ends.
+func storedAlbums(tx *sql.Tx, artistID int) (map[string]int, error) {
+	rows, err := tx.Query("SELECT id, title FROM albums WHERE artist_id=? FOR UPDATE", artistID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query albums: %w", err)
+	}
+	defer rows.Close()
+
+	albums := map[string]int{}
+	for rows.Next() {
+		var (
+			id    int
+			title string
+		)
+		if err := rows.Scan(&id, &title); err != nil {
+			return nil, fmt.Errorf("unable to scan album: %w", err)
+		}
+		albums[title] = id
+	}
+	return albums, rows.Err()
+}
+
+// removedAlbums returns the IDs of the stored albums which are no longer
+// listed, in order.
+func removedAlbums(stored map[string]int, albums []*Album) []int {
+	listed := map[string]bool{}
+	for _, album := range albums {
+		listed[album.Title] = true
+	}
+	var removed []int
+	for title, id := range stored {
+		if !listed[title] {
+			removed = append(removed, id)
+		}
+	}
+	sort.Ints(removed)
+	return removed
+}
+
+// storedTracks returns the numbers of the stored tracks of the album.
+func storedTracks(tx *sql.Tx, albumID int) ([]int, error) {
+	rows, err := tx.Query("SELECT number FROM tracks WHERE album_id=? FOR UPDATE", albumID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query tracks: %w", err)
+	}
+	defer rows.Close()
+
+	var numbers []int
+	for rows.Next() {
+		var n int
+		if err := rows.Scan(&n); err != nil {
+			return nil, fmt.Errorf("unable to scan track: %w", err)
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, rows.Err()
+}
+
+// removedTracks returns the numbers of the stored tracks which are no longer
+// listed.
+func removedTracks(stored []int, tracks []*Track) []int {
+	listed := map[int]bool{}
+	for _, t := range tracks {
+		listed[t.Number] = true
+	}
+	var removed []int
+	for _, n := range stored {
+		if !listed[n] {
+			removed = append(removed, n)
+		}
+	}
+	return removed
+}
+
+// writeAlbum inserts or updates the album and replaces its tracks. Tracks
+// are only removed if the album lists some, as they aren't read for albums
+// without an article or whose article couldn't be fetched.
+func writeAlbum(tx *sql.Tx, artistID int, album *Album) error {
+	res, err := tx.Exec(`INSERT INTO albums(artist_id, title, kind, release_date, wikipedia)
+	                     VALUES(?, ?, ?, ?, ?)
+	                     ON DUPLICATE KEY UPDATE id=LAST_INSERT_ID(id), kind=VALUES(kind),
+	                         release_date=VALUES(release_date), wikipedia=VALUES(wikipedia)`,
+		artistID, album.Title, album.Kind, nullTime(album.ReleaseDate), album.WikipediaURL)
+	if err != nil {
+		return fmt.Errorf("unable to write album %q: %w", album.Title, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("unable to get id of album %q: %w", album.Title, err)
+	}
+	album.ID = int(id)
+
+	for _, t := range album.Tracks {
+		_, err = tx.Exec(`REPLACE INTO tracks(album_id, number, title, runtime)
+		                  VALUES(?, ?, ?, ?)`, album.ID, t.Number, t.Title,
+			int(t.Runtime.Seconds()))
+		if err != nil {
+			return fmt.Errorf("unable to write track %d of %q: %w", t.Number, album.Title, err)
+		}
+	}
+
+	if len(album.Tracks) == 0 {
+		return nil
+	}
+	stored, err := storedTracks(tx, album.ID)
+	if err != nil {
+		return fmt.Errorf("unable to write album %q: %w", album.Title, err)
+	}
+	for _, n := range removedTracks(stored, album.Tracks) {
+		if _, err := tx.Exec("DELETE FROM tracks WHERE album_id=? AND number=?", album.ID, n); err != nil {
+			return fmt.Errorf("unable to remove track %d of %q: %w", n, album.Title, err)
+		}
+	}
+
+	return nil
+}
+
+// nullTime stores unknown release dates as NULL.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func (a *Artist) Ref() trackable.Ref {
+	return trackable.Ref{Kind: Kind, ID: a.ID}
+}
+
+// Releases returns the albums released in the range.
+func (a *Artist) Releases(start, end time.Time) []*trackable.Release {
+	releases := make([]*trackable.Release, 0)
+	for _, album := range a.AlbumsInRange(start, end) {
+		releases = append(releases, &trackable.Release{
+			Ref:   a.Ref(),
+			Name:  a.Name,
+			Title: album.Title,
+			Date:  album.ReleaseDate,
+		})
+	}
+	return releases
+}
+
+// LatestAlbum returns the most recently released album.
+func (a *Artist) LatestAlbum(now time.Time) *Album {
+	var latest *Album
+	for _, album := range a.Albums {
+		if album.Released(now) {
+			latest = album
+		}
+	}
+	return latest
+}
+
+// UpcomingAlbums returns all albums which have not been released yet. Albums
+// without a known release date are included last.
+func (a *Artist) UpcomingAlbums(now time.Time) []*Album {
+	albums := make([]*Album, 0)
+	for _, album := range a.Albums {
+		if !album.Released(now) {
+			albums = append(albums, album)
+		}
+	}
+	return albums
+}
+
+// AlbumsInRange returns the albums released within the range.
+func (a *Artist) AlbumsInRange(start, end time.Time) []*Album {
+	albums := make([]*Album, 0)
+	for _, album := range a.Albums {
+		if !album.ReleaseDate.Before(start) && album.ReleaseDate.Before(end) {
+			albums = append(albums, album)
+		}
+	}
+	return albums
+}
+
+// sortAlbums orders albums by release date, putting unknown dates last.
+func sortAlbums(albums []*Album) {
+	sort.SliceStable(albums, func(i, j int) bool {
+		a, b := albums[i].ReleaseDate, albums[j].ReleaseDate
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero() && b.IsZero()
+		}
+		return a.Before(b)
+	})
+}
+
+func (a *Artist) String() string {
+	albumString := ""
+	for _, album := range a.Albums {
+		albumString += "\t" + album.String() + "\n"
+	}
+
+	return fmt.Sprintf("%-2d - %-30s - %3d Albums, WikipediaURL='%s'\n%s", a.ID, a.Name,
+		len(a.Albums), a.WikipediaURL, albumString)
+}
+
+func (a *Album) String() string {
+	return fmt.Sprintf("%-11s %s - '%s' (%d tracks)", a.Kind, a.ReleaseDate.Format("2006-01-02"),
+		a.Title, len(a.Tracks))
+}
+
+func loadAllArtists() ([]*Artist, error) {
+	artists := make([]*Artist, 0)
+
+	db, err := database.Open("tracker")
+	if err != nil {
+		return artists, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT id,name,wikipedia,solo FROM artists")
+	if err != nil {
+		return artists, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		artist := &Artist{}
+		if err := rows.Scan(&artist.ID, &artist.Name, &artist.WikipediaURL,
+			&artist.SoloArtist); err != nil {
+			return artists, fmt.Errorf("Unable to scan artist: %v", err)
+		}
+		artists = append(artists, artist)
+	}
+	if err := rows.Err(); err != nil {
+		return artists, err
+	}
+
+	for _, artist := range artists {
+		if err := artist.loadAllAlbums(db); err != nil {
+			return artists, err
+		}
+	}
+
+	return artists, nil
+}
+
+func (a *Artist) loadAllAlbums(db *sql.DB) error {
+	a.Albums = make([]*Album, 0)
+
+	rows, err := db.Query(`SELECT id,title,kind,release_date,wikipedia FROM albums
+	                       WHERE artist_id=?`, a.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	albums := map[int]*Album{}
+	for rows.Next() {
+		album := &Album{Tracks: make([]*Track, 0)}
+		var released sql.NullTime
+		var wikipedia sql.NullString
+		if err := rows.Scan(&album.ID, &album.Title, &album.Kind, &released,
+			&wikipedia); err != nil {
+			return fmt.Errorf("Unable to scan album: %v", err)
+		}
+		album.ReleaseDate = released.Time
+		album.WikipediaURL = wikipedia.String
+		a.Albums = append(a.Albums, album)
+		albums[album.ID] = album
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	sortAlbums(a.Albums)
+
+	rows, err = db.Query(`SELECT t.album_id,t.number,t.title,t.runtime FROM tracks t
+	                      JOIN albums a ON a.id=t.album_id
+	                      WHERE a.artist_id=? ORDER BY t.album_id,t.number`, a.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var albumID, runtime int
+		t := &Track{}
+		if err := rows.Scan(&albumID, &t.Number, &t.Title, &runtime); err != nil {
+			return fmt.Errorf("Unable to scan track: %v", err)
+		}
+		t.Runtime = time.Duration(runtime) * time.Second
+		if album, ok := albums[albumID]; ok {
+			album.Tracks = append(album.Tracks, t)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,29 @@
+package trackable
+
+import (
+	"context"
+
+	"tracker/internal/fetch"
+)
+
+// ScrapePage fetches the page at the url and parses it, unless the page is
+// unchanged since it was last fetched, in which case ErrUnchanged is
+// returned. The page is parsed along with the report of the scrape, to record
+// what couldn't be. A page which can't be parsed is forgotten by the cache, so
+// that it is parsed again next time.
+func ScrapePage(ctx context.Context, url string, parse func([]byte, *Report) error) error {
+	page, err := fetch.Fetch(ctx, url)
+	if err != nil {
+		return err
+	}
+	ReportFrom(ctx).Fetched(url)
+	if page.Unchanged {
+		return ErrUnchanged
+	}
+
+	if err := parse(page.Body, ReportFrom(ctx)); err != nil {
+		fetch.Invalidate(url)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package podcast
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"tracker/trackable"
+)
+
+// Scrape fetches the feed of the podcast and replaces its episodes with the
+// ones listed in it. Both RSS 2.0 and Atom feeds are supported.
+func (p *Podcast) Scrape(ctx context.Context) error {
+	return trackable.ScrapePage(ctx, p.FeedURL, p.parse)
+}
+
+// parse the feed, picking the format from its root element.
+func (p *Podcast) parse(body []byte, report *trackable.Report) error {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(body, &root); err != nil {
+		return fmt.Errorf("Unable to parse feed: %v", err)
+	}
+
+	var err error
+	switch root.XMLName.Local {
+	case "rss":
+		err = p.parseRSS(body, report)
+	case "feed":
+		err = p.parseAtom(body, report)
+	default:
+		err = fmt.Errorf("Unknown feed format: %s", root.XMLName.Local)
+	}
+	if err != nil {
+		return err
+	}
+
+	sortEpisodes(p.Episodes)
+	return nil
+}
+
+type rssFeed struct {
+	Channel struct {
+		Title string    `xml:"title"`
+		Links []string  `xml:"link"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+// rssItem is an episode of an RSS feed. The duration comes from the iTunes
+// extensions, which most podcast feeds use.
+type rssItem struct {
+	GUID      string `xml:"guid"`
+	Title     string `xml:"title"`
+	Link      string `xml:"link"`
+	PubDate   string `xml:"pubDate"`
+	Duration  string `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+	Enclosure struct {
+		URL string `xml:"url,attr"`
+	} `xml:"enclosure"`
+}
+
+func (p *Podcast) parseRSS(body []byte, report *trackable.Report) error {
+	var feed rssFeed
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		return fmt.Errorf("Unable to parse RSS feed: %v", err)
+	}
+
+	if title := strings.TrimSpace(feed.Channel.Title); title != "" {
+		p.Name = title
+	}
+	// Feeds often link to themselves using atom:link, which has no text.
+	for _, link := range feed.Channel.Links {
+		if link = strings.TrimSpace(link); link != "" {
+			p.WebsiteURL = link
+			break
+		}
+	}
+
+	p.Episodes = make([]*Episode, 0, len(feed.Channel.Items))
+	for _, item := range feed.Channel.Items {
+		published, err := parsePublished(item.PubDate)
+		if err != nil {
+			report.Warn("%s: %v", item.Title, err)
+		}
+
+		e := &Episode{
+			GUID:      firstNonEmpty(item.GUID, item.Enclosure.URL, item.Link, item.Title),
+			Title:     strings.TrimSpace(item.Title),
+			Published: published,
+			Duration:  parseDuration(item.Duration),
+			URL:       firstNonEmpty(item.Enclosure.URL, item.Link),
+		}
+		if e.GUID != "" {
+			p.Episodes = append(p.Episodes, e)
+		}
+	}
+
+	return nil
+}
+
+type atomFeed struct {
+	Title   string      `xml:"title"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	Duration  string     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd duration"`
+	Links     []atomLink `xml:"link"`
+}
+
+func (p *Podcast) parseAtom(body []byte, report *trackable.Report) error {
+	var feed atomFeed
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		return fmt.Errorf("Unable to parse Atom feed: %v", err)
+	}
+
+	if title := strings.TrimSpace(feed.Title); title != "" {
+		p.Name = title
+	}
+	if link := atomHref(feed.Links, "alternate"); link != "" {
+		p.WebsiteURL = link
+	}
+
+	p.Episodes = make([]*Episode, 0, len(feed.Entries))
+	for _, entry := range feed.Entries {
+		published, err := parsePublished(firstNonEmpty(entry.Published, entry.Updated))
+		if err != nil {
+			report.Warn("%s: %v", entry.Title, err)
+		}
+
+		url := firstNonEmpty(atomHref(entry.Links, "enclosure"), atomHref(entry.Links, "alternate"))
+		e := &Episode{
+			GUID:      firstNonEmpty(entry.ID, url, entry.Title),
+			Title:     strings.TrimSpace(entry.Title),
+			Published: published,
+			Duration:  parseDuration(entry.Duration),
+			URL:       url,
+		}
+		if e.GUID != "" {
+			p.Episodes = append(p.Episodes, e)
+		}
+	}
+
+	return nil
+}
+
+// atomHref returns the first link with the relation. Links without a relation
+// are alternate links.
+func atomHref(links []atomLink, rel string) string {
+	for _, l := range links {
+		if l.Rel == rel || (l.Rel == "" && rel == "alternate") {
+			return strings.TrimSpace(l.Href)
+		}
+	}
+	return ""
+}
+
+// publishedLayouts are the formats of dates found in feeds. RSS uses RFC 822
+// dates, which are often written with single digit days or named zones, and
+// Atom uses RFC 3339.
+var publishedLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	time.RFC3339,
+}
+
+// parsePublished parses the publish time of an episode. An empty value is a
+// zero time.
+func parsePublished(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range publishedLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Unknown publish date format: %q", s)
+}
+
+// parseDuration parses the iTunes duration of an episode, which is either a
+// number of seconds or given as [[HH:]MM:]SS. Unknown durations are zero.
+func parseDuration(s string) time.Duration {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0
+	}
+
+	var seconds int
+	for _, part := range strings.Split(s, ":") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0
+		}
+		seconds = seconds*60 + n
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,201 @@
+package podcast
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"tracker/internal/fetch"
+	"tracker/trackable"
+)
+
+func TestMain(m *testing.M) {
+	// The fixture server is local, there is no need to be polite to it.
+	fetch.SetDefault(fetch.New(fetch.RateLimit(0, 0)))
+	os.Exit(m.Run())
+}
+
+func TestScrape(t *testing.T) {
+	srv := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer srv.Close()
+
+	testCases := map[string]struct {
+		feed string
+		want *Podcast
+	}{
+		"rss": {
+			feed: "/rss.xml",
+			want: &Podcast{
+				Name:       "Tracker Talk",
+				WebsiteURL: "https://example.com/tracker-talk",
+				Episodes: []*Episode{{
+					GUID:      "https://example.com/audio/bonus-1.mp3",
+					Title:     "Bonus: Listener Questions",
+					Published: time.Date(2021, time.January, 15, 17, 30, 0, 0, time.UTC),
+					Duration:  20 * time.Minute,
+					URL:       "https://example.com/audio/bonus-1.mp3",
+				}, {
+					GUID:      "tracker-talk-2",
+					Title:     "Episode 2: Schedules",
+					Published: time.Date(2021, time.January, 11, 6, 0, 0, 0, time.UTC),
+					Duration:  time.Hour + 2*time.Minute + 3*time.Second,
+					URL:       "https://example.com/audio/2.mp3",
+				}, {
+					GUID:      "tracker-talk-1",
+					Title:     "Episode 1: Pilot",
+					Published: time.Date(2021, time.January, 4, 6, 0, 0, 0, time.UTC),
+					Duration:  45*time.Minute + 30*time.Second,
+					URL:       "https://example.com/audio/1.mp3",
+				}},
+			},
+		},
+		"atom": {
+			feed: "/atom.xml",
+			want: &Podcast{
+				Name:       "Release Radar",
+				WebsiteURL: "https://example.org/release-radar",
+				Episodes: []*Episode{{
+					GUID:      "urn:uuid:release-radar-11",
+					Title:     "Mid-season check in",
+					Published: time.Date(2021, time.February, 8, 11, 0, 0, 0, time.UTC),
+					URL:       "https://example.org/release-radar/11",
+				}, {
+					GUID:      "urn:uuid:release-radar-10",
+					Title:     "What's coming in February",
+					Published: time.Date(2021, time.February, 1, 12, 0, 0, 0, time.UTC),
+					Duration:  30 * time.Minute,
+					URL:       "https://example.org/release-radar/10.mp3",
+				}},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := &Podcast{FeedURL: srv.URL + tc.feed}
+			if err := p.Scrape(context.Background()); err != nil {
+				t.Fatalf("Scrape() err = %v, want %v", err, nil)
+			}
+
+			// Times are compared in UTC, as feeds give them in any zone.
+			for _, e := range p.Episodes {
+				e.Published = e.Published.UTC()
+			}
+			tc.want.FeedURL = p.FeedURL
+			if diff := deep.Equal(p, tc.want); diff != nil {
+				t.Errorf("Scrape() diff = %v", diff)
+			}
+		})
+	}
+}
+
+func TestScrapeError(t *testing.T) {
+	srv := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer srv.Close()
+
+	testCases := map[string]string{
+		"missing feed": "/missing.xml",
+		"not a feed":   "/",
+	}
+
+	for name, feed := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := &Podcast{FeedURL: srv.URL + feed}
+			if err := p.Scrape(context.Background()); err == nil {
+				t.Errorf("Scrape() err = %v, want an error", err)
+			}
+		})
+	}
+}
+
+func TestScrapeUnchanged(t *testing.T) {
+	srv := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer srv.Close()
+
+	cache, err := fetch.NewDiskCache(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("NewDiskCache() err = %v, want %v", err, nil)
+	}
+	fetch.SetDefault(fetch.New(fetch.RateLimit(0, 0), fetch.Cache(cache)))
+	defer fetch.SetDefault(fetch.New(fetch.RateLimit(0, 0)))
+
+	p := &Podcast{FeedURL: srv.URL + "/rss.xml"}
+	if err := p.Scrape(context.Background()); err != nil {
+		t.Fatalf("Scrape() err = %v, want %v", err, nil)
+	}
+	if err := p.Scrape(context.Background()); !errors.Is(err, trackable.ErrUnchanged) {
+		t.Errorf("Scrape() err = %v, want %v", err, trackable.ErrUnchanged)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	testCases := map[string]time.Duration{
+		"":         0,
+		"90":       90 * time.Second,
+		"05:30":    5*time.Minute + 30*time.Second,
+		"1:00:01":  time.Hour + time.Second,
+		"about 1h": 0,
+	}
+
+	for s, want := range testCases {
+		if got := parseDuration(s); got != want {
+			t.Errorf("parseDuration(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestReleases(t *testing.T) {
+	p := &Podcast{
+		ID:   1,
+		Name: "Tracker Talk",
+		Episodes: []*Episode{
+			{Title: "Late", Published: time.Date(2021, time.January, 11, 23, 0, 0, 0, time.UTC)},
+			{Title: "Early", Published: time.Date(2021, time.January, 4, 6, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	start := time.Date(2021, time.January, 5, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, time.January, 12, 0, 0, 0, 0, time.UTC)
+	releases := p.Releases(start, end)
+	if len(releases) != 1 || releases[0].Title != "Late" {
+		t.Errorf("Releases() = %v, want only the late episode", releases)
+	}
+}
+
+func TestRemovedEpisodes(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2021, time.January, d, 6, 0, 0, 0, time.UTC) }
+	stored := []*Episode{
+		{GUID: "archived", Published: day(1)},
+		{GUID: "kept", Published: day(4)},
+		{GUID: "pulled", Published: day(8)},
+		{GUID: "undated"},
+	}
+
+	testCases := map[string]struct {
+		episodes []*Episode
+		want     []string
+	}{
+		"feed": {
+			episodes: []*Episode{{GUID: "kept", Published: day(4)}, {GUID: "new", Published: day(11)}},
+			want:     []string{"pulled"},
+		},
+		"undated feed": {
+			episodes: []*Episode{{GUID: "kept"}},
+		},
+		"empty feed": {},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if diff := deep.Equal(removedEpisodes(stored, tc.episodes), tc.want); diff != nil {
+				t.Errorf("removedEpisodes() diff = %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package podcast
+
+import (
+	"fmt"
+	"time"
+
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+
+	"go.uber.org/zap"
+)
+
+// Handler will take care of database loading and API prepping for Podcasts.
+type Handler struct {
+	podcasts []*Podcast
+}
+
+func (h *Handler) Init() {
+	podcasts, err := loadAllPodcasts()
+	if err != nil {
+		h.podcasts = make([]*Podcast, 0)
+		zap.L().Error("unable to load podcasts", zap.Error(err))
+	} else {
+		h.podcasts = podcasts
+	}
+}
+
+type PodcastSimple struct {
+	ID     int
+	Name   string
+	Latest *Episode
+}
+
+type PodcastList struct {
+	Count    int
+	Podcasts []*PodcastSimple
+}
+
+type PodcastFull struct {
+	*Podcast
+
+	Latest *Episode `json:"latest"`
+}
+
+var listFilters = map[string]func(*Podcast, time.Time) bool{
+	"all":    listFilterAll,
+	"active": listFilterActive,
+}
+
+func (h *Handler) Get(id int) (*PodcastFull, error) {
+	for _, p := range h.podcasts {
+		if p.ID == id {
+			return &PodcastFull{
+				Podcast: p,
+				Latest:  p.LatestEpisode(),
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("Invalid podcast ID")
+}
+
+func (h *Handler) GetList(listType string) (*PodcastList, error) {
+	filter, ok := listFilters[listType]
+	if !ok {
+		return nil, fmt.Errorf("Unknown list type: %s", listType)
+	}
+
+	now := time.Now()
+	podcasts := make([]*PodcastSimple, 0)
+	for _, p := range h.podcasts {
+		if filter(p, now) {
+			podcasts = append(podcasts, &PodcastSimple{
+				ID:     p.ID,
+				Name:   p.Name,
+				Latest: p.LatestEpisode(),
+			})
+		}
+	}
+
+	return &PodcastList{
+		Count:    len(podcasts),
+		Podcasts: podcasts,
+	}, nil
+}
+
+// GetSchedule returns every episode published between start and end.
+func (h *Handler) GetSchedule(start, end string) (*trackable.Schedule, error) {
+	startDate, endDate, err := trackable.ParseRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]*trackable.Release, 0)
+	for _, p := range h.podcasts {
+		releases = append(releases, p.Releases(startDate, endDate)...)
+	}
+
+	return &trackable.Schedule{
+		StartDate: timeutil.JSONTime(startDate),
+		EndDate:   timeutil.JSONTime(endDate),
+		Releases:  releases,
+	}, nil
+}
+
+// listFilterAll will always return true.
+func listFilterAll(*Podcast, time.Time) bool {
+	return true
+}
+
+// listFilterActive will return true for podcasts which published an episode
+// recently.
+func listFilterActive(p *Podcast, now time.Time) bool {
+	return p.Status(now) == trackable.Active
+}
@@ -0,0 +1,38 @@
+package podcast
+
+import (
+	"context"
+
+	"tracker/trackable"
+)
+
+// Kind of the podcast trackable.
+const Kind trackable.Kind = "podcast"
+
+var (
+	_ trackable.Trackable = &Podcast{}
+	_ trackable.Statuser  = &Podcast{}
+)
+
+func init() {
+	trackable.Register(&trackable.Module{
+		Kind: Kind,
+		Name: "Podcasts",
+		API:  &API{},
+		Load: load,
+	})
+}
+
+// load all podcasts as trackables.
+func load(context.Context) ([]trackable.Trackable, error) {
+	items, err := loadAllPodcasts()
+	if err != nil {
+		return nil, err
+	}
+
+	trackables := make([]trackable.Trackable, len(items))
+	for i, item := range items {
+		trackables[i] = item
+	}
+	return trackables, nil
+}
@@ -0,0 +1,262 @@
+package podcast
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"tracker/database"
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// Podcast struct must implement Trackable
+type Podcast struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	FeedURL    string     `json:"feed"`
+	WebsiteURL string     `json:"website"`
+	Episodes   []*Episode `json:"episodes"`
+}
+
+// Episode of a podcast, as listed in its feed. The GUID identifies the episode
+// within the feed.
+type Episode struct {
+	GUID      string        `json:"guid"`
+	Title     string        `json:"title"`
+	Published time.Time     `json:"published"`
+	Duration  time.Duration `json:"duration"`
+	URL       string        `json:"url"`
+}
+
+// Runtime returns the duration of the episode in a readable form, or an empty
+// string when the feed doesn't list it.
+func (e *Episode) Runtime() string {
+	if e.Duration <= 0 {
+		return ""
+	}
+	hours := int(e.Duration.Hours())
+	minutes := int(e.Duration.Minutes()) % 60
+	if hours == 0 {
+		return fmt.Sprintf("%dmin", minutes)
+	}
+	return fmt.Sprintf("%dh%02d", hours, minutes)
+}
+
+// Write persists the podcast and its episodes in a single transaction.
+// Episodes which are no longer in the feed are removed, unless they are older
+// than every episode it lists, as feeds often only list the latest ones.
+func (p *Podcast) Write() error {
+	db, err := database.Open("tracker")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("UPDATE podcasts SET title=?, website=? WHERE id=?",
+		p.Name, p.WebsiteURL, p.ID)
+	if err != nil {
+		return fmt.Errorf("unable to write podcast %d: %w", p.ID, err)
+	}
+
+	stored, err := storedEpisodes(tx, p.ID)
+	if err != nil {
+		return fmt.Errorf("unable to write podcast %d: %w", p.ID, err)
+	}
+	for _, e := range p.Episodes {
+		_, err = tx.Exec(`REPLACE INTO podcast_episodes(podcast_id, guid, title, published, duration, url)
+		                  VALUES(?, ?, ?, ?, ?, ?)`, p.ID, e.GUID, e.Title,
+			sql.NullTime{Time: e.Published, Valid: !e.Published.IsZero()},
+			int(e.Duration.Seconds()), e.URL)
+		if err != nil {
+			return fmt.Errorf("unable to write episode %q of podcast %d: %w", e.GUID, p.ID, err)
+		}
+	}
+
+	for _, guid := range removedEpisodes(stored, p.Episodes) {
+		if _, err := tx.Exec("DELETE FROM podcast_episodes WHERE podcast_id=? AND guid=?", p.ID, guid); err != nil {
+			return fmt.Errorf("unable to remove episode %q of podcast %d: %w", guid, p.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("unable to commit podcast %d: %w", p.ID, err)
+	}
+	return nil
+}
+
+// storedEpisodes returns the stored episodes of the podcast, which are
+// locked until the transaction ends.
+func storedEpisodes(tx *sql.Tx, podcastID int) ([]*Episode, error) {
+	rows, err := tx.Query("SELECT guid, published FROM podcast_episodes WHERE podcast_id=? FOR UPDATE",
+		podcastID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query episodes: %w", err)
+	}
+	defer rows.Close()
+
+	var episodes []*Episode
+	for rows.Next() {
+		e := &Episode{}
+		var published sql.NullTime
+		if err := rows.Scan(&e.GUID, &published); err != nil {
+			return nil, fmt.Errorf("unable to scan episode: %w", err)
+		}
+		e.Published = published.Time
+		episodes = append(episodes, e)
+	}
+	return episodes, rows.Err()
+}
+
+// removedEpisodes returns the GUIDs of the stored episodes which are no
+// longer in the feed, but were published since its oldest episode. Nothing
+// is removed if the feed doesn't date any episode.
+func removedEpisodes(stored, episodes []*Episode) []string {
+	var oldest time.Time
+	listed := map[string]bool{}
+	for _, e := range episodes {
+		listed[e.GUID] = true
+		if !e.Published.IsZero() && (oldest.IsZero() || e.Published.Before(oldest)) {
+			oldest = e.Published
+		}
+	}
+	if oldest.IsZero() {
+		return nil
+	}
+
+	var removed []string
+	for _, e := range stored {
+		if !listed[e.GUID] && !e.Published.Before(oldest) {
+			removed = append(removed, e.GUID)
+		}
+	}
+	return removed
+}
+
+func (p *Podcast) Ref() trackable.Ref {
+	return trackable.Ref{Kind: Kind, ID: p.ID}
+}
+
+// Releases returns the episodes published in the range.
+func (p *Podcast) Releases(start, end time.Time) []*trackable.Release {
+	releases := make([]*trackable.Release, 0)
+	for _, e := range p.Episodes {
+		if e.Published.Before(start) || !e.Published.Before(end) {
+			continue
+		}
+		releases = append(releases, &trackable.Release{
+			Ref:   p.Ref(),
+			Name:  p.Name,
+			Title: e.Title,
+			Date:  e.Published,
+		})
+	}
+	return releases
+}
+
+// LatestEpisode returns the most recently published episode, or nil if the
+// podcast has no episodes.
+func (p *Podcast) LatestEpisode() *Episode {
+	var latest *Episode
+	for _, e := range p.Episodes {
+		if latest == nil || e.Published.After(latest.Published) {
+			latest = e
+		}
+	}
+	return latest
+}
+
+// activePeriod is how recently a podcast must have published an episode to
+// be considered active.
+const activePeriod = 30 * timeutil.Day
+
+// Status returns whether the podcast published an episode recently.
+func (p *Podcast) Status(now time.Time) trackable.Status {
+	if latest := p.LatestEpisode(); latest != nil && latest.Published.After(now.Add(-activePeriod)) {
+		return trackable.Active
+	}
+	return trackable.Idle
+}
+
+// sortEpisodes orders the episodes from newest to oldest.
+func sortEpisodes(episodes []*Episode) {
+	sort.SliceStable(episodes, func(i, j int) bool {
+		return episodes[i].Published.After(episodes[j].Published)
+	})
+}
+
+func (p *Podcast) String() string {
+	episodeString := ""
+	for _, e := range p.Episodes {
+		episodeString += fmt.Sprintf("\t%s - %s %s\n", e.Published.Format("2006-01-02"),
+			e.Title, e.Runtime())
+	}
+
+	return fmt.Sprintf("%-2d - %-30s - %3d Episodes, FeedURL='%s'\n%s", p.ID, p.Name,
+		len(p.Episodes), p.FeedURL, episodeString)
+}
+
+func loadAllPodcasts() ([]*Podcast, error) {
+	podcasts := make([]*Podcast, 0)
+
+	db, err := database.Open("tracker")
+	if err != nil {
+		return podcasts, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT id,title,feed,website FROM podcasts")
+	if err != nil {
+		return podcasts, err
+	}
+	defer rows.Close()
+
+	byID := map[int]*Podcast{}
+	for rows.Next() {
+		p := &Podcast{Episodes: make([]*Episode, 0)}
+		var website sql.NullString
+		if err := rows.Scan(&p.ID, &p.Name, &p.FeedURL, &website); err != nil {
+			return podcasts, fmt.Errorf("Unable to scan podcast: %v", err)
+		}
+		p.WebsiteURL = website.String
+		podcasts = append(podcasts, p)
+		byID[p.ID] = p
+	}
+	if err := rows.Err(); err != nil {
+		return podcasts, err
+	}
+
+	rows, err = db.Query(`SELECT podcast_id,guid,title,published,duration,url FROM podcast_episodes
+	                      ORDER BY published DESC`)
+	if err != nil {
+		return podcasts, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, duration int
+		var published sql.NullTime
+		var url sql.NullString
+		e := &Episode{}
+		if err := rows.Scan(&id, &e.GUID, &e.Title, &published, &duration, &url); err != nil {
+			return podcasts, fmt.Errorf("Unable to scan episode: %v", err)
+		}
+		e.Published = published.Time
+		e.Duration = time.Duration(duration) * time.Second
+		e.URL = url.String
+		if p, ok := byID[id]; ok {
+			p.Episodes = append(p.Episodes, e)
+		}
+	}
+
+	return podcasts, rows.Err()
+}
@@ -0,0 +1,148 @@
+package trackable
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"tracker/server"
+)
+
+// Module describes a kind of trackable and how the rest of the tracker
+// interacts with it.
+type Module struct {
+	Kind Kind
+
+	// Name of the kind shown to users, e.g. "Shows".
+	Name string
+
+	// API is served by the backend at api/<kind>.
+	API server.API
+
+	// Load returns every trackable of this kind from the database.
+	Load func(ctx context.Context) ([]Trackable, error)
+}
+
+var (
+	mu      sync.RWMutex
+	modules = map[Kind]*Module{}
+)
+
+// Register makes a kind of trackable available to the tracker. It is meant
+// to be called from the init function of the package implementing the kind,
+// and panics if the kind is registered twice.
+func Register(m *Module) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if m.Kind == "" {
+		panic("trackable: Register with empty kind")
+	}
+	if _, ok := modules[m.Kind]; ok {
+		panic(fmt.Sprintf("trackable: Register called twice for kind %s", m.Kind))
+	}
+	modules[m.Kind] = m
+}
+
+// Modules returns all registered kinds, ordered by kind.
+func Modules() []*Module {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	list := make([]*Module, 0, len(modules))
+	for _, m := range modules {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Kind < list[j].Kind
+	})
+	return list
+}
+
+// Lookup returns the module of the kind, if it has been registered.
+func Lookup(kind Kind) (*Module, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	m, ok := modules[kind]
+	return m, ok
+}
+
+// APIs returns the APIs of all registered kinds, keyed by their path on the
+// backend.
+func APIs() map[string]server.API {
+	apis := map[string]server.API{}
+	for _, m := range Modules() {
+		if m.API != nil {
+			apis[fmt.Sprintf("api/%s", m.Kind)] = m.API
+		}
+	}
+	return apis
+}
+
+// ScrapeAll scrapes and persists every trackable of the module, using up to
+// workers trackables at once. A trackable which fails doesn't stop the others
+// from being scraped. The report of every trackable is passed to reported,
+// if it isn't nil, from the workers concurrently.
+func (m *Module) ScrapeAll(ctx context.Context, workers int, reported func(*Report)) error {
+	items, err := m.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to load %s: %w", m.Kind, err)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		wg     sync.WaitGroup
+		errMu  sync.Mutex
+		errors = make([]error, 0)
+		queue  = make(chan Trackable)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range queue {
+				r, err := Scrape(ctx, item)
+				if reported != nil {
+					reported(r)
+				}
+				if err != nil {
+					errMu.Lock()
+					errors = append(errors, fmt.Errorf("%s: %w", item.Ref(), err))
+					errMu.Unlock()
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for _, item := range items {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case queue <- item:
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(errors) > 0 {
+		failed := make([]string, len(errors))
+		for i, err := range errors {
+			failed[i] = err.Error()
+		}
+		return fmt.Errorf("%d of %d %s failed: %s", len(errors), len(items), m.Kind,
+			strings.Join(failed, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package trackable
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+)
+
+func TestModuleScrapeAll(t *testing.T) {
+	items := []*testTrackable{{id: 1}, {id: 2, err: errors.New("unreachable")}, {id: 3}}
+	m := &Module{
+		Kind: "test",
+		Load: func(context.Context) ([]Trackable, error) {
+			trackables := make([]Trackable, len(items))
+			for i, item := range items {
+				trackables[i] = item
+			}
+			return trackables, nil
+		},
+	}
+
+	var (
+		mu      sync.Mutex
+		reports = map[Ref]string{}
+	)
+	reported := func(r *Report) {
+		mu.Lock()
+		defer mu.Unlock()
+		reports[r.Ref] = r.Error
+	}
+	if err := m.ScrapeAll(context.Background(), 2, reported); err == nil {
+		t.Errorf("ScrapeAll() err = %v, want an error", err)
+	}
+
+	wantReports := map[Ref]string{
+		items[0].Ref(): "",
+		items[1].Ref(): "unable to scrape: unreachable",
+		items[2].Ref(): "",
+	}
+	if diff := deep.Equal(reports, wantReports); diff != nil {
+		t.Errorf("ScrapeAll() reports diff = %v", diff)
+	}
+
+	for _, item := range items {
+		wantWritten := item.err == nil
+		if !item.scraped || item.written != wantWritten {
+			t.Errorf("%s scraped = %t, written = %t, want %t and %t",
+				item.Ref(), item.scraped, item.written, true, wantWritten)
+		}
+	}
+}
+
+func TestModuleScrapeAllCancelled(t *testing.T) {
+	items := []*testTrackable{{id: 1}, {id: 2}}
+	m := &Module{
+		Kind: "test",
+		Load: func(context.Context) ([]Trackable, error) {
+			return []Trackable{items[0], items[1]}, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := m.ScrapeAll(ctx, 2, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("ScrapeAll() err = %v, want %v", err, context.Canceled)
+	}
+	for _, item := range items {
+		if item.scraped {
+			t.Errorf("%s scraped after cancel", item.Ref())
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register(&Module{Kind: "b"})
+	Register(&Module{Kind: "a"})
+
+	var kinds []Kind
+	for _, m := range Modules() {
+		kinds = append(kinds, m.Kind)
+	}
+	if diff := deep.Equal(kinds, []Kind{"a", "b"}); diff != nil {
+		t.Errorf("Modules() diff = %v", diff)
+	}
+
+	if _, ok := Lookup("a"); !ok {
+		t.Errorf("Lookup(a) = _, %t, want %t", ok, true)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Register() twice didn't panic")
+		}
+	}()
+	Register(&Module{Kind: "a"})
+}
+
+type testTrackable struct {
+	id  int
+	err error
+
+	scraped, written bool
+}
+
+func (t *testTrackable) Ref() Ref {
+	return Ref{Kind: "test", ID: t.id}
+}
+
+func (t *testTrackable) Scrape(context.Context) error {
+	t.scraped = true
+	return t.err
+}
+
+func (t *testTrackable) Write() error {
+	t.written = true
+	return nil
+}
+
+func (t *testTrackable) Releases(time.Time, time.Time) []*Release {
+	return nil
+}
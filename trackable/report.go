@@ -0,0 +1,153 @@
+package trackable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"tracker/internal/fetch"
+)
+
+// Skip is a row of a source which couldn't be parsed, and was left out.
+type Skip struct {
+	Where  string `json:"where"`
+	Reason string `json:"reason"`
+}
+
+// Report of a single scrape of a trackable: what was fetched and parsed, and
+// what went wrong. Its methods are safe to call on a nil report, so that
+// scrapers don't need to know whether anyone is listening.
+type Report struct {
+	Ref
+
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+
+	Pages    []string `json:"pages,omitempty"`
+	Tables   int      `json:"tables"`
+	Parsed   int      `json:"parsed"`
+	Skipped  []*Skip  `json:"skipped,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Unchanged is true if the source hasn't changed since the last scrape.
+	Unchanged bool   `json:"unchanged,omitempty"`
+	Diff      *Diff  `json:"diff,omitempty"`
+	Error     string `json:"error,omitempty"`
+
+	mu sync.Mutex
+}
+
+// Fetched records a page fetched from the source.
+func (r *Report) Fetched(url string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Pages = append(r.Pages, url)
+}
+
+// fetched returns the pages fetched so far.
+func (r *Report) fetched() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.Pages...)
+}
+
+// Table records a table of releases found in the source.
+func (r *Report) Table() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Tables++
+}
+
+// Parse records a release parsed from the source.
+func (r *Report) Parse() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Parsed++
+}
+
+// Skip records a row of the source which was left out, and why.
+func (r *Report) Skip(where, reason string, args ...interface{}) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Skipped = append(r.Skipped, &Skip{Where: where, Reason: fmt.Sprintf(reason, args...)})
+}
+
+// Warn records something which was parsed, but possibly wrong.
+func (r *Report) Warn(format string, args ...interface{}) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Problem returns true if the scrape failed, or left something out.
+func (r *Report) Problem() bool {
+	return r.Error != "" || len(r.Skipped) > 0 || len(r.Warnings) > 0
+}
+
+type reportKey struct{}
+
+// WithReport returns a context in which scrapers record to the report.
+func WithReport(ctx context.Context, r *Report) context.Context {
+	return context.WithValue(ctx, reportKey{}, r)
+}
+
+// ReportFrom returns the report of the context, nil if there is none.
+func ReportFrom(ctx context.Context) *Report {
+	r, _ := ctx.Value(reportKey{}).(*Report)
+	return r
+}
+
+// Scrape scrapes and persists the trackable, reporting on how it went.
+// Trackables which haven't changed aren't written again.
+func Scrape(ctx context.Context, item Trackable) (*Report, error) {
+	r := &Report{Ref: item.Ref(), Started: time.Now().UTC()}
+	err := scrape(WithReport(ctx, r), item)
+	r.Duration = time.Since(r.Started)
+	if err != nil {
+		r.Error = err.Error()
+	}
+	return r, err
+}
+
+func scrape(ctx context.Context, item Trackable) error {
+	err := item.Scrape(ctx)
+	if errors.Is(err, ErrUnchanged) {
+		ReportFrom(ctx).Unchanged = true
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to scrape: %w", err)
+	}
+
+	diff, err := Write(ctx, item)
+	if err != nil {
+		// The pages are cached as fetched already, and would be unchanged
+		// next time, so that what failed to be written wouldn't be again.
+		for _, url := range ReportFrom(ctx).fetched() {
+			fetch.Invalidate(url)
+		}
+		return fmt.Errorf("unable to write: %w", err)
+	}
+	ReportFrom(ctx).Diff = diff
+	return nil
+}
@@ -0,0 +1,79 @@
+package trackable
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"tracker/internal/fetch"
+)
+
+// pageTrackable is scraped from a single page, and fails to be written as
+// long as it has an error.
+type pageTrackable struct {
+	url    string
+	err    error
+	parsed int
+}
+
+func (t *pageTrackable) Ref() Ref {
+	return Ref{Kind: "test", ID: 1}
+}
+
+func (t *pageTrackable) Scrape(ctx context.Context) error {
+	return ScrapePage(ctx, t.url, func([]byte, *Report) error {
+		t.parsed++
+		return nil
+	})
+}
+
+func (t *pageTrackable) Write() error {
+	return t.err
+}
+
+func (t *pageTrackable) Releases(time.Time, time.Time) []*Release {
+	return nil
+}
+
+func TestScrapeWriteFailed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("page"))
+	}))
+	defer srv.Close()
+
+	cache, err := fetch.NewDiskCache(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatalf("NewDiskCache() err = %v, want %v", err, nil)
+	}
+	old := fetch.Default()
+	fetch.SetDefault(fetch.New(fetch.Cache(cache), fetch.RateLimit(0, 0)))
+	t.Cleanup(func() { fetch.SetDefault(old) })
+
+	item := &pageTrackable{url: srv.URL, err: errors.New("database is down")}
+	if _, err := Scrape(context.Background(), item); err == nil {
+		t.Fatalf("Scrape() err = %v, want an error", err)
+	}
+
+	// The page is parsed and written again, rather than unchanged.
+	item.err = nil
+	r, err := Scrape(context.Background(), item)
+	if err != nil {
+		t.Fatalf("Scrape() err = %v, want %v", err, nil)
+	}
+	if r.Unchanged || item.parsed != 2 {
+		t.Errorf("Scrape() unchanged = %t, parsed %d times, want %t and %d", r.Unchanged, item.parsed, false, 2)
+	}
+
+	// Once written, the page is unchanged.
+	if r, _ := Scrape(context.Background(), item); !r.Unchanged {
+		t.Errorf("Scrape() unchanged = %t, want %t", r.Unchanged, true)
+	}
+}
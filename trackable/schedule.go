@@ -0,0 +1,34 @@
+package trackable
+
+import (
+	"fmt"
+	"time"
+
+	"tracker/internal/timeutil"
+)
+
+// Schedule contains the releases of trackables within a range of dates.
+type Schedule struct {
+	StartDate timeutil.JSONTime `json:"start_date"`
+	EndDate   timeutil.JSONTime `json:"end_date"`
+	Releases  []*Release        `json:"releases"`
+}
+
+// ParseRange parses the start and the end of a range of dates, as used by
+// the schedule of the APIs.
+func ParseRange(start, end string) (time.Time, time.Time, error) {
+	startDate, err := time.Parse(timeutil.Format, start)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("unable to parse start: %w", err)
+	}
+
+	endDate, err := time.Parse(timeutil.Format, end)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("unable to parse end: %w", err)
+	}
+
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, timeutil.ErrInvalidRange
+	}
+	return startDate, endDate, nil
+}
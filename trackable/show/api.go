@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"tracker/internal/timeutil"
 	"tracker/server/host"
 	"tracker/server/page"
 
@@ -23,6 +24,9 @@ func (a *API) RegisterHandlers(subdomain string) {
 	rtr := mux.NewRouter()
 	rtr.HandleFunc(fmt.Sprintf("/%s/", subdomain), a.defaultRequest)
 	rtr.HandleFunc(fmt.Sprintf("/%s/get/{id:[0-9]+}", subdomain), a.getRequest)
+	rtr.HandleFunc(fmt.Sprintf("/%s/get/{id:[0-9]+}/history", subdomain), a.historyRequest)
+	rtr.HandleFunc(fmt.Sprintf("/%s/correct/{id:[0-9]+}/{season:[0-9]+}/{episode:[0-9]+}", subdomain),
+		a.correctRequest).Methods(http.MethodPost)
 	rtr.HandleFunc(fmt.Sprintf("/%s/get/list", subdomain), a.listRequest)
 	rtr.HandleFunc(fmt.Sprintf("/%s/get/list/{type:[a-z]*}", subdomain), a.listRequest)
 	rtr.HandleFunc(fmt.Sprintf("/%s/get/schedule/{start:[0-9-]+}/{end:[0-9-]+}", subdomain),
@@ -65,6 +69,77 @@ func (a *API) getRequest(w http.ResponseWriter, r *http.Request) {
 	p.ServePage(w)
 }
 
+func (a *API) historyRequest(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		serveError(err, w, r)
+		return
+	}
+
+	history, err := a.handler.History(r.Context(), id)
+	if err != nil {
+		serveError(err, w, r)
+		return
+	}
+
+	body, err := json.Marshal(history)
+	if err != nil {
+		serveError(err, w, r)
+		return
+	}
+	p := page.Page{Body: body}
+	p.ServePage(w)
+}
+
+// correctRequest corrects the title or release date of an episode, given as
+// the form values title and release_date. Release dates may be partial, as
+// in "2025-03" or "2025-Q2". The source is admin or user.
+func (a *API) correctRequest(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	c := &Correction{
+		Title:  r.FormValue("title"),
+		Source: Source(r.FormValue("source")),
+	}
+
+	var err error
+	if c.ShowID, err = strconv.Atoi(params["id"]); err != nil {
+		serveError(err, w, r)
+		return
+	}
+	if c.Season, err = strconv.Atoi(params["season"]); err != nil {
+		serveError(err, w, r)
+		return
+	}
+	if c.Episode, err = strconv.Atoi(params["episode"]); err != nil {
+		serveError(err, w, r)
+		return
+	}
+	if date := r.FormValue("release_date"); date != "" {
+		if c.ReleaseDate, err = timeutil.ParseDate(date); err != nil {
+			serveError(err, w, r)
+			return
+		}
+	}
+	if c.Source == SourceScraper {
+		serveError(fmt.Errorf("%w: %q", ErrInvalidSource, c.Source), w, r)
+		return
+	}
+
+	changes, err := a.handler.Correct(r.Context(), c)
+	if err != nil {
+		serveError(err, w, r)
+		return
+	}
+
+	body, err := json.Marshal(changes)
+	if err != nil {
+		serveError(err, w, r)
+		return
+	}
+	p := page.Page{Body: body}
+	p.ServePage(w)
+}
+
 func (a *API) listRequest(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 
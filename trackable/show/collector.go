@@ -1,35 +1,28 @@
 package show
 
 import (
+	"context"
 	"fmt"
-	"io/ioutil"
-	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
-	"time"
 
-	"tracker/internal/timeutil"
+	"tracker/internal/fetch"
 	"tracker/scrape"
+	"tracker/trackable"
 )
 
 type attr = map[string]string
 
-func Collect() error {
-	// This should handle getting a list of all Shows we want and crawling through each one.
-	// We shouldn't be giving this a static URL but for now it's fine.
-	// In the end it should be able to queue up jobs to be run and the "collector" will
-	// take care of them.
-	show := &Show{}
-	return show.scrape("https://en.wikipedia.org/wiki/Game_of_Thrones")
-}
-
-func (s *Show) scrape(url string) error {
-	body, err := getBytes(url)
+func (s *Show) scrape(ctx context.Context, url string) error {
+	report := trackable.ReportFrom(ctx)
+	page, err := fetch.Fetch(ctx, url)
 	if err != nil {
 		return err
 	}
+	report.Fetched(url)
 
-	scraper, err := scrape.Create(body)
+	scraper, err := scrape.Create(page.Body)
 	if err != nil {
 		return fmt.Errorf("Unable to create scraper; %v\n", err)
 	}
@@ -37,52 +30,121 @@ func (s *Show) scrape(url string) error {
 	// Scrape info from the infobox.
 	infobox := scraper.FindFirst("table", attr{"class": "infobox"})
 	if infobox.Valid {
-		if err := s.parseInfobox(infobox); err != nil {
+		if err := s.parseInfobox(infobox, report); err != nil {
+			fetch.Invalidate(url)
 			return err
 		}
 	}
 
+	// The episodes are either listed in the article itself, or on a separate
+	// list of episodes.
+	episodes := page
 	if s.EpisodeURL == "" {
 		s.EpisodeURL = url
+	} else if s.EpisodeURL != url {
+		episodes, err = fetch.Fetch(ctx, s.EpisodeURL)
+		if err != nil {
+			return err
+		}
+		report.Fetched(s.EpisodeURL)
 	}
-	if err := s.scrapeEpisodes(s.EpisodeURL); err != nil {
-		return err
+	if page.Unchanged && episodes.Unchanged && !s.reread {
+		return trackable.ErrUnchanged
 	}
 
-	fmt.Printf("Show: %+v\n", s)
+	if err := s.scrapeEpisodes(episodes.Body, report); err != nil {
+		fetch.Invalidate(url)
+		fetch.Invalidate(s.EpisodeURL)
+		return err
+	}
 	return nil
 }
 
-func (s *Show) scrapeEpisodes(url string) error {
-	body, err := getBytes(url)
-	if err != nil {
-		return err
-	}
+// headingLevels are the levels of the headings of an article by tag.
+var headingLevels = map[string]int{"h2": 2, "h3": 3, "h4": 4, "h5": 5}
 
+// scrapeEpisodes parses the tables of episodes of the page, finding out what
+// each table lists from the headings it is listed under.
+func (s *Show) scrapeEpisodes(body []byte, report *trackable.Report) error {
 	scraper, err := scrape.Create(body)
 	if err != nil {
 		return fmt.Errorf("Unable to create scraper; %v - %v\n", err, scraper)
 	}
 
-	seasonNum := 1
-	var previousDate time.Time
-	tables := scraper.FindAll("table", attr{"class": "wikiepisodetable"})
-	for _, table := range tables {
-		if !table.Valid {
+	s.Episodes = nil
+	loc := s.locale()
+	seasons := newSeasonTracker()
+	var headings [6]string
+	for _, tag := range scraper.FindAllOf([]string{"h2", "h3", "h4", "h5", "table"}, nil) {
+		if level, ok := headingLevels[tag.Name()]; ok {
+			headings[level] = headingText(tag)
+			for i := level + 1; i < len(headings); i++ {
+				headings[i] = ""
+			}
 			continue
 		}
 
-		err := s.parseEpisodeTable(table, seasonNum, previousDate)
-		seasonNum++
-		if err != nil {
-			return err
+		if class, _ := tag.GetAttr("class"); !tag.Valid || !looseClass(class, loc.tableClass) {
+			continue
+		}
+		var cols columns
+		if loc.columns != nil {
+			// Tables of other Wikipedias are only told apart by their
+			// columns, as overviews of seasons don't have titles.
+			if cols = loc.columns.find(tableHeadings(tag)); cols.title < 0 {
+				continue
+			}
+		}
+		report.Table()
+		if seasons.table(headings[:]) == extrasSection {
+			report.Skip(strings.Join(nonEmpty(headings[:]), " / "), "not tracking extras")
+			continue
+		}
+		if loc.columns != nil {
+			s.parseColumnTable(tag, cols, seasons, report)
+		} else {
+			s.parseEpisodeTable(tag, seasons, report)
 		}
-
 	}
 	return nil
 }
 
-func (s *Show) parseInfobox(infobox *scrape.Tag) error {
+// headingText returns the text of the heading, without its edit link.
+func headingText(tag *scrape.Tag) string {
+	return parseString(strings.Replace(tag.Text(), "[edit]", "", 1))
+}
+
+func looseClass(class, want string) bool {
+	for _, c := range strings.Fields(class) {
+		if c == want {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+func nonEmpty(list []string) []string {
+	var out []string
+	for _, s := range list {
+		if s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// parseInfobox reads the name and info of the show from its infobox, along
+// with the link to its list of episodes.
+func (s *Show) parseInfobox(infobox *scrape.Tag, report *trackable.Report) error {
 	if !infobox.Valid {
 		return fmt.Errorf("Infobox is not a valid object")
 	}
@@ -98,19 +160,24 @@ func (s *Show) parseInfobox(infobox *scrape.Tag) error {
 		// Find the link to the episode list
 		link := row.FindFirst("a", nil)
 		attrib, ok := link.GetAttr("title")
-		if ok && strings.Contains(attrib, "List of") {
+		if ok && containsAny(attrib, s.locale().listLinks) {
 			if href, ok := link.GetAttr("href"); ok {
-				s.EpisodeURL = fmt.Sprintf("http://en.wikipedia.org%s", href)
+				s.EpisodeURL = fmt.Sprintf("http://%s.wikipedia.org%s", s.lang(), href)
 			}
 		}
 	}
+	s.Info = s.parseInfoRows(rows, report)
 
 	return nil
 }
 
-func (s *Show) parseEpisodeTable(table *scrape.Tag, season int, previousDate time.Time) error {
+// parseEpisodeTable adds the episodes of the table to the show, in the
+// season the tracker assigns them. Rows which can't be parsed are skipped,
+// and recorded in the report.
+func (s *Show) parseEpisodeTable(table *scrape.Tag, seasons *seasonTracker, report *trackable.Report) {
+	dates := s.locale().dates
 	rows := table.FindAll("tr", nil)
-	for _, row := range rows {
+	for i, row := range rows {
 		if !row.Valid {
 			continue
 		}
@@ -120,16 +187,16 @@ func (s *Show) parseEpisodeTable(table *scrape.Tag, season int, previousDate tim
 			continue
 		}
 
+		where := fmt.Sprintf("season %d, row %d", seasons.season, i)
 		episodeNumStr := parseString(columns[0].Text())
 		episodeNum, err := strconv.Atoi(episodeNumStr)
-		if err != nil {
-			return fmt.Errorf("Unable to convert %s to an integer: %v", episodeNumStr, err)
+		if err != nil && seasons.season != 0 {
+			report.Skip(where, "episode number %q isn't a number", episodeNumStr)
+			continue
 		}
 
-		episode := &Episode{
-			Season:  season,
-			Episode: episodeNum,
-		}
+		episode := &Episode{}
+		episode.Season, episode.Episode = seasons.episode(episodeNum)
 
 		for _, column := range columns {
 			if !column.Valid {
@@ -143,43 +210,97 @@ func (s *Show) parseEpisodeTable(table *scrape.Tag, season int, previousDate tim
 				continue
 			}
 
-			// Get release date
+			// Get release date, the first column which reads as one. Other
+			// columns may read "TBA" as well, so unknown dates don't stop
+			// the search.
 			text := parseString(column.Text())
-			if timeutil.HasMonth(text) {
-				episode.ReleaseDate, err = timeutil.Parse(text)
+			if !episode.ReleaseDate.Known() && dates.HasDate(text) {
+				episode.ReleaseDate, err = dates.ParseDate(text)
 				if err != nil {
-					fmt.Printf("Unable to convert %s to a date object: %v\n", text, err)
+					report.Warn("%s: release date %q isn't a date", where, text)
 				}
 				continue
 			}
 
 		}
 
-		// If new date is less than previous date we can skip this episode.
-		// (Indicative of Webisodes)
-		if episode.ReleaseDate.Before(previousDate) {
+		s.Episodes = append(s.Episodes, episode)
+		report.Parse()
+	}
+}
+
+// tableHeadings returns the headings of the columns of the table, those of
+// its first row of header cells only.
+func tableHeadings(table *scrape.Tag) []string {
+	for _, row := range table.FindAll("tr", nil) {
+		if !row.Valid || len(row.FindAll("td", nil)) > 0 {
 			continue
 		}
-
-		previousDate = episode.ReleaseDate
-		s.Episodes = append(s.Episodes, episode)
+		var headings []string
+		for _, th := range row.FindAll("th", nil) {
+			headings = append(headings, parseString(th.Text()))
+		}
+		if len(headings) > 0 {
+			return headings
+		}
 	}
 	return nil
 }
 
-func getBytes(url string) ([]byte, error) {
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
+// numberRegexp matches the first number of a cell, as in "12" of "12 (1)".
+var numberRegexp = regexp.MustCompile(`[0-9]+`)
 
-	// Convert reader to bytes
-	bytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+// parseColumnTable adds the episodes of a table whose columns are found
+// from their headings to the show, as parseEpisodeTable does those of tables
+// which mark their cells. The first cell which reads as a date is the
+// release date of tables without a column of dates.
+func (s *Show) parseColumnTable(table *scrape.Tag, cols columns, seasons *seasonTracker, report *trackable.Report) {
+	dates := s.locale().dates
+	rows := table.FindAll("tr", nil)
+	for i, row := range rows {
+		if !row.Valid {
+			continue
+		}
+
+		// Rows of header cells only are the headings, and those with fewer
+		// cells than the title column summaries spanning the table.
+		cells := row.FindAllOf([]string{"th", "td"}, nil)
+		if len(row.FindAll("td", nil)) == 0 || len(cells) <= cols.title || len(cells) <= cols.number {
+			continue
+		}
+
+		where := fmt.Sprintf("season %d, row %d", seasons.season, i)
+		var (
+			episodeNum int
+			err        error
+		)
+		if cols.number >= 0 {
+			numStr := parseString(cells[cols.number].Text())
+			episodeNum, err = strconv.Atoi(numberRegexp.FindString(numStr))
+			if err != nil && seasons.season != 0 {
+				report.Skip(where, "episode number %q isn't a number", numStr)
+				continue
+			}
+		}
 
-	return bytes, nil
+		episode := &Episode{Title: parseString(cells[cols.title].Text())}
+		episode.Season, episode.Episode = seasons.episode(episodeNum)
+
+		for j, cell := range cells {
+			if j == cols.number || j == cols.title || (cols.date >= 0 && j != cols.date) {
+				continue
+			}
+			text := parseString(cell.Text())
+			if !episode.ReleaseDate.Known() && dates.HasDate(text) {
+				if episode.ReleaseDate, err = dates.ParseDate(text); err != nil {
+					report.Warn("%s: release date %q isn't a date", where, text)
+				}
+			}
+		}
+
+		s.Episodes = append(s.Episodes, episode)
+		report.Parse()
+	}
 }
 
 func parseString(str string) string {
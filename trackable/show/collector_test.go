@@ -0,0 +1,70 @@
+package show
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"tracker/internal/timeutil"
+	"tracker/scrape"
+	"tracker/trackable"
+)
+
+func TestParseInfobox(t *testing.T) {
+	body, err := ioutil.ReadFile("testdata/infobox.html")
+	if err != nil {
+		t.Fatalf("unable to read infobox: %v", err)
+	}
+	date := func(year int, month time.Month, day int) timeutil.Date {
+		return timeutil.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+	}
+
+	// Show runners are counted as creators.
+	if err := SetInfoLabel("en", "Showrunner", InfoCreators); err != nil {
+		t.Fatalf("SetInfoLabel() err = %v, want %v", err, nil)
+	}
+	t.Cleanup(func() { SetInfoLabel("en", "Showrunner", "") })
+
+	scraper, err := scrape.Create(body)
+	if err != nil {
+		t.Fatalf("unable to create scraper: %v", err)
+	}
+	s := &Show{}
+	report := &trackable.Report{}
+	if err := s.parseInfobox(scraper.FindFirst("table", attr{"class": "infobox"}), report); err != nil {
+		t.Fatalf("parseInfobox() err = %v, want %v", err, nil)
+	}
+
+	want := &Show{
+		Name:       "Tracker",
+		EpisodeURL: "http://en.wikipedia.org/wiki/List_of_Tracker_episodes",
+		Info: &Info{
+			Genres:           []string{"Drama", "Mystery"},
+			Creators:         []string{"Jane Doe", "John Roe"},
+			Starring:         []string{"John Roe", "Max Mustermann"},
+			Country:          "United States",
+			OriginalLanguage: "English",
+			Seasons:          2,
+			Episodes:         5,
+			Network:          "Example Network",
+			FirstAired:       date(2020, time.January, 5),
+			LastAired:        date(2022, time.March, 3),
+			Website:          "https://tracker.example.com",
+		},
+	}
+	if diff := deep.Equal(s, want); diff != nil {
+		t.Errorf("parseInfobox() diff = %v", diff)
+	}
+	if len(report.Warnings) > 0 {
+		t.Errorf("parseInfobox() warnings = %q, want none", report.Warnings)
+	}
+}
+
+func TestSetInfoLabel(t *testing.T) {
+	if err := SetInfoLabel("en", "Showrunner", "showrunners"); !errors.Is(err, ErrUnknownInfoField) {
+		t.Errorf("SetInfoLabel(%q) err = %v, want %v", "showrunners", err, ErrUnknownInfoField)
+	}
+}
@@ -0,0 +1,92 @@
+package show
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-test/deep"
+
+	"tracker/internal/fetch"
+	"tracker/internal/mediawiki"
+	"tracker/trackable"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the corpus")
+
+// golden is what is expected of scraping a show of the corpus.
+type golden struct {
+	Listing  *Listing          `json:"listing,omitempty"`
+	Error    string            `json:"error,omitempty"`
+	Skipped  []*trackable.Skip `json:"skipped,omitempty"`
+	Warnings []string          `json:"warnings,omitempty"`
+}
+
+// TestCorpus scrapes every show of the corpus from its recorded pages, and
+// compares the episodes and the report with the golden file of the show.
+// Shows are added by scraping them with -record into a directory of
+// testdata/corpus named after their article, prefixed by the language of its
+// Wikipedia if it isn't English, as in "de:Tracker_(Fernsehserie)", then
+// running the test with -update and checking the golden file.
+func TestCorpus(t *testing.T) {
+	dirs, err := filepath.Glob("testdata/corpus/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dir := range dirs {
+		dir := dir
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			recordings, err := fetch.NewRecordings(dir)
+			if err != nil {
+				t.Fatalf("NewRecordings() err = %v, want %v", err, nil)
+			}
+			// The rendered pages are fetched with the default client.
+			c := fetch.New(fetch.Replay(recordings))
+			old := fetch.Default()
+			fetch.SetDefault(c)
+			t.Cleanup(func() { fetch.SetDefault(old) })
+
+			lang, article := splitLang(filepath.Base(dir))
+			opts := []mediawiki.Option{mediawiki.Fetcher(c)}
+			if lang != "" {
+				opts = append(opts, mediawiki.Language(lang))
+			}
+			src := &WikipediaSource{Client: mediawiki.New(opts...), Article: article}
+			report := &trackable.Report{}
+			got := &golden{}
+			got.Listing, err = src.Listing(trackable.WithReport(context.Background(), report), &Show{Language: lang})
+			if err != nil {
+				got.Error = err.Error()
+			}
+			got.Skipped, got.Warnings = report.Skipped, report.Warnings
+
+			path := filepath.Join(dir, "golden.json")
+			if *update {
+				data, err := json.MarshalIndent(got, "", " ")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := ioutil.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatalf("no golden file, run with -update: %v", err)
+			}
+			want := &golden{}
+			if err := json.Unmarshal(data, want); err != nil {
+				t.Fatalf("invalid golden file: %v", err)
+			}
+			if diff := deep.Equal(got, want); diff != nil {
+				t.Errorf("Listing() diff = %v", diff)
+			}
+		})
+	}
+}
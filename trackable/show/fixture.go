@@ -0,0 +1,62 @@
+package show
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+)
+
+// FixtureSource reads a show from a local JSON file, for shows whose
+// episodes are kept by hand, or to stand in for other sources.
+type FixtureSource struct {
+	Path string
+}
+
+// fixture is the format of the file. Release dates may be partial, as in
+// "2025-03", or "TBA".
+type fixture struct {
+	Name       string `json:"name"`
+	EpisodeURL string `json:"episode_url"`
+	Episodes   []struct {
+		Title       string `json:"title"`
+		Season      int    `json:"season"`
+		Episode     int    `json:"episode"`
+		ReleaseDate string `json:"release_date"`
+	} `json:"episodes"`
+}
+
+func (f *FixtureSource) Name() string {
+	return "fixture"
+}
+
+func (f *FixtureSource) Listing(ctx context.Context, s *Show) (*Listing, error) {
+	data, err := ioutil.ReadFile(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("show: unable to read fixture: %w", err)
+	}
+	var fx fixture
+	if err := json.Unmarshal(data, &fx); err != nil {
+		return nil, fmt.Errorf("show: invalid fixture %s: %w", f.Path, err)
+	}
+
+	report := trackable.ReportFrom(ctx)
+	report.Fetched(f.Path)
+	report.Table()
+
+	l := &Listing{Name: fx.Name, EpisodeURL: fx.EpisodeURL}
+	for _, fe := range fx.Episodes {
+		e := &Episode{Title: fe.Title, Season: fe.Season, Episode: fe.Episode}
+		if fe.ReleaseDate != "" {
+			if e.ReleaseDate, err = timeutil.ParseDate(fe.ReleaseDate); err != nil {
+				report.Warn("S%02dE%02d: release date %q isn't a date", e.Season, e.Episode, fe.ReleaseDate)
+			}
+		}
+		l.Episodes = append(l.Episodes, e)
+		report.Parse()
+	}
+	return l, nil
+}
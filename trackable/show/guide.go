@@ -0,0 +1,94 @@
+package show
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"tracker/internal/fetch"
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+)
+
+// GuideEndpoint is the API of the TV guide shows are read from by default,
+// in the format of TVmaze.
+var GuideEndpoint = "https://api.tvmaze.com"
+
+// GuideSource reads a show from the API of a TV guide, where it is identified
+// by its ID.
+type GuideSource struct {
+	Endpoint string
+	ID       string
+
+	// Client fetches from the API, the default client if nil.
+	Client *fetch.Client
+}
+
+// guideShow is a show as returned by the API, with its episodes embedded.
+type guideShow struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Embedded struct {
+		Episodes []*guideEpisode `json:"episodes"`
+	} `json:"_embedded"`
+}
+
+type guideEpisode struct {
+	Name   string `json:"name"`
+	Season int    `json:"season"`
+	// Number is null for specials.
+	Number  *int   `json:"number"`
+	Airdate string `json:"airdate"`
+}
+
+func (g *GuideSource) Name() string {
+	return "guide"
+}
+
+// Listing reads the show and its episodes. Specials, which the guide lists
+// without a number within their season, are numbered in season 0 in the
+// order they are listed.
+func (g *GuideSource) Listing(ctx context.Context, s *Show) (*Listing, error) {
+	client := g.Client
+	if client == nil {
+		client = fetch.Default()
+	}
+
+	u := fmt.Sprintf("%s/shows/%s?embed=episodes", strings.TrimSuffix(g.Endpoint, "/"), url.PathEscape(g.ID))
+	page, err := client.Fetch(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	report := trackable.ReportFrom(ctx)
+	report.Fetched(u)
+
+	var show guideShow
+	if err := json.Unmarshal(page.Body, &show); err != nil {
+		client.Invalidate(u)
+		return nil, fmt.Errorf("show: invalid guide response: %w", err)
+	}
+
+	l := &Listing{Name: show.Name, EpisodeURL: show.URL, Unchanged: page.Unchanged}
+	report.Table()
+	specials := 0
+	for _, ge := range show.Embedded.Episodes {
+		e := &Episode{Title: ge.Name, Season: ge.Season}
+		if ge.Number != nil {
+			e.Episode = *ge.Number
+		} else {
+			specials++
+			e.Season, e.Episode = 0, specials
+		}
+		if ge.Airdate != "" {
+			if e.ReleaseDate, err = timeutil.ParseDate(ge.Airdate); err != nil {
+				report.Warn("S%02dE%02d: release date %q isn't a date", e.Season, e.Episode, ge.Airdate)
+			}
+		}
+
+		l.Episodes = append(l.Episodes, e)
+		report.Parse()
+	}
+	return l, nil
+}
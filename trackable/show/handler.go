@@ -1,10 +1,13 @@
 package show
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"tracker/internal/timeutil"
+
+	"go.uber.org/zap"
 )
 
 // Handler will take care of database loading and API prepping for Shows.
@@ -16,7 +19,7 @@ func (h *Handler) Init() {
 	shows, err := loadAllShows()
 	if err != nil {
 		h.shows = make([]*Show, 0)
-		fmt.Println(err)
+		zap.L().Error("unable to load shows", zap.Error(err))
 	} else {
 		h.shows = shows
 	}
@@ -48,6 +51,10 @@ type Schedule struct {
 	StartDate timeutil.JSONTime `json:"start_date"`
 	EndDate   timeutil.JSONTime `json:"end_date"`
 	Items     []ScheduleItem    `json:"items"`
+
+	// Unscheduled are the episodes which may be released in the range, but
+	// whose date isn't known to the day.
+	Unscheduled []*CalendarEntry `json:"unscheduled"`
 }
 
 type ScheduleItem struct {
@@ -73,6 +80,32 @@ func (h *Handler) Get(id int) (*ShowFull, error) {
 	return sf, nil
 }
 
+// History returns the changes to the episodes of the show.
+func (h *Handler) History(ctx context.Context, id int) (*History, error) {
+	if id <= 0 || len(h.shows) < id {
+		return nil, fmt.Errorf("Invalid show ID")
+	}
+	return loadHistory(ctx, id)
+}
+
+// Correct applies the correction to the episode, both stored and loaded.
+func (h *Handler) Correct(ctx context.Context, c *Correction) ([]*Change, error) {
+	if c.ShowID <= 0 || len(h.shows) < c.ShowID {
+		return nil, fmt.Errorf("Invalid show ID")
+	}
+
+	changes, err := Correct(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range h.shows[c.ShowID-1].Episodes {
+		if e.Season == c.Season && e.Episode == c.Episode {
+			c.apply(e)
+		}
+	}
+	return changes, nil
+}
+
 func (h *Handler) GetList(listType string) (*ShowList, error) {
 	filter, ok := listFilters[listType]
 	if !ok {
@@ -123,6 +156,7 @@ func (h *Handler) GetSchedule(start, end string) (*Schedule, error) {
 	}
 
 	schedule.Items = days
+	schedule.Unscheduled = h.unscheduled(startDate, endDate, time.Now())
 	return schedule, nil
 }
 
@@ -144,13 +178,28 @@ func (h *Handler) episodesInRange(dateRange []time.Time) map[time.Time][]*Calend
 	for _, show := range h.shows {
 		eps := show.EpisodesInRange(dateRange[0], dateRange[len(dateRange)-1])
 		for _, e := range eps {
+			if !e.ReleaseDate.Exact() {
+				continue
+			}
 			entry := &CalendarEntry{show.ID, show.Name, e}
-			episodeMap[e.ReleaseDate] = append(episodeMap[e.ReleaseDate], entry)
+			episodeMap[e.ReleaseDate.Time] = append(episodeMap[e.ReleaseDate.Time], entry)
 		}
 	}
 	return episodeMap
 }
 
+// unscheduled returns the episodes of every show which may be released in
+// the range, but whose date isn't known to the day.
+func (h *Handler) unscheduled(start, end, now time.Time) []*CalendarEntry {
+	entries := make([]*CalendarEntry, 0)
+	for _, show := range h.shows {
+		for _, e := range show.unscheduled(start, end, now) {
+			entries = append(entries, &CalendarEntry{show.ID, show.Name, e})
+		}
+	}
+	return entries
+}
+
 func showToSimple(show *Show) *ShowSimple {
 	s := ShowSimple{
 		ID:    show.ID,
@@ -0,0 +1,44 @@
+package show
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"tracker/internal/timeutil"
+)
+
+func TestUnscheduled(t *testing.T) {
+	now := time.Date(2025, time.February, 10, 0, 0, 0, 0, time.UTC)
+	date := func(text string) timeutil.Date {
+		d, err := timeutil.ParseDate(text)
+		if err != nil {
+			t.Fatalf("ParseDate(%q) err = %v, want %v", text, err, nil)
+		}
+		return d
+	}
+
+	exact := &Episode{Title: "Exact", ReleaseDate: date("March 6, 2025")}
+	month := &Episode{Title: "Month", ReleaseDate: date("March 2025")}
+	year := &Episode{Title: "Year", ReleaseDate: date("2026")}
+	tba := &Episode{Title: "TBA", ReleaseDate: date("TBA")}
+	finished := &Episode{Title: "Never", ReleaseDate: date("TBA")}
+	h := &Handler{shows: []*Show{
+		{ID: 1, Name: "Airing", Episodes: []*Episode{exact, month, year, tba}},
+		{ID: 2, Name: "Cancelled", Finished: true, Episodes: []*Episode{finished}},
+	}}
+
+	start := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)
+	want := []*CalendarEntry{{1, "Airing", month}, {1, "Airing", tba}}
+	if diff := deep.Equal(h.unscheduled(start, end, now), want); diff != nil {
+		t.Errorf("unscheduled() diff = %v", diff)
+	}
+
+	// Episodes without a date aren't listed in ranges which are over.
+	start, end = start.AddDate(-1, 0, 0), end.AddDate(-1, 0, 0)
+	if diff := deep.Equal(h.unscheduled(start, end, now), []*CalendarEntry{}); diff != nil {
+		t.Errorf("unscheduled() diff = %v", diff)
+	}
+}
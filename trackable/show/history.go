@@ -0,0 +1,363 @@
+package show
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"tracker/database"
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+)
+
+// Source is who made a change to a show.
+type Source string
+
+const (
+	SourceScraper Source = "scraper"
+	SourceAdmin   Source = "admin"
+	SourceUser    Source = "user"
+)
+
+// Valid returns true if the source is known.
+func (s Source) Valid() bool {
+	return s == SourceScraper || s == SourceAdmin || s == SourceUser
+}
+
+// Correction returns true if the source corrects the scraper.
+func (s Source) Correction() bool {
+	return s == SourceAdmin || s == SourceUser
+}
+
+// Fields of an episode whose changes are recorded.
+const (
+	FieldTitle       = "title"
+	FieldReleaseDate = "release_date"
+)
+
+// FieldName is the name of the show, whose changes are recorded along with
+// those of the stored fields of its info, named by InfoField.
+const FieldName = "name"
+
+const (
+	ErrInvalidSource  = trackable.Error("show: invalid source")
+	ErrUnknownEpisode = trackable.Error("show: unknown episode")
+)
+
+// Change is a change to a single field of an episode, or of the show whose
+// season and episode are then 0. Fields of episodes which were added change
+// from an empty value, and those of episodes which were removed to one.
+type Change struct {
+	ShowID  int       `json:"show_id"`
+	Season  int       `json:"season"`
+	Episode int       `json:"episode"`
+	Field   string    `json:"field"`
+	Old     string    `json:"old"`
+	New     string    `json:"new"`
+	Source  Source    `json:"source"`
+	Changed time.Time `json:"changed"`
+}
+
+func (c *Change) String() string {
+	if showField(c.Field) {
+		return fmt.Sprintf("%s %q -> %q (%s)", c.Field, c.Old, c.New, c.Source)
+	}
+	return fmt.Sprintf("S%02dE%02d %s %q -> %q (%s)", c.Season, c.Episode, c.Field,
+		c.Old, c.New, c.Source)
+}
+
+// EpisodeHistory is every change to a single episode, oldest first.
+type EpisodeHistory struct {
+	Season  int       `json:"season"`
+	Episode int       `json:"episode"`
+	Changes []*Change `json:"changes"`
+
+	// Postponed counts the changes of the release date to a later date.
+	Postponed int `json:"postponed"`
+}
+
+// History of a show and of its episodes which changed, ordered by episode.
+type History struct {
+	ShowID int `json:"show_id"`
+	// Show is every change to the show itself, oldest first.
+	Show     []*Change         `json:"show"`
+	Episodes []*EpisodeHistory `json:"episodes"`
+}
+
+// showField returns true if the field is one of the show, rather than of an
+// episode.
+func showField(field string) bool {
+	return field == FieldName || infoFields[InfoField(field)]
+}
+
+// showChanges returns the changes to the name and the info of the stored
+// show, of which the show is a newer version. Shows without a name or an
+// info don't change them.
+func (s *Show) showChanges(stored *Show, source Source, now time.Time) []*Change {
+	var list []*Change
+	add := func(field, old, new string) {
+		if old == new {
+			return
+		}
+		list = append(list, &Change{
+			ShowID:  s.ID,
+			Field:   field,
+			Old:     old,
+			New:     new,
+			Source:  source,
+			Changed: now,
+		})
+	}
+
+	if s.Name != "" {
+		add(FieldName, stored.Name, s.Name)
+	}
+	if s.Info != nil {
+		old := stored.Info
+		if old == nil {
+			old = &Info{}
+		}
+		for _, field := range storedInfoFields {
+			add(string(field), old.value(field), s.Info.value(field))
+		}
+	}
+	return list
+}
+
+// changes returns the field level changes of the episodes of the diff.
+func changes(diff *trackable.Diff, source Source, now time.Time) []*Change {
+	var list []*Change
+	add := func(r *trackable.Release, field, old, new string) {
+		if old == new {
+			return
+		}
+		list = append(list, &Change{
+			ShowID:  r.ID,
+			Season:  r.Season,
+			Episode: r.Number,
+			Field:   field,
+			Old:     old,
+			New:     new,
+			Source:  source,
+			Changed: now,
+		})
+	}
+
+	for _, r := range diff.Added {
+		add(r, FieldTitle, "", r.Title)
+		add(r, FieldReleaseDate, "", r.PartialDate().String())
+	}
+	for _, c := range diff.Changed {
+		add(c.New, FieldTitle, c.Old.Title, c.New.Title)
+		add(c.New, FieldReleaseDate, c.Old.PartialDate().String(), c.New.PartialDate().String())
+	}
+	for _, r := range diff.Removed {
+		add(r, FieldTitle, r.Title, "")
+		add(r, FieldReleaseDate, r.PartialDate().String(), "")
+	}
+	return list
+}
+
+// recordChanges adds the changes to the history of their shows.
+func recordChanges(ctx context.Context, tx *sql.Tx, changes []*Change) error {
+	for _, c := range changes {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO show_history(show_id, season, episode, field,
+			old_value, new_value, source, changed) VALUES(?, ?, ?, ?, ?, ?, ?, ?)`,
+			c.ShowID, c.Season, c.Episode, c.Field, c.Old, c.New, c.Source, c.Changed); err != nil {
+			return fmt.Errorf("unable to record change %v: %w", c, err)
+		}
+	}
+	return nil
+}
+
+// loadHistory returns the history of the show.
+func loadHistory(ctx context.Context, showID int) (*History, error) {
+	db, err := database.Open("tracker")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, `SELECT show_id, season, episode, field, old_value, new_value,
+		source, changed FROM show_history WHERE show_id=? ORDER BY changed, id`, showID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query history of show %d: %w", showID, err)
+	}
+	defer rows.Close()
+
+	changes := make([]*Change, 0)
+	for rows.Next() {
+		c := &Change{}
+		if err := rows.Scan(&c.ShowID, &c.Season, &c.Episode, &c.Field, &c.Old, &c.New,
+			&c.Source, &c.Changed); err != nil {
+			return nil, fmt.Errorf("unable to scan change: %w", err)
+		}
+		changes = append(changes, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return newHistory(showID, changes), nil
+}
+
+// newHistory groups the changes, ordered oldest first, by episode. Changes
+// to the show itself are kept apart.
+func newHistory(showID int, changes []*Change) *History {
+	type key struct{ season, episode int }
+
+	h := &History{ShowID: showID, Show: make([]*Change, 0), Episodes: make([]*EpisodeHistory, 0)}
+	episodes := map[key]*EpisodeHistory{}
+	for _, c := range changes {
+		if showField(c.Field) {
+			h.Show = append(h.Show, c)
+			continue
+		}
+
+		k := key{c.Season, c.Episode}
+		e, ok := episodes[k]
+		if !ok {
+			e = &EpisodeHistory{Season: c.Season, Episode: c.Episode}
+			episodes[k] = e
+			h.Episodes = append(h.Episodes, e)
+		}
+		e.Changes = append(e.Changes, c)
+
+		if c.Field == FieldReleaseDate && postponed(c.Old, c.New) {
+			e.Postponed++
+		}
+	}
+
+	sort.Slice(h.Episodes, func(i, j int) bool {
+		a, b := h.Episodes[i], h.Episodes[j]
+		if a.Season != b.Season {
+			return a.Season < b.Season
+		}
+		return a.Episode < b.Episode
+	})
+	return h
+}
+
+// postponed returns true if the new release date is after the whole period
+// of the old one. Dates which only become more precise aren't postponed.
+func postponed(old, new string) bool {
+	o, err := timeutil.ParseDate(old)
+	if err != nil || !o.Known() {
+		return false
+	}
+	n, err := timeutil.ParseDate(new)
+	if err != nil || !n.Known() {
+		return false
+	}
+	return !n.Time.Before(o.End())
+}
+
+// Correction of an episode by an admin or a user. Fields which are empty, or
+// dates which are unknown, are left as they are.
+type Correction struct {
+	ShowID      int
+	Season      int
+	Episode     int
+	Title       string
+	ReleaseDate timeutil.Date
+	Source      Source
+}
+
+// apply the correction to the episode. The corrected fields are supplied by
+// whoever corrected them. What the scraper read of the fields is kept, so
+// that the correction holds until the scraper reads them differently.
+func (c *Correction) apply(e *Episode) {
+	sources, scraped := map[string]string{}, map[string]string{}
+	for field, source := range e.Sources {
+		sources[field] = source
+	}
+	for field, value := range e.scraped {
+		scraped[field] = value
+	}
+	correct := func(field, value string) {
+		if !Source(sources[field]).Correction() {
+			scraped[field] = value
+		}
+		sources[field] = string(c.Source)
+	}
+	if c.Title != "" {
+		correct(FieldTitle, e.Title)
+		e.Title = c.Title
+	}
+	if c.ReleaseDate.Known() {
+		correct(FieldReleaseDate, e.ReleaseDate.String())
+		e.ReleaseDate = c.ReleaseDate
+	}
+	e.Sources, e.scraped = sources, scraped
+}
+
+// Correct applies the correction to the stored episode, recording the
+// changes in the history of the show.
+func Correct(ctx context.Context, c *Correction) ([]*Change, error) {
+	if !c.Source.Valid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSource, c.Source)
+	}
+
+	db, err := database.Open("tracker")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	old := &Episode{}
+	var (
+		released                  sql.NullTime
+		titleSource, dateSource   string
+		scrapedTitle, scrapedDate string
+	)
+	err = tx.QueryRowContext(ctx, `SELECT title, season, episode, release_date, release_precision,
+		release_tentative, title_source, date_source, scraped_title, scraped_date FROM episodes
+		WHERE show_id=? AND season=? AND episode=? FOR UPDATE`, c.ShowID, c.Season, c.Episode).
+		Scan(&old.Title, &old.Season, &old.Episode, &released, &old.ReleaseDate.Precision,
+			&old.ReleaseDate.Tentative, &titleSource, &dateSource, &scrapedTitle, &scrapedDate)
+	old.ReleaseDate.Time = released.Time
+	old.Sources = episodeSources(titleSource, dateSource)
+	old.scraped = episodeScraped(old.Sources, scrapedTitle, scrapedDate)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %d S%02dE%02d", ErrUnknownEpisode, c.ShowID, c.Season, c.Episode)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to query episode: %w", err)
+	}
+
+	corrected := *old
+	c.apply(&corrected)
+
+	s := &Show{ID: c.ShowID}
+	diff := &trackable.Diff{Ref: s.Ref(), Changed: []*trackable.Change{{
+		Old: s.release(old),
+		New: s.release(&corrected),
+	}}}
+	list := changes(diff, c.Source, time.Now().UTC())
+	if len(list) == 0 {
+		return list, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE episodes SET title=?, release_date=?, release_precision=?,
+		release_tentative=?, title_source=?, date_source=?, scraped_title=?, scraped_date=?
+		WHERE show_id=? AND season=? AND episode=?`,
+		corrected.Title, nullTime(corrected.ReleaseDate.Time), corrected.ReleaseDate.Precision,
+		corrected.ReleaseDate.Tentative, corrected.Sources[FieldTitle], corrected.Sources[FieldReleaseDate],
+		corrected.scraped[FieldTitle], corrected.scraped[FieldReleaseDate], c.ShowID, c.Season, c.Episode); err != nil {
+		return nil, fmt.Errorf("unable to update episode: %w", err)
+	}
+	if err := recordChanges(ctx, tx, list); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("unable to commit correction: %w", err)
+	}
+	return list, nil
+}
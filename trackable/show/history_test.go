@@ -0,0 +1,123 @@
+package show
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+)
+
+func TestChanges(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	date := func(day int) time.Time {
+		return time.Date(2021, time.March, day, 0, 0, 0, 0, time.UTC)
+	}
+	ref := trackable.Ref{Kind: Kind, ID: 1}
+
+	diff := &trackable.Diff{
+		Ref:   ref,
+		Added: []*trackable.Release{{Ref: ref, Title: "New", Season: 3, Number: 8}},
+		Changed: []*trackable.Change{{
+			Old: &trackable.Release{Ref: ref, Title: "TBA", Date: date(8), Season: 3, Number: 5},
+			New: &trackable.Release{Ref: ref, Title: "TBA", Date: date(15), Season: 3, Number: 5},
+		}, {
+			Old: &trackable.Release{Ref: ref, Title: "TBA", Date: date(1), Season: 3, Number: 6,
+				Precision: timeutil.PrecisionMonth},
+			New: &trackable.Release{Ref: ref, Title: "TBA", Date: date(1), Season: 3, Number: 6},
+		}},
+		Removed: []*trackable.Release{{Ref: ref, Title: "Cancelled", Season: 3, Number: 7}},
+	}
+
+	want := []*Change{
+		{ShowID: 1, Season: 3, Episode: 8, Field: FieldTitle, Old: "", New: "New",
+			Source: SourceScraper, Changed: now},
+		{ShowID: 1, Season: 3, Episode: 5, Field: FieldReleaseDate, Old: "2021-03-08", New: "2021-03-15",
+			Source: SourceScraper, Changed: now},
+		{ShowID: 1, Season: 3, Episode: 6, Field: FieldReleaseDate, Old: "2021-03", New: "2021-03-01",
+			Source: SourceScraper, Changed: now},
+		{ShowID: 1, Season: 3, Episode: 7, Field: FieldTitle, Old: "Cancelled", New: "",
+			Source: SourceScraper, Changed: now},
+	}
+	if diff := deep.Equal(changes(diff, SourceScraper, now), want); diff != nil {
+		t.Errorf("changes() diff = %v", diff)
+	}
+}
+
+func TestShowChanges(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	stored := &Show{ID: 1, Name: "Tracker", Info: &Info{
+		Genres: []string{"Drama"}, Network: "HBO", Seasons: 2,
+	}}
+
+	s := &Show{ID: 1, Name: "The Tracker", Info: &Info{
+		Genres:     []string{"Drama", "Comedy"},
+		Network:    "HBO",
+		Seasons:    3,
+		FirstAired: timeutil.Date{Time: time.Date(2020, time.January, 5, 0, 0, 0, 0, time.UTC)},
+	}}
+	want := []*Change{
+		{ShowID: 1, Field: FieldName, Old: "Tracker", New: "The Tracker", Source: SourceScraper, Changed: now},
+		{ShowID: 1, Field: string(InfoGenres), Old: "Drama", New: "Drama, Comedy", Source: SourceScraper, Changed: now},
+		{ShowID: 1, Field: string(InfoSeasons), Old: "2", New: "3", Source: SourceScraper, Changed: now},
+		{ShowID: 1, Field: string(InfoFirstAired), Old: "", New: "2020-01-05", Source: SourceScraper, Changed: now},
+	}
+	if diff := deep.Equal(s.showChanges(stored, SourceScraper, now), want); diff != nil {
+		t.Errorf("showChanges() diff = %v", diff)
+	}
+
+	// Shows which didn't read their name or info don't change them.
+	if got := (&Show{ID: 1}).showChanges(stored, SourceScraper, now); len(got) != 0 {
+		t.Errorf("showChanges() = %v, want none", got)
+	}
+}
+
+func TestCorrectionApply(t *testing.T) {
+	date := func(day int) timeutil.Date {
+		return timeutil.Date{Time: time.Date(2021, time.March, day, 0, 0, 0, 0, time.UTC)}
+	}
+	e := &Episode{Title: "TBA", Season: 1, Episode: 2, ReleaseDate: date(8),
+		Sources: map[string]string{FieldTitle: "wikipedia", FieldReleaseDate: "wikipedia"}}
+
+	(&Correction{Title: "Second", Source: SourceUser}).apply(e)
+	(&Correction{Title: "The Second", ReleaseDate: date(9), Source: SourceAdmin}).apply(e)
+
+	want := &Episode{Title: "The Second", Season: 1, Episode: 2, ReleaseDate: date(9),
+		Sources: map[string]string{FieldTitle: "admin", FieldReleaseDate: "admin"}}
+	if diff := deep.Equal(e, want); diff != nil {
+		t.Errorf("apply() diff = %v", diff)
+	}
+	// What the scraper read is kept from the first correction of each field.
+	wantScraped := map[string]string{FieldTitle: "TBA", FieldReleaseDate: "2021-03-08"}
+	if diff := deep.Equal(e.scraped, wantScraped); diff != nil {
+		t.Errorf("apply() scraped diff = %v", diff)
+	}
+}
+
+func TestNewHistory(t *testing.T) {
+	change := func(season, episode int, field, old, new string, source Source) *Change {
+		return &Change{ShowID: 1, Season: season, Episode: episode, Field: field, Old: old, New: new, Source: source}
+	}
+	list := []*Change{
+		change(3, 5, FieldReleaseDate, "2021-03-08", "2021-03-15", SourceScraper),
+		change(1, 2, FieldTitle, "TBA", "Second", SourceScraper),
+		change(3, 5, FieldReleaseDate, "2021-03-15", "2021-03-29", SourceScraper),
+		change(3, 5, FieldReleaseDate, "2021-03-29", "2021-03-22", SourceAdmin),
+		// Dates which become more precise aren't postponed.
+		change(2, 1, FieldReleaseDate, "2021-03", "2021-03-20", SourceScraper),
+		change(2, 1, FieldReleaseDate, "2021-03-20", "2021-Q2?", SourceScraper),
+		change(0, 0, FieldName, "Tracker", "The Tracker", SourceScraper),
+		change(0, 0, string(InfoNetwork), "", "HBO", SourceScraper),
+	}
+
+	want := &History{ShowID: 1, Show: list[6:8], Episodes: []*EpisodeHistory{
+		{Season: 1, Episode: 2, Changes: list[1:2]},
+		{Season: 2, Episode: 1, Changes: list[4:6], Postponed: 1},
+		{Season: 3, Episode: 5, Changes: []*Change{list[0], list[2], list[3]}, Postponed: 2},
+	}}
+	if diff := deep.Equal(newHistory(1, list), want); diff != nil {
+		t.Errorf("newHistory() diff = %v", diff)
+	}
+}
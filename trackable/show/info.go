@@ -0,0 +1,425 @@
+package show
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"tracker/internal/mediawiki"
+	"tracker/internal/timeutil"
+	"tracker/scrape"
+	"tracker/trackable"
+)
+
+// Info is what the infobox of the article of a show says of it.
+type Info struct {
+	Genres           []string      `json:"genres,omitempty"`
+	Creators         []string      `json:"creators,omitempty"`
+	Starring         []string      `json:"starring,omitempty"`
+	Country          string        `json:"country,omitempty"`
+	OriginalLanguage string        `json:"original_language,omitempty"`
+	Seasons          int           `json:"seasons,omitempty"`
+	Episodes         int           `json:"episodes,omitempty"`
+	Network          string        `json:"network,omitempty"`
+	FirstAired       timeutil.Date `json:"first_aired"`
+	// LastAired is unknown while the show is still airing.
+	LastAired timeutil.Date `json:"last_aired"`
+	Website   string        `json:"website,omitempty"`
+}
+
+// InfoField is a field of the info of shows, which labels of infoboxes are
+// mapped to.
+type InfoField string
+
+const (
+	InfoGenres           InfoField = "genres"
+	InfoCreators         InfoField = "creators"
+	InfoStarring         InfoField = "starring"
+	InfoCountry          InfoField = "country"
+	InfoOriginalLanguage InfoField = "original_language"
+	InfoSeasons          InfoField = "seasons"
+	InfoEpisodes         InfoField = "episodes"
+	InfoNetwork          InfoField = "network"
+	// InfoRelease is the range of the release of the show, as in
+	// "January 5, 2020 – present", read as its first and last air dates.
+	InfoRelease    InfoField = "release"
+	InfoFirstAired InfoField = "first_aired"
+	InfoLastAired  InfoField = "last_aired"
+	InfoWebsite    InfoField = "website"
+)
+
+var infoFields = map[InfoField]bool{
+	InfoGenres: true, InfoCreators: true, InfoStarring: true, InfoCountry: true,
+	InfoOriginalLanguage: true, InfoSeasons: true, InfoEpisodes: true, InfoNetwork: true,
+	InfoRelease: true, InfoFirstAired: true, InfoLastAired: true, InfoWebsite: true,
+}
+
+const ErrUnknownInfoField = trackable.Error("show: unknown info field")
+
+// infoLabels map the labels of the rows of infoboxes, and the parameters of
+// the templates of infoboxes, to the fields of the info of shows, by
+// language. Labels are lowercase.
+var infoLabels = map[string]map[string]InfoField{
+	"en": {
+		"genre": InfoGenres, "genres": InfoGenres,
+		"created by": InfoCreators, "creator": InfoCreators,
+		"starring":          InfoStarring,
+		"country of origin": InfoCountry, "country": InfoCountry,
+		"original language": InfoOriginalLanguage, "original languages": InfoOriginalLanguage,
+		"language":       InfoOriginalLanguage,
+		"no. of seasons": InfoSeasons, "no. of series": InfoSeasons,
+		"num_seasons": InfoSeasons, "num_series": InfoSeasons,
+		"no. of episodes": InfoEpisodes, "num_episodes": InfoEpisodes,
+		"original network": InfoNetwork, "network": InfoNetwork,
+		"original release": InfoRelease, "release": InfoRelease, "released": InfoRelease,
+		"first_aired": InfoFirstAired, "last_aired": InfoLastAired,
+		"website": InfoWebsite, "official website": InfoWebsite,
+	},
+	"de": {
+		"genre": InfoGenres, "idee": InfoCreators, "besetzung": InfoStarring,
+		"produktionsland": InfoCountry, "originalsprache": InfoOriginalLanguage,
+		"staffeln": InfoSeasons, "episoden": InfoEpisodes,
+		"erstausstrahlung": InfoFirstAired, "website": InfoWebsite,
+	},
+	"fr": {
+		"genre": InfoGenres, "création": InfoCreators, "acteurs principaux": InfoStarring,
+		"pays d'origine": InfoCountry, "langue": InfoOriginalLanguage,
+		"nb. de saisons": InfoSeasons, "nb. d'épisodes": InfoEpisodes,
+		"chaîne d'origine": InfoNetwork, "diffusion": InfoRelease, "site web": InfoWebsite,
+	},
+	"es": {
+		"género": InfoGenres, "creado por": InfoCreators, "reparto": InfoStarring,
+		"país de origen": InfoCountry, "idioma(s)": InfoOriginalLanguage,
+		"idioma original":   InfoOriginalLanguage,
+		"n.º de temporadas": InfoSeasons, "n.º de episodios": InfoEpisodes,
+		"cadena original": InfoNetwork, "primera emisión": InfoFirstAired,
+		"última emisión": InfoLastAired, "sitio web": InfoWebsite,
+	},
+	"ja": {
+		"ジャンル": InfoGenres, "企画": InfoCreators, "出演者": InfoStarring,
+		"製作国": InfoCountry, "言語": InfoOriginalLanguage,
+		"シーズン数": InfoSeasons, "話数": InfoEpisodes,
+		"放送局": InfoNetwork, "放送チャンネル": InfoNetwork, "放送期間": InfoRelease,
+		"公式ウェブサイト": InfoWebsite,
+	},
+}
+
+// SetInfoLabel maps the label of rows of infoboxes on the Wikipedia of the
+// language, or a parameter of their template, to the field. Labels mapped to
+// the empty field are ignored. It isn't safe to call while shows are
+// scraped.
+func SetInfoLabel(lang, label string, field InfoField) error {
+	if field != "" && !infoFields[field] {
+		return fmt.Errorf("%w: %q", ErrUnknownInfoField, field)
+	}
+	labels, ok := infoLabels[lang]
+	if !ok {
+		labels = map[string]InfoField{}
+		infoLabels[lang] = labels
+	}
+	label = normalLabel(label)
+	if field == "" {
+		delete(labels, label)
+	} else {
+		labels[label] = field
+	}
+	return nil
+}
+
+// LoadInfoLabels maps the labels of infoboxes read as JSON, by language then
+// label, as in {"en": {"Showrunner": "creators"}}, adding to the labels
+// mapped already.
+func LoadInfoLabels(r io.Reader) error {
+	var langs map[string]map[string]InfoField
+	if err := json.NewDecoder(r).Decode(&langs); err != nil {
+		return fmt.Errorf("unable to read infobox labels: %w", err)
+	}
+	for lang, labels := range langs {
+		for label, field := range labels {
+			if err := SetInfoLabel(lang, label, field); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// normalLabel is the label in lowercase, without the colon some infoboxes
+// end their labels with.
+func normalLabel(label string) string {
+	label = strings.Join(strings.Fields(strings.ToLower(label)), " ")
+	return strings.TrimSpace(strings.TrimRight(label, ":"))
+}
+
+// infoField returns the field the label maps to on the Wikipedia of the show,
+// false if it isn't mapped.
+func (s *Show) infoField(label string) (InfoField, bool) {
+	field, ok := infoLabels[s.lang()][normalLabel(label)]
+	return field, ok
+}
+
+var (
+	// referenceRegexp matches references and notes left in rendered pages,
+	// as in "[1]" or "[a]".
+	referenceRegexp = regexp.MustCompile(`\[(?:[0-9]+|[a-z]|citation needed|note [0-9]+)\]`)
+	// rangeRegexp splits ranges of dates.
+	rangeRegexp = regexp.MustCompile(`\s*[–—〜～]\s*|\s+-\s+`)
+	// presentRegexp matches the end of the range of a show still airing.
+	presentRegexp = regexp.MustCompile(`(?i)^(?:present|heute|aujourd'hui|presente|actualidad|現在)$`)
+	// urlRegexp matches URLs in wikitext.
+	urlRegexp = regexp.MustCompile(`https?://[^\s\]|}<]+`)
+)
+
+// storedInfoFields are the fields of the info which are stored, in the order
+// their changes are recorded.
+var storedInfoFields = []InfoField{InfoGenres, InfoCreators, InfoStarring, InfoCountry,
+	InfoOriginalLanguage, InfoSeasons, InfoEpisodes, InfoNetwork, InfoFirstAired, InfoLastAired, InfoWebsite}
+
+// value returns the stored field as text, as recorded in the history of the
+// show. Lists are joined with commas, and unknown numbers and dates are empty.
+func (i *Info) value(field InfoField) string {
+	if l := i.list(field); l != nil {
+		return strings.Join(*l, ", ")
+	}
+	number := func(n int) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.Itoa(n)
+	}
+	switch field {
+	case InfoCountry:
+		return i.Country
+	case InfoOriginalLanguage:
+		return i.OriginalLanguage
+	case InfoSeasons:
+		return number(i.Seasons)
+	case InfoEpisodes:
+		return number(i.Episodes)
+	case InfoNetwork:
+		return i.Network
+	case InfoFirstAired:
+		return i.FirstAired.String()
+	case InfoLastAired:
+		return i.LastAired.String()
+	case InfoWebsite:
+		return i.Website
+	}
+	return ""
+}
+
+// list returns the list of the field, nil if it isn't one.
+func (i *Info) list(field InfoField) *[]string {
+	switch field {
+	case InfoGenres:
+		return &i.Genres
+	case InfoCreators:
+		return &i.Creators
+	case InfoStarring:
+		return &i.Starring
+	}
+	return nil
+}
+
+// set the field from the values of an infobox. Lists given as a single value
+// are split at commas.
+func (i *Info) set(field InfoField, values []string, dates *timeutil.Locale) error {
+	if len(values) == 0 {
+		return nil
+	}
+	text := strings.Join(values, " ")
+	date := func(text string) (timeutil.Date, error) {
+		if presentRegexp.MatchString(text) {
+			return timeutil.Date{}, nil
+		}
+		return dates.ParseDate(text)
+	}
+
+	var err error
+	switch field {
+	case InfoGenres, InfoCreators, InfoStarring:
+		if len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+		list := i.list(field)
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v != "" {
+				*list = append(*list, v)
+			}
+		}
+	case InfoCountry, InfoOriginalLanguage, InfoNetwork:
+		value := strings.Join(values, ", ")
+		switch field {
+		case InfoCountry:
+			i.Country = value
+		case InfoOriginalLanguage:
+			i.OriginalLanguage = value
+		default:
+			i.Network = value
+		}
+	case InfoSeasons, InfoEpisodes:
+		var n int
+		if n, err = strconv.Atoi(numberRegexp.FindString(text)); err != nil {
+			return fmt.Errorf("%s %q isn't a number", field, text)
+		}
+		if field == InfoSeasons {
+			i.Seasons = n
+		} else {
+			i.Episodes = n
+		}
+	case InfoRelease:
+		parts := rangeRegexp.Split(strings.TrimSpace(text), 2)
+		if i.FirstAired, err = date(parts[0]); err == nil && len(parts) > 1 {
+			i.LastAired, err = date(parts[1])
+		}
+	case InfoFirstAired:
+		i.FirstAired, err = date(text)
+	case InfoLastAired:
+		i.LastAired, err = date(text)
+	case InfoWebsite:
+		i.Website = values[0]
+	}
+	if err != nil {
+		return fmt.Errorf("%s %q isn't a date", field, text)
+	}
+	return nil
+}
+
+// parseInfoRows reads the info of the show from the rows of a rendered
+// infobox, those whose label is mapped to a field. Values which can't be
+// read are reported as warnings.
+func (s *Show) parseInfoRows(rows []*scrape.Tag, report *trackable.Report) *Info {
+	info := &Info{}
+	for _, row := range rows {
+		label, data := row.FindFirst("th", nil), row.FindFirst("td", nil)
+		if !label.Valid || !data.Valid {
+			continue
+		}
+		field, ok := s.infoField(parseString(label.Text()))
+		if !ok {
+			continue
+		}
+
+		var values []string
+		if field == InfoWebsite {
+			for _, a := range data.FindAll("a", nil) {
+				if href, ok := a.GetAttr("href"); ok && strings.HasPrefix(href, "http") {
+					values = append(values, href)
+					break
+				}
+			}
+		} else {
+			for _, line := range data.Lines() {
+				if line = strings.TrimSpace(referenceRegexp.ReplaceAllString(line, "")); line != "" {
+					values = append(values, line)
+				}
+			}
+		}
+		if err := info.set(field, values, s.locale().dates); err != nil {
+			report.Warn("infobox: %v", err)
+		}
+	}
+	return info
+}
+
+// parseInfoTemplate reads the info of the show from the parameters of the
+// template of its infobox, as parseInfoRows does from rendered infoboxes.
+func (s *Show) parseInfoTemplate(t *mediawiki.Template, report *trackable.Report) *Info {
+	info := &Info{}
+	for param, text := range t.Params {
+		field, ok := s.infoField(param)
+		if !ok {
+			continue
+		}
+
+		var values []string
+		switch {
+		case field == InfoWebsite:
+			if u := urlRegexp.FindString(text); u != "" {
+				values = []string{u}
+			} else if urls := mediawiki.Templates(text, "URL"); len(urls) > 0 && urls[0].Param("1") != "" {
+				values = []string{"https://" + strings.TrimPrefix(urls[0].Param("1"), "//")}
+			}
+		case field == InfoFirstAired || field == InfoLastAired:
+			if d, ok := mediawiki.Date(text); ok {
+				values = []string{d.String()}
+			} else if p := mediawiki.Plain(text); p != "" {
+				values = []string{p}
+			}
+		default:
+			values = mediawiki.List(text)
+		}
+		if err := info.set(field, values, s.locale().dates); err != nil {
+			report.Warn("infobox: %v", err)
+		}
+	}
+	return info
+}
+
+// writeInfo replaces the stored info of the show, if it has any.
+func (s *Show) writeInfo(ctx context.Context, tx *sql.Tx) error {
+	if s.Info == nil {
+		return nil
+	}
+	i := s.Info
+	if _, err := tx.ExecContext(ctx, `UPDATE shows SET country=?, original_language=?, network=?, seasons=?,
+		episode_count=?, first_aired=?, last_aired=?, website=? WHERE id=?`,
+		i.Country, i.OriginalLanguage, i.Network, i.Seasons, i.Episodes, i.FirstAired.String(),
+		i.LastAired.String(), i.Website, s.ID); err != nil {
+		return fmt.Errorf("unable to update info of show %d: %w", s.ID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM show_info WHERE show_id=?`, s.ID); err != nil {
+		return fmt.Errorf("unable to delete info of show %d: %w", s.ID, err)
+	}
+	for _, field := range []InfoField{InfoGenres, InfoCreators, InfoStarring} {
+		for pos, value := range *i.list(field) {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO show_info(show_id, field, position, value)
+				VALUES(?, ?, ?, ?)`, s.ID, field, pos, value); err != nil {
+				return fmt.Errorf("unable to insert %s of show %d: %w", field, s.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// loadInfoLists fills the lists of the info of the shows, by ID. Only the
+// info of a single show is queried.
+func loadInfoLists(ctx context.Context, q queryer, shows map[int]*Show) error {
+	query := "SELECT show_id, field, value FROM show_info"
+	var args []interface{}
+	if len(shows) == 1 {
+		for id := range shows {
+			query += " WHERE show_id=?"
+			args = append(args, id)
+		}
+	}
+	rows, err := q.QueryContext(ctx, query+" ORDER BY show_id, field, position", args...)
+	if err != nil {
+		return fmt.Errorf("unable to query show info: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id           int
+			field, value string
+		)
+		if err := rows.Scan(&id, &field, &value); err != nil {
+			return fmt.Errorf("unable to scan show info: %w", err)
+		}
+		s, ok := shows[id]
+		if !ok || s.Info == nil {
+			continue
+		}
+		if list := s.Info.list(InfoField(field)); list != nil {
+			*list = append(*list, value)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,192 @@
+package show
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"tracker/internal/mediawiki"
+	"tracker/internal/timeutil"
+)
+
+// locale is how the Wikipedia of a language lists the episodes of shows.
+type locale struct {
+	dates *timeutil.Locale
+
+	// tableClass is the class of the tables listing episodes.
+	tableClass string
+
+	// listLinks are found in the titles of the lists of episodes articles
+	// link to.
+	listLinks []string
+
+	// columns name the columns of the tables of episodes, for Wikipedias
+	// whose tables don't mark the cell of the title. It is nil for those
+	// which do.
+	columns *columnNames
+}
+
+// columnNames are the words of the headings of the columns of tables of
+// episodes, lowercase.
+type columnNames struct {
+	// number is the column of the number of the episode, and season that
+	// of its number within the season if the table has both.
+	number, season []string
+	title, date    []string
+}
+
+var locales = map[string]*locale{
+	"en": {
+		dates:      timeutil.English,
+		tableClass: "wikiepisodetable",
+		listLinks:  []string{"List of"},
+	},
+	"de": {
+		dates:      timeutil.German,
+		tableClass: "wikitable",
+		listLinks:  []string{"Liste der", "Episodenliste"},
+		columns: &columnNames{
+			number: []string{"nr", "#", "folge"},
+			season: []string{"staffel"},
+			title:  []string{"titel"},
+			date:   []string{"erstausstrahlung", "ausstrahlung"},
+		},
+	},
+	"fr": {
+		dates:      timeutil.French,
+		tableClass: "wikitable",
+		listLinks:  []string{"Liste des épisodes"},
+		columns: &columnNames{
+			number: []string{"n°", "nº", "no", "#", "épisode"},
+			season: []string{"saison"},
+			title:  []string{"titre"},
+			date:   []string{"diffusion"},
+		},
+	},
+	"es": {
+		dates:      timeutil.Spanish,
+		tableClass: "wikitable",
+		listLinks:  []string{"Episodios de", "Anexo:Episodios"},
+		columns: &columnNames{
+			number: []string{"nº", "no", "#", "episodio"},
+			season: []string{"temporada"},
+			title:  []string{"título"},
+			date:   []string{"emisión", "estreno", "fecha"},
+		},
+	},
+	"ja": {
+		dates:      timeutil.Japanese,
+		tableClass: "wikitable",
+		listLinks:  []string{"エピソード一覧", "各話"},
+		columns: &columnNames{
+			number: []string{"話数", "話", "#", "no"},
+			title:  []string{"サブタイトル", "タイトル"},
+			date:   []string{"放送日", "配信日"},
+		},
+	},
+}
+
+// lang returns the language of the Wikipedia the show is read from.
+func (s *Show) lang() string {
+	if s.Language == "" {
+		return "en"
+	}
+	return s.Language
+}
+
+// locale returns how the Wikipedia of the show lists episodes, as the
+// English one does if its language isn't known.
+func (s *Show) locale() *locale {
+	if l, ok := locales[s.lang()]; ok {
+		return l
+	}
+	return locales["en"]
+}
+
+var (
+	wikisMu sync.Mutex
+	// wikis are the APIs of the Wikipedias other than the English one.
+	wikis = map[string]*mediawiki.Client{}
+)
+
+// wikiOf returns the API of the Wikipedia of the language.
+func wikiOf(lang string) *mediawiki.Client {
+	if lang == "" || lang == "en" {
+		return wiki
+	}
+
+	wikisMu.Lock()
+	defer wikisMu.Unlock()
+	c, ok := wikis[lang]
+	if !ok {
+		c = mediawiki.New(mediawiki.Language(lang))
+		wikis[lang] = c
+	}
+	return c
+}
+
+// splitLang splits the language prefixing an article, as in
+// "de:Tracker_(Fernsehserie)". The language is empty if there is none.
+func splitLang(ref string) (lang, article string) {
+	i := strings.Index(ref, ":")
+	if i < 2 || i > 3 {
+		return "", ref
+	}
+	for _, r := range ref[:i] {
+		if r < 'a' || r > 'z' {
+			return "", ref
+		}
+	}
+	return ref[:i], ref[i+1:]
+}
+
+// columns are the indexes of the columns of a table of episodes, -1 if the
+// table doesn't have the column.
+type columns struct {
+	number, title, date int
+}
+
+// find the columns of the table from the headings of its columns. The
+// number within the season is preferred to the overall number.
+func (n *columnNames) find(headings []string) columns {
+	c := columns{number: -1, title: -1, date: -1}
+	season := -1
+	for i, h := range headings {
+		words := headingWords(h)
+		switch {
+		case c.title < 0 && matchWords(words, n.title):
+			c.title = i
+		case c.date < 0 && matchWords(words, n.date):
+			c.date = i
+		case season < 0 && matchWords(words, n.season):
+			season = i
+		case c.number < 0 && matchWords(words, n.number):
+			c.number = i
+		}
+	}
+	if season >= 0 {
+		c.number = season
+	}
+	return c
+}
+
+// headingWords splits the heading of a column in lowercase words, dropping
+// punctuation such as the dot of "Nr.".
+func headingWords(h string) []string {
+	return strings.FieldsFunc(strings.ToLower(h), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("#°º", r)
+	})
+}
+
+// matchWords returns true if any word is one of the names, or ends with one
+// of them, as "Originaltitel" does with "titel".
+func matchWords(words, names []string) bool {
+	for _, w := range words {
+		for _, name := range names {
+			if w == name || (len([]rune(name)) > 2 && strings.HasSuffix(w, name)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package show
+
+import (
+	"context"
+
+	"tracker/trackable"
+)
+
+// Kind of the show trackable.
+const Kind trackable.Kind = "show"
+
+var (
+	_ trackable.Trackable = &Show{}
+	_ trackable.Statuser  = &Show{}
+)
+
+func init() {
+	trackable.Register(&trackable.Module{
+		Kind: Kind,
+		Name: "Shows",
+		API:  &API{},
+		Load: load,
+	})
+}
+
+// load all shows as trackables.
+func load(context.Context) ([]trackable.Trackable, error) {
+	items, err := loadAllShows()
+	if err != nil {
+		return nil, err
+	}
+
+	trackables := make([]trackable.Trackable, len(items))
+	for i, item := range items {
+		trackables[i] = item
+	}
+	return trackables, nil
+}
@@ -0,0 +1,157 @@
+package show
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// sectionKind is what the episodes listed in a section of an article are.
+type sectionKind int
+
+const (
+	// regularSection lists the episodes of a season.
+	regularSection sectionKind = iota
+	// specialsSection lists specials, which are stored as season 0.
+	specialsSection
+	// extrasSection lists webisodes, shorts and the like, which aren't
+	// tracked.
+	extrasSection
+)
+
+// The headings of other Wikipedias are matched as well: German, French,
+// Spanish and Japanese. Japanese headings number seasons as in "第2期" or
+// "シーズン2", hence the other groups of seasonRegexp.
+var (
+	seasonRegexp   = regexp.MustCompile(`(?i)(?:\b(?:season|series|staffel|saison|temporada)\s+([0-9]+|[a-z]+)\b|第\s*([0-9]+)\s*(?:期|シーズン|シリーズ)|シーズン\s*([0-9]+))`)
+	partRegexp     = regexp.MustCompile(`(?i)\b(?:part|volume|vol\.|teil|partie|parte)\s+([0-9]+|[a-z]+)\b`)
+	specialsRegexp = regexp.MustCompile(`(?i)\b(?:specials?|especial(?:es)?)\b|\bspezial|spéciaux|スペシャル|特別編`)
+	extrasRegexp   = regexp.MustCompile(`(?i)\b(?:webisodes?|web series|mobisodes?|minisodes?|shorts|digital series|online series|webisoden|webisodios)\b|\bwebséries?|ウェブ`)
+)
+
+// seasonNumber returns the number of the season the match of seasonRegexp
+// reads, 0 if it isn't a number.
+func seasonNumber(m []string) int {
+	for _, n := range m[1:] {
+		if n != "" {
+			return parseNumber(n)
+		}
+	}
+	return 0
+}
+
+// numberWords are the numbers headings spell out.
+var numberWords = map[string]int{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7,
+	"eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12, "thirteen": 13,
+	"fourteen": 14, "fifteen": 15, "sixteen": 16, "seventeen": 17, "eighteen": 18,
+	"nineteen": 19, "twenty": 20,
+}
+
+func parseNumber(s string) int {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n
+	}
+	return numberWords[strings.ToLower(s)]
+}
+
+// section is the classification of the headings a table is listed under.
+type section struct {
+	kind sectionKind
+	// season is the number of the season, 0 if the headings don't say.
+	season int
+	// part is the part of a season split in parts, 0 if it isn't.
+	part int
+}
+
+// classify the section of the headings leading to a table, outermost first.
+// The innermost heading which says what the section is wins.
+func classify(headings []string) section {
+	var sec section
+	for _, h := range headings {
+		switch {
+		case extrasRegexp.MatchString(h):
+			sec = section{kind: extrasSection}
+		case specialsRegexp.MatchString(h) && !seasonRegexp.MatchString(h):
+			sec = section{kind: specialsSection}
+		}
+		if m := seasonRegexp.FindStringSubmatch(h); m != nil {
+			if n := seasonNumber(m); n > 0 {
+				sec = section{kind: regularSection, season: n}
+			}
+		}
+		if m := partRegexp.FindStringSubmatch(h); m != nil && sec.kind == regularSection {
+			sec.part = parseNumber(m[1])
+		}
+	}
+	return sec
+}
+
+// seasonTracker assigns the tables of episodes of an article to seasons,
+// in the order they are listed.
+type seasonTracker struct {
+	// previous is the season of the latest regular table.
+	previous int
+	// numbers are the highest episode number of every season so far.
+	numbers map[int]int
+
+	// The current table.
+	section section
+	season  int
+	offset  int
+	started bool
+}
+
+func newSeasonTracker() *seasonTracker {
+	return &seasonTracker{numbers: map[int]int{}}
+}
+
+// table starts a new table listed under the headings, and returns what it
+// lists. Tables of regular seasons whose headings don't give the season
+// follow the previous one, unless they are a later part of it.
+func (t *seasonTracker) table(headings []string) sectionKind {
+	t.section = classify(headings)
+	t.offset = 0
+	t.started = false
+
+	switch {
+	case t.section.kind == specialsSection:
+		t.season = 0
+	case t.section.kind == extrasSection:
+		t.season = -1
+	case t.section.season > 0:
+		t.season = t.section.season
+	case t.section.part > 1 && t.previous > 0:
+		t.season = t.previous
+	default:
+		t.season = t.previous + 1
+	}
+
+	if t.section.kind == regularSection {
+		t.previous = t.season
+	}
+	return t.section.kind
+}
+
+// episode returns the season and number of the episode numbered n in the
+// current table. Later parts of a season which number their episodes from 1
+// again continue the numbering of the earlier parts. Specials without a
+// number, n <= 0, are numbered in the order they are listed.
+func (t *seasonTracker) episode(n int) (season, number int) {
+	highest := t.numbers[t.season]
+	if !t.started {
+		t.started = true
+		if t.section.kind == regularSection && n > 0 && n <= highest {
+			t.offset = highest
+		}
+	}
+
+	number = n + t.offset
+	if n <= 0 {
+		number = highest + 1
+	}
+	if number > highest {
+		t.numbers[t.season] = number
+	}
+	return t.season, number
+}
@@ -0,0 +1,185 @@
+package show
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+)
+
+func TestClassify(t *testing.T) {
+	testCases := map[string]struct {
+		headings []string
+		want     section
+	}{
+		"season":           {headings: []string{"Episodes", "Season 3 (2021)"}, want: section{season: 3}},
+		"british series":   {headings: []string{"Episodes", "Series 2 (2015)"}, want: section{season: 2}},
+		"spelled out":      {headings: []string{"Season One"}, want: section{season: 1}},
+		"part of a season": {headings: []string{"Season 5 (2012–13)", "Part 2"}, want: section{season: 5, part: 2}},
+		"part heading":     {headings: []string{"Season 5 Part 2"}, want: section{season: 5, part: 2}},
+		"part only":        {headings: []string{"Episodes", "Part 2"}, want: section{part: 2}},
+		"specials":         {headings: []string{"Episodes", "Specials"}, want: section{kind: specialsSection}},
+		"special in season": {headings: []string{"Season 2", "Christmas special (2020)"},
+			want: section{kind: specialsSection}},
+		"webisodes":        {headings: []string{"Episodes", "Webisodes"}, want: section{kind: extrasSection}},
+		"miniseries":       {headings: []string{"Episodes"}, want: section{}},
+		"overview":         {headings: []string{"Series overview"}, want: section{}},
+		"staffel":          {headings: []string{"Episoden", "Staffel 2 (2021)"}, want: section{season: 2}},
+		"saison":           {headings: []string{"Saison 3", "Partie 2"}, want: section{season: 3, part: 2}},
+		"temporada":        {headings: []string{"Temporada 1"}, want: section{season: 1}},
+		"japanese period":  {headings: []string{"各話リスト", "第2期"}, want: section{season: 2}},
+		"japanese season":  {headings: []string{"シーズン3"}, want: section{season: 3}},
+		"spezial":          {headings: []string{"Staffel 1", "Spezialfolgen"}, want: section{kind: specialsSection}},
+		"spéciaux":         {headings: []string{"Épisodes spéciaux"}, want: section{kind: specialsSection}},
+		"especiales":       {headings: []string{"Especiales"}, want: section{kind: specialsSection}},
+		"japanese special": {headings: []string{"特別編"}, want: section{kind: specialsSection}},
+		"webisoden":        {headings: []string{"Webisoden"}, want: section{kind: extrasSection}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := classify(tc.headings); got != tc.want {
+				t.Errorf("classify(%q) = %+v, want %+v", tc.headings, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSeasonTracker(t *testing.T) {
+	type episode struct{ season, number int }
+	tables := []struct {
+		headings []string
+		numbers  []int
+		want     []episode
+	}{
+		// Tables without a season follow the previous season.
+		{headings: []string{"Episodes"}, numbers: []int{1, 2}, want: []episode{{1, 1}, {1, 2}}},
+		{headings: []string{"Season 2", "Part 1"}, numbers: []int{1, 2}, want: []episode{{2, 1}, {2, 2}}},
+		// Later parts continue the numbering of the season.
+		{headings: []string{"Season 2", "Part 2"}, numbers: []int{1, 2}, want: []episode{{2, 3}, {2, 4}}},
+		{headings: []string{"Season 3", "Part 1"}, numbers: []int{1}, want: []episode{{3, 1}}},
+		{headings: []string{"Season 3", "Part 2"}, numbers: []int{2, 3}, want: []episode{{3, 2}, {3, 3}}},
+		// Specials are season 0, numbered in order unless they have numbers.
+		{headings: []string{"Specials"}, numbers: []int{0, 0}, want: []episode{{0, 1}, {0, 2}}},
+		{headings: []string{"Season 4"}, numbers: []int{1}, want: []episode{{4, 1}}},
+		{headings: []string{"Part 2"}, numbers: []int{1}, want: []episode{{4, 2}}},
+	}
+
+	tracker := newSeasonTracker()
+	for _, table := range tables {
+		tracker.table(table.headings)
+		var got []episode
+		for _, n := range table.numbers {
+			season, number := tracker.episode(n)
+			got = append(got, episode{season, number})
+		}
+		if diff := deep.Equal(got, table.want); diff != nil {
+			t.Errorf("episodes under %q diff = %v", table.headings, diff)
+		}
+	}
+}
+
+func TestScrapeEpisodes(t *testing.T) {
+	body, err := ioutil.ReadFile("testdata/episodes.html")
+	if err != nil {
+		t.Fatalf("unable to read episodes: %v", err)
+	}
+	date := func(year int, month time.Month, day int) timeutil.Date {
+		return timeutil.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+	}
+
+	s := &Show{}
+	report := &trackable.Report{}
+	if err := s.scrapeEpisodes(body, report); err != nil {
+		t.Fatalf("scrapeEpisodes() err = %v, want %v", err, nil)
+	}
+
+	want := []*Episode{
+		{Title: `"Pilot"`, Season: 1, Episode: 1, ReleaseDate: date(2020, time.January, 5)},
+		{Title: `"Second Thoughts"`, Season: 1, Episode: 2, ReleaseDate: date(2020, time.January, 12)},
+		{Title: `"Return"`, Season: 2, Episode: 1, ReleaseDate: date(2021, time.March, 6)},
+		{Title: `"Midpoint"`, Season: 2, Episode: 2, ReleaseDate: date(2021, time.March, 13)},
+		{Title: `"Resumption"`, Season: 2, Episode: 3, ReleaseDate: date(2021, time.September, 4)},
+		{Title: `"Future"`, Season: 3, Episode: 1, ReleaseDate: timeutil.Date{
+			Time: time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC), Precision: timeutil.PrecisionQuarter, Tentative: true}},
+		{Title: `"Further"`, Season: 3, Episode: 2, ReleaseDate: timeutil.Date{Precision: timeutil.PrecisionUnknown}},
+		{Title: `"Holiday Special"`, Season: 0, Episode: 1, ReleaseDate: date(2020, time.December, 24)},
+	}
+	if diff := deep.Equal(s.Episodes, want); diff != nil {
+		t.Errorf("scrapeEpisodes() diff = %v", diff)
+	}
+
+	wantSkipped := []*trackable.Skip{
+		{Where: "season 2, row 2", Reason: `episode number "TBA" isn't a number`},
+		{Where: "Episodes / Webisodes", Reason: "not tracking extras"},
+	}
+	if diff := deep.Equal(report.Skipped, wantSkipped); diff != nil {
+		t.Errorf("scrapeEpisodes() skipped diff = %v", diff)
+	}
+	if report.Tables != 6 || report.Parsed != 8 {
+		t.Errorf("scrapeEpisodes() tables = %d, parsed = %d, want %d and %d",
+			report.Tables, report.Parsed, 6, 8)
+	}
+}
+
+func TestScrapeLocalizedEpisodes(t *testing.T) {
+	date := func(year int, month time.Month, day int) timeutil.Date {
+		return timeutil.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+	}
+
+	testCases := map[string]struct {
+		lang        string
+		want        []*Episode
+		wantSkipped []*trackable.Skip
+	}{
+		"de": {
+			lang: "de",
+			want: []*Episode{
+				{Title: "Pilotfolge", Season: 1, Episode: 1, ReleaseDate: date(2020, time.January, 5)},
+				{Title: "Zweifel", Season: 1, Episode: 2, ReleaseDate: date(2020, time.January, 12)},
+				{Title: "Die Zukunft", Season: 2, Episode: 1, ReleaseDate: timeutil.Date{
+					Time: time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC), Precision: timeutil.PrecisionQuarter, Tentative: true}},
+				{Title: "Weiter", Season: 2, Episode: 3, ReleaseDate: timeutil.Date{Precision: timeutil.PrecisionUnknown}},
+				{Title: "Weihnachtsfolge", Season: 0, Episode: 1, ReleaseDate: date(2020, time.December, 24)},
+			},
+			wantSkipped: []*trackable.Skip{
+				{Where: "season 2, row 2", Reason: `episode number "–" isn't a number`},
+				{Where: "Episoden / Webisoden", Reason: "not tracking extras"},
+			},
+		},
+		"ja": {
+			lang: "ja",
+			want: []*Episode{
+				{Title: "始まり", Season: 1, Episode: 1, ReleaseDate: date(2020, time.January, 5)},
+				{Title: "迷い", Season: 1, Episode: 2, ReleaseDate: date(2020, time.January, 12)},
+				{Title: "未来", Season: 2, Episode: 1, ReleaseDate: timeutil.Date{
+					Time: time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC), Precision: timeutil.PrecisionMonth, Tentative: true}},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			body, err := ioutil.ReadFile("testdata/episodes_" + tc.lang + ".html")
+			if err != nil {
+				t.Fatalf("unable to read episodes: %v", err)
+			}
+
+			s := &Show{Language: tc.lang}
+			report := &trackable.Report{}
+			if err := s.scrapeEpisodes(body, report); err != nil {
+				t.Fatalf("scrapeEpisodes() err = %v, want %v", err, nil)
+			}
+			if diff := deep.Equal(s.Episodes, tc.want); diff != nil {
+				t.Errorf("scrapeEpisodes() diff = %v", diff)
+			}
+			if diff := deep.Equal(report.Skipped, tc.wantSkipped); diff != nil {
+				t.Errorf("scrapeEpisodes() skipped diff = %v", diff)
+			}
+		})
+	}
+}
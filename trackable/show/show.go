@@ -1,12 +1,17 @@
 package show
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"tracker/database"
+	"tracker/internal/timeutil"
+	"tracker/trackable"
 
 	_ "github.com/go-sql-driver/mysql"
 )
@@ -21,6 +26,34 @@ type Show struct {
 	WikipediaURL string     `json:"wikipedia"`
 	TrailerURL   string     `json:"trailer"`
 
+	// Language of the Wikipedia the show is read from, as in "de". Shows
+	// without one are read from the English Wikipedia.
+	Language string `json:"language,omitempty"`
+
+	// Revision of the Wikipedia page listing the episodes when they were
+	// last scraped from its wikitext.
+	Revision int64 `json:"revision,omitempty"`
+	// ArticleRevision is that of the article of the show, whose infobox is
+	// read even when the episodes are listed on another page.
+	ArticleRevision int64 `json:"article_revision,omitempty"`
+
+	// Sources the episodes are read from, in order of priority. Shows
+	// without any are read from Wikipedia.
+	Sources []*SourceConfig `json:"sources,omitempty"`
+
+	// Info is what the infobox of the article of the show says of it, nil
+	// if it wasn't read.
+	Info *Info `json:"info,omitempty"`
+
+	// partial is true if a source failed when the show was last scraped.
+	// The episodes only that source lists are then missing, rather than
+	// removed, so they are kept when the show is written.
+	partial bool
+	// reread is true if the episodes must be read even if the pages listing
+	// them are unchanged, as they are merged with those of another source
+	// which changed.
+	reread bool
+
 	// Backwards Compatability
 	Location string `json:"location"`
 	Airing   int    `json:"airing"`
@@ -33,26 +66,241 @@ type Episode struct {
 	Title       string
 	Season      int
 	Episode     int
-	ReleaseDate time.Time
+	ReleaseDate timeutil.Date
+
+	// Sources names the source of each field, by field.
+	Sources map[string]string
+
+	// scraped is what the scraper read of each field corrected by an admin or
+	// a user, when it was corrected, by field.
+	scraped map[string]string
 }
 
+// Write persists the show and the changes to its episodes.
 func (s *Show) Write() error {
+	_, err := s.WriteDiff(context.Background())
+	return err
+}
+
+// WriteDiff persists the show in a single transaction, inserting, updating
+// and deleting only the episodes which changed since it was last written.
+// The previous values of the show and its episodes are recorded in its
+// history. Writing the
+// same show twice is a no-op. The revision of a show which fails to be
+// written is forgotten, so that it isn't unchanged when scraped again.
+func (s *Show) WriteDiff(ctx context.Context) (_ *trackable.Diff, err error) {
+	defer func() {
+		if err != nil {
+			s.Revision, s.ArticleRevision = 0, 0
+		}
+	}()
+
 	db, err := database.Open("tracker")
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer db.Close()
 
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Querying the show first locks it, so that concurrent writes of the same
+	// show compute their diff one after the other.
+	stored, err := queryShow(ctx, tx, s.ID)
+	if err != nil {
+		return nil, err
+	}
+	name := s.Name
+	if name == "" {
+		name = stored.Name
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE shows SET title=?, revision=?, article_revision=? WHERE id=?`,
+		name, s.Revision, s.ArticleRevision, s.ID); err != nil {
+		return nil, fmt.Errorf("unable to update show %d: %w", s.ID, err)
+	}
+	if err := s.writeInfo(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	episodes, err := queryEpisodes(ctx, tx, s.ID)
+	if err != nil {
+		return nil, err
+	}
+	diff := s.diff(episodes)
+	for _, r := range diff.Added {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO episodes(show_id, season, episode, title, release_date,
+			release_precision, release_tentative, title_source, date_source) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			s.ID, r.Season, r.Number, r.Title, nullTime(r.Date), r.Precision, r.Tentative,
+			r.Sources[FieldTitle], r.Sources[FieldReleaseDate]); err != nil {
+			return nil, fmt.Errorf("unable to insert episode %dx%d: %w", r.Season, r.Number, err)
+		}
+	}
+	for _, c := range diff.Changed {
+		if _, err := tx.ExecContext(ctx, `UPDATE episodes SET title=?, release_date=?, release_precision=?,
+			release_tentative=?, title_source=?, date_source=? WHERE show_id=? AND season=? AND episode=?`,
+			c.New.Title, nullTime(c.New.Date), c.New.Precision, c.New.Tentative, c.New.Sources[FieldTitle],
+			c.New.Sources[FieldReleaseDate], s.ID, c.New.Season, c.New.Number); err != nil {
+			return nil, fmt.Errorf("unable to update episode %dx%d: %w", c.New.Season, c.New.Number, err)
+		}
+	}
+	for _, r := range diff.Removed {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM episodes WHERE show_id=? AND season=? AND episode=?`,
+			s.ID, r.Season, r.Number); err != nil {
+			return nil, fmt.Errorf("unable to delete episode %dx%d: %w", r.Season, r.Number, err)
+		}
+	}
+
+	now := time.Now().UTC()
+	list := append(s.showChanges(stored, SourceScraper, now), changes(diff, SourceScraper, now)...)
+	if err := recordChanges(ctx, tx, list); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("unable to commit show %d: %w", s.ID, err)
+	}
+	return diff, nil
+}
+
+// diff compares the episodes of the show with the stored ones. Episodes are
+// identified by their season and number. Corrections of stored episodes are
+// kept until the scraper reads the corrected fields differently. A show
+// without episodes is more likely a page which failed to parse than a show
+// which lost all of them, so nothing is removed then, nor when a source of
+// the show failed.
+func (s *Show) diff(stored []*Episode) *trackable.Diff {
+	type key struct{ season, episode int }
+
+	diff := &trackable.Diff{Ref: s.Ref()}
+	old := make(map[key]*Episode, len(stored))
+	for _, e := range stored {
+		old[key{e.Season, e.Episode}] = e
+	}
+
+	seen := make(map[key]bool, len(s.Episodes))
 	for _, e := range s.Episodes {
-		_, err = db.Exec(`INSERT INTO episodes(show_id, season, episode, title, release_date)
-		 		          VALUES(?, ?, ?, ?, ?)`, s.ID, e.Season, e.Episode, e.Title,
-			e.ReleaseDate)
-		if err != nil {
-			fmt.Printf("Error with show %d: %v\n", s.ID, err)
+		k := key{e.Season, e.Episode}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+
+		o, ok := old[k]
+		if ok {
+			e = o.corrected(e)
+		}
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, s.release(e))
+		case o.Title != e.Title || !o.ReleaseDate.Equal(e.ReleaseDate):
+			diff.Changed = append(diff.Changed, &trackable.Change{Old: s.release(o), New: s.release(e)})
 		}
 	}
 
-	return nil
+	if len(s.Episodes) == 0 || s.partial {
+		return diff
+	}
+	for _, e := range stored {
+		if !seen[key{e.Season, e.Episode}] {
+			diff.Removed = append(diff.Removed, s.release(e))
+		}
+	}
+	return diff
+}
+
+func (s *Show) Ref() trackable.Ref {
+	return trackable.Ref{Kind: Kind, ID: s.ID}
+}
+
+// Releases returns the episodes released in the range. Episodes whose date
+// isn't known to the day aren't released on any day of it.
+func (s *Show) Releases(start, end time.Time) []*trackable.Release {
+	releases := make([]*trackable.Release, 0)
+	for _, e := range s.Episodes {
+		if !e.ReleaseDate.Exact() || e.ReleaseDate.Before(start) || !e.ReleaseDate.Before(end) {
+			continue
+		}
+		releases = append(releases, s.release(e))
+	}
+	return releases
+}
+
+// Unscheduled returns the episodes which may be released in the range, but
+// whose date isn't known to the day.
+func (s *Show) Unscheduled(start, end time.Time) []*trackable.Release {
+	releases := make([]*trackable.Release, 0)
+	for _, e := range s.unscheduled(start, end, time.Now()) {
+		releases = append(releases, s.release(e))
+	}
+	return releases
+}
+
+// unscheduled returns the episodes whose imprecise date overlaps the range.
+// Episodes without a date at all are included in ranges which aren't over by
+// now, unless the show has finished.
+func (s *Show) unscheduled(start, end, now time.Time) []*Episode {
+	episodes := make([]*Episode, 0)
+	for _, e := range s.Episodes {
+		switch {
+		case e.ReleaseDate.Exact():
+			continue
+		case e.ReleaseDate.Known() && !e.ReleaseDate.Overlaps(start, end):
+			continue
+		case !e.ReleaseDate.Known() && (s.Finished || !end.After(now)):
+			continue
+		}
+		episodes = append(episodes, e)
+	}
+	return episodes
+}
+
+func (s *Show) release(e *Episode) *trackable.Release {
+	return &trackable.Release{
+		Ref:    s.Ref(),
+		Name:   s.Name,
+		Title:  e.Title,
+		Date:   e.ReleaseDate.Time,
+		Season: e.Season,
+		Number: e.Episode,
+
+		Precision: e.ReleaseDate.Precision,
+		Tentative: e.ReleaseDate.Tentative,
+		Sources:   e.Sources,
+	}
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// activeWindow is how close to its latest or next episode a show is
+// considered active.
+const activeWindow = 30 * timeutil.Day
+
+// Status returns whether the show is airing, has episodes announced, or has
+// finished airing.
+func (s *Show) Status(now time.Time) trackable.Status {
+	if s.Finished {
+		return trackable.Finished
+	}
+
+	status := trackable.Idle
+	for _, e := range s.Episodes {
+		if !e.ReleaseDate.Known() {
+			continue
+		}
+		if e.ReleaseDate.Exact() && e.ReleaseDate.After(now.Add(-activeWindow)) &&
+			e.ReleaseDate.Before(now.Add(activeWindow)) {
+			return trackable.Active
+		}
+		if !e.ReleaseDate.Released(now) {
+			status = trackable.Upcoming
+		}
+	}
+	return status
 }
 
 func (s *Show) GetEpisodes() ([]*Episode, error) {
@@ -64,7 +312,7 @@ func (s *Show) GetMostRecentEpisode() *Episode {
 
 	var last *Episode
 	for i := len(s.Episodes) - 1; i >= 0; i-- {
-		if s.Episodes[i].ReleaseDate.Before(now) {
+		if s.Episodes[i].ReleaseDate.Released(now) {
 			return s.Episodes[i]
 		}
 		last = s.Episodes[i]
@@ -76,7 +324,7 @@ func (s *Show) GetNextEpisode() *Episode {
 	now := time.Now()
 
 	for _, episode := range s.Episodes {
-		if episode.ReleaseDate.After(now) {
+		if episode.ReleaseDate.Known() && !episode.ReleaseDate.Released(now) {
 			return episode
 		}
 	}
@@ -123,47 +371,226 @@ func (s *Episode) String() string {
 		s.ReleaseDate, s.Title)
 }
 
-func ScrapeAll() error {
-	shows, err := loadAllShows()
+// showColumns are the columns of shows read by Scan.
+const showColumns = `id,title,wikipedia,language,trailer,finished,revision,article_revision,country,
+	original_language,network,seasons,episode_count,first_aired,last_aired,website`
+
+// Scan reads the show and its info, but for its lists, from the columns of
+// showColumns.
+func (s *Show) Scan(rows *sql.Rows) error {
+	var firstAired, lastAired string
+	s.Info = &Info{}
+	err := rows.Scan(&s.ID, &s.Name, &s.WikipediaURL, &s.Language, &s.TrailerURL,
+		&s.Finished, &s.Revision, &s.ArticleRevision, &s.Info.Country, &s.Info.OriginalLanguage,
+		&s.Info.Network, &s.Info.Seasons, &s.Info.Episodes, &firstAired, &lastAired, &s.Info.Website)
 	if err != nil {
-		return err
+		return fmt.Errorf("unable to scan show: %w", err)
+	}
+	if s.Info.FirstAired, err = parseStoredDate(firstAired); err != nil {
+		return fmt.Errorf("unable to scan first air date of show %d: %w", s.ID, err)
+	}
+	if s.Info.LastAired, err = parseStoredDate(lastAired); err != nil {
+		return fmt.Errorf("unable to scan last air date of show %d: %w", s.ID, err)
 	}
+	return nil
+}
 
-	errors := make([]error, 0)
+// parseStoredDate parses a date stored with Date.String, which is empty if
+// the date isn't known.
+func parseStoredDate(text string) (timeutil.Date, error) {
+	if text == "" {
+		return timeutil.Date{}, nil
+	}
+	return timeutil.ParseDate(text)
+}
 
-	for _, show := range shows {
-		fmt.Printf("\t%v", show)
-		url := fmt.Sprintf("https://en.wikipedia.org/wiki/%s", show.WikipediaURL)
-		if err = show.scrape(url); err != nil {
-			return err
+// episodeColumns are the columns of episodes read by Scan.
+const episodeColumns = `title,season,episode,release_date,release_precision,release_tentative,
+	title_source,date_source,scraped_title,scraped_date`
+
+// Scan reads the episode from the columns of episodeColumns.
+func (e *Episode) Scan(rows *sql.Rows) error {
+	return e.scan(rows)
+}
+
+// scan reads the episode from the columns of episodeColumns, which follow
+// the columns read into dest.
+func (e *Episode) scan(rows *sql.Rows, dest ...interface{}) error {
+	var (
+		released                  sql.NullTime
+		titleSource, dateSource   string
+		scrapedTitle, scrapedDate string
+	)
+	err := rows.Scan(append(dest, &e.Title, &e.Season, &e.Episode, &released, &e.ReleaseDate.Precision,
+		&e.ReleaseDate.Tentative, &titleSource, &dateSource, &scrapedTitle, &scrapedDate)...)
+	if err != nil {
+		return fmt.Errorf("Unable to scan episode: %v", err)
+	}
+	e.ReleaseDate.Time = released.Time
+	e.Sources = episodeSources(titleSource, dateSource)
+	e.scraped = episodeScraped(e.Sources, scrapedTitle, scrapedDate)
+
+	return nil
+}
+
+// episodeSources returns the sources of the fields of an episode as stored,
+// nil if none are known.
+func episodeSources(title, date string) map[string]string {
+	if title == "" && date == "" {
+		return nil
+	}
+	return map[string]string{FieldTitle: title, FieldReleaseDate: date}
+}
+
+// episodeScraped returns what the scraper read of the corrected fields of an
+// episode as stored, nil if none are corrected. What is stored of the other
+// fields is left from earlier corrections, and ignored.
+func episodeScraped(sources map[string]string, title, date string) map[string]string {
+	var scraped map[string]string
+	for field, value := range map[string]string{FieldTitle: title, FieldReleaseDate: date} {
+		if !Source(sources[field]).Correction() {
+			continue
 		}
-		err = show.Write()
-		if err != nil {
-			err = fmt.Errorf("Error with show %d; %v", show.ID, err)
-			errors = append(errors, err)
+		if scraped == nil {
+			scraped = map[string]string{}
 		}
+		scraped[field] = value
 	}
+	return scraped
+}
 
-	fmt.Printf("%d Error(s) occured\n", len(errors))
-	for _, err := range errors {
-		fmt.Printf("Error: %v\n", err)
+// corrected returns the scraped episode with the corrections of the stored
+// one, for the fields which the scraper still reads as it did when they were
+// corrected. Fields which the scraper reads differently since are its own.
+func (e *Episode) corrected(scraped *Episode) *Episode {
+	if len(e.scraped) == 0 {
+		return scraped
 	}
 
-	return nil
+	c := *scraped
+	c.Sources, c.scraped = map[string]string{}, map[string]string{}
+	for field, source := range scraped.Sources {
+		c.Sources[field] = source
+	}
+	if v, ok := e.scraped[FieldTitle]; ok && scraped.Title == v {
+		c.Title, c.Sources[FieldTitle], c.scraped[FieldTitle] = e.Title, e.Sources[FieldTitle], v
+	}
+	if v, ok := e.scraped[FieldReleaseDate]; ok && scraped.ReleaseDate.String() == v {
+		c.ReleaseDate, c.Sources[FieldReleaseDate], c.scraped[FieldReleaseDate] =
+			e.ReleaseDate, e.Sources[FieldReleaseDate], v
+	}
+	return &c
 }
 
-func (s *Show) Scan(rows *sql.Rows) error {
-	return rows.Scan(&s.ID, &s.Name, &s.WikipediaURL, &s.TrailerURL,
-		&s.Finished)
+// queryer is a database, or a transaction.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
 }
 
-func (e *Episode) Scan(rows *sql.Rows) error {
-	err := rows.Scan(&e.Title, &e.Season, &e.Episode, &e.ReleaseDate)
+// queryShow returns the stored show with its info, locking it until the
+// transaction ends.
+func queryShow(ctx context.Context, tx *sql.Tx, id int) (*Show, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT "+showColumns+" FROM shows WHERE id=? FOR UPDATE", id)
 	if err != nil {
-		return fmt.Errorf("Unable to scan episode: %v", err)
+		return nil, fmt.Errorf("unable to query show %d: %w", id, err)
 	}
+	defer rows.Close()
 
-	return nil
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %d", ErrNotFound, id)
+	}
+	s := &Show{}
+	if err := s.Scan(rows); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	return s, loadInfoLists(ctx, tx, map[int]*Show{s.ID: s})
+}
+
+// queryEpisodes returns the stored episodes of the show, locking them until
+// the transaction ends.
+func queryEpisodes(ctx context.Context, tx *sql.Tx, showID int) ([]*Episode, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT "+episodeColumns+" FROM episodes WHERE show_id=? FOR UPDATE", showID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query episodes of show %d: %w", showID, err)
+	}
+	defer rows.Close()
+
+	episodes := make([]*Episode, 0)
+	for rows.Next() {
+		episode := &Episode{}
+		if err := episode.Scan(rows); err != nil {
+			return nil, err
+		}
+		episodes = append(episodes, episode)
+	}
+	return episodes, rows.Err()
+}
+
+// ErrNotFound is returned by Find for shows which aren't tracked.
+const ErrNotFound = trackable.Error("show: not found")
+
+// Find returns the stored show with the ID, or the article on Wikipedia,
+// along with its episodes and sources. Articles may be given with spaces or
+// underscores, as in their URL.
+func Find(ctx context.Context, key string) (*Show, error) {
+	db, err := database.Open("tracker")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	query := "SELECT " + showColumns + " FROM shows WHERE wikipedia=?"
+	arg := interface{}(strings.ReplaceAll(key, " ", "_"))
+	if id, err := strconv.Atoi(key); err == nil {
+		query, arg = "SELECT "+showColumns+" FROM shows WHERE id=?", id
+	}
+	rows, err := db.QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query show %q: %w", key, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	s := &Show{}
+	if err := s.Scan(rows); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	sources, err := loadSources(db)
+	if err != nil {
+		return nil, err
+	}
+	s.Sources = sources[s.ID]
+	if err := loadInfoLists(ctx, db, map[int]*Show{s.ID: s}); err != nil {
+		return nil, err
+	}
+	return s, loadEpisodes(db, map[int]*Show{s.ID: s})
+}
+
+// Preview returns what writing the show would change, without writing it.
+func (s *Show) Preview(ctx context.Context) (*trackable.Diff, error) {
+	db, err := database.Open("tracker")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	stored := &Show{ID: s.ID}
+	if err := loadEpisodes(db, map[int]*Show{s.ID: stored}); err != nil {
+		return nil, fmt.Errorf("unable to load episodes of show %d: %w", s.ID, err)
+	}
+	return s.diff(stored.Episodes), nil
 }
 
 func loadAllShows() ([]*Show, error) {
@@ -173,51 +600,72 @@ func loadAllShows() ([]*Show, error) {
 	if err != nil {
 		return shows, err
 	}
+	defer db.Close()
+
+	sources, err := loadSources(db)
+	if err != nil {
+		return shows, err
+	}
 
-	rows, err := db.Query("SELECT id,title,wikipedia,trailer,finished FROM shows")
+	rows, err := db.Query("SELECT " + showColumns + " FROM shows")
 	if err != nil {
 		return shows, err
 	}
+	defer rows.Close()
 
+	byID := map[int]*Show{}
 	for rows.Next() {
 		show := &Show{}
 		err := show.Scan(rows)
 		if err != nil {
 			return shows, err
 		}
-
-		err = show.loadAllEpisodes()
-		if err != nil {
-			return shows, err
-		}
+		show.Sources = sources[show.ID]
 		shows = append(shows, show)
+		byID[show.ID] = show
+	}
+	if err := rows.Err(); err != nil {
+		return shows, err
 	}
+	rows.Close()
 
-	return shows, nil
+	if err := loadEpisodes(db, byID); err != nil {
+		return shows, err
+	}
+	return shows, loadInfoLists(context.Background(), db, byID)
 }
 
-func (s *Show) loadAllEpisodes() error {
-	s.Episodes = make([]*Episode, 0)
-
-	db, err := database.Open("tracker")
-	if err != nil {
-		return err
+// loadEpisodes fills the episodes of the shows, by ID, in the order of their
+// seasons and numbers. Only the episodes of a single show are queried.
+func loadEpisodes(db *sql.DB, shows map[int]*Show) error {
+	query := "SELECT show_id," + episodeColumns + " FROM episodes"
+	var args []interface{}
+	if len(shows) == 1 {
+		for id := range shows {
+			query += " WHERE show_id=?"
+			args = append(args, id)
+		}
+	}
+	query += " ORDER BY show_id,season,episode"
+	for _, s := range shows {
+		s.Episodes = make([]*Episode, 0)
 	}
 
-	rows, err := db.Query("SELECT title,season,episode,release_date FROM episodes WHERE show_id=?",
-		s.ID)
+	rows, err := db.Query(query, args...)
 	if err != nil {
-		return err
+		return fmt.Errorf("unable to query episodes: %w", err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
+		var id int
 		episode := &Episode{}
-		err := episode.Scan(rows)
-		if err != nil {
+		if err := episode.scan(rows, &id); err != nil {
 			return err
 		}
-		s.Episodes = append(s.Episodes, episode)
+		if s, ok := shows[id]; ok {
+			s.Episodes = append(s.Episodes, episode)
+		}
 	}
-	return nil
+	return rows.Err()
 }
@@ -0,0 +1,147 @@
+package show
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+)
+
+func TestStatus(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	episode := func(days int) *Episode {
+		return &Episode{ReleaseDate: timeutil.Date{Time: now.AddDate(0, 0, days)}}
+	}
+
+	testCases := map[string]struct {
+		show *Show
+		want trackable.Status
+	}{
+		"finished": {
+			show: &Show{Finished: true, Episodes: []*Episode{episode(-1)}},
+			want: trackable.Finished,
+		},
+		"aired last week": {
+			show: &Show{Episodes: []*Episode{episode(-100), episode(-7)}},
+			want: trackable.Active,
+		},
+		"airs next week": {
+			show: &Show{Episodes: []*Episode{episode(7)}},
+			want: trackable.Active,
+		},
+		"announced": {
+			show: &Show{Episodes: []*Episode{episode(-400), episode(90)}},
+			want: trackable.Upcoming,
+		},
+		"announced for this year": {
+			show: &Show{Episodes: []*Episode{episode(-90), {ReleaseDate: timeutil.Date{
+				Time: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC), Precision: timeutil.PrecisionYear}}}},
+			want: trackable.Upcoming,
+		},
+		"on break": {
+			show: &Show{Episodes: []*Episode{episode(-90), {}}},
+			want: trackable.Idle,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.show.Status(now); got != tc.want {
+				t.Errorf("Status() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	date := func(day int) timeutil.Date {
+		return timeutil.Date{Time: time.Date(2021, time.March, day, 0, 0, 0, 0, time.UTC)}
+	}
+	stored := []*Episode{
+		{Title: "Pilot", Season: 1, Episode: 1, ReleaseDate: date(1)},
+		{Title: "TBA", Season: 1, Episode: 2, ReleaseDate: date(8)},
+		{Title: "Cancelled", Season: 1, Episode: 3, ReleaseDate: date(15)},
+	}
+
+	s := &Show{ID: 1, Name: "Tracker", Episodes: []*Episode{
+		{Title: "Pilot", Season: 1, Episode: 1, ReleaseDate: date(1)},
+		{Title: "Second", Season: 1, Episode: 2, ReleaseDate: date(9)},
+		{Title: "Finale", Season: 1, Episode: 4, ReleaseDate: date(22)},
+		{Title: "Finale", Season: 1, Episode: 4, ReleaseDate: date(22)},
+	}}
+	ref := s.Ref()
+
+	want := &trackable.Diff{
+		Ref: ref,
+		Added: []*trackable.Release{
+			{Ref: ref, Name: "Tracker", Title: "Finale", Date: date(22).Time, Season: 1, Number: 4},
+		},
+		Changed: []*trackable.Change{{
+			Old: &trackable.Release{Ref: ref, Name: "Tracker", Title: "TBA", Date: date(8).Time, Season: 1, Number: 2},
+			New: &trackable.Release{Ref: ref, Name: "Tracker", Title: "Second", Date: date(9).Time, Season: 1, Number: 2},
+		}},
+		Removed: []*trackable.Release{
+			{Ref: ref, Name: "Tracker", Title: "Cancelled", Date: date(15).Time, Season: 1, Number: 3},
+		},
+	}
+	if diff := deep.Equal(s.diff(stored), want); diff != nil {
+		t.Errorf("diff() diff = %v", diff)
+	}
+
+	// Writing the stored episodes again changes nothing.
+	s.Episodes = stored
+	if d := s.diff(stored); !d.Empty() {
+		t.Errorf("diff() = %v, want empty", d)
+	}
+
+	// A show which lost all its episodes most likely failed to parse.
+	s.Episodes = nil
+	if d := s.diff(stored); !d.Empty() {
+		t.Errorf("diff() = %v, want empty", d)
+	}
+
+	// Episodes missing from a show whose source failed aren't removed.
+	s.Episodes, s.partial = stored[:1], true
+	if d := s.diff(stored); !d.Empty() {
+		t.Errorf("diff() = %v, want empty", d)
+	}
+}
+
+func TestDiffCorrected(t *testing.T) {
+	date := func(day int) timeutil.Date {
+		return timeutil.Date{Time: time.Date(2021, time.March, day, 0, 0, 0, 0, time.UTC)}
+	}
+	scraped := map[string]string{FieldTitle: "wikipedia", FieldReleaseDate: "wikipedia"}
+	stored := []*Episode{
+		{Title: "Pilot", Season: 1, Episode: 1, ReleaseDate: date(2),
+			Sources: map[string]string{FieldTitle: "wikipedia", FieldReleaseDate: "admin"},
+			scraped: map[string]string{FieldReleaseDate: "2021-03-01"}},
+		{Title: "Second", Season: 1, Episode: 2, ReleaseDate: date(9),
+			Sources: map[string]string{FieldTitle: "user", FieldReleaseDate: "user"},
+			scraped: map[string]string{FieldTitle: "TBA", FieldReleaseDate: "2021-03-08"}},
+	}
+
+	// The scraper still reads what was corrected, but for the date of the
+	// second episode, which was rescheduled since.
+	s := &Show{ID: 1, Name: "Tracker", Episodes: []*Episode{
+		{Title: "Pilot", Season: 1, Episode: 1, ReleaseDate: date(1), Sources: scraped},
+		{Title: "TBA", Season: 1, Episode: 2, ReleaseDate: date(15), Sources: scraped},
+	}}
+	ref := s.Ref()
+
+	want := &trackable.Diff{
+		Ref: ref,
+		Changed: []*trackable.Change{{
+			Old: &trackable.Release{Ref: ref, Name: "Tracker", Title: "Second", Date: date(9).Time, Season: 1,
+				Number: 2, Sources: stored[1].Sources},
+			New: &trackable.Release{Ref: ref, Name: "Tracker", Title: "Second", Date: date(15).Time, Season: 1,
+				Number: 2, Sources: map[string]string{FieldTitle: "user", FieldReleaseDate: "wikipedia"}},
+		}},
+	}
+	if diff := deep.Equal(s.diff(stored), want); diff != nil {
+		t.Errorf("diff() diff = %v", diff)
+	}
+}
@@ -0,0 +1,357 @@
+package show
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"tracker/internal/fetch"
+	"tracker/internal/mediawiki"
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+)
+
+// EpisodeSource is somewhere the episodes of a show are read from, such as
+// Wikipedia or a TV guide.
+type EpisodeSource interface {
+	// Name identifies the source in the configuration of shows, and in the
+	// provenance of their episodes.
+	Name() string
+
+	// Listing reads the show from the source. The show is what is known of
+	// it so far, and must not be modified.
+	Listing(ctx context.Context, s *Show) (*Listing, error)
+}
+
+// Listing is a show as read from a single source.
+type Listing struct {
+	Source     string
+	Name       string
+	EpisodeURL string
+	Revision   int64
+	Episodes   []*Episode
+	// ArticleRevision is the revision of the article the Info is read
+	// from, which may not be the page listing the episodes.
+	ArticleRevision int64
+	// Info is what the source says of the show, nil if it doesn't.
+	Info *Info
+
+	// Unchanged is true if the source hasn't changed since the show was last
+	// read from it.
+	Unchanged bool
+}
+
+// SourceConfig selects a source of the episodes of a show, and the show
+// within it, such as the title of its article on Wikipedia.
+type SourceConfig struct {
+	Source string `json:"source"`
+	Ref    string `json:"ref"`
+}
+
+const ErrUnknownSource = trackable.Error("show: unknown episode source")
+
+// sourceFuncs create the adapters of the sources by name, from the reference
+// of a show within them.
+var sourceFuncs = map[string]func(ref string) EpisodeSource{}
+
+// RegisterSource makes the source available to the configuration of shows,
+// replacing any source of the same name. It isn't safe to call while shows
+// are scraped.
+func RegisterSource(name string, f func(ref string) EpisodeSource) {
+	sourceFuncs[name] = f
+}
+
+func init() {
+	RegisterSource("wikipedia", func(ref string) EpisodeSource {
+		lang, article := splitLang(ref)
+		return &WikipediaSource{Lang: lang, Article: article}
+	})
+	RegisterSource("guide", func(ref string) EpisodeSource {
+		return &GuideSource{Endpoint: GuideEndpoint, ID: ref}
+	})
+	RegisterSource("fixture", func(ref string) EpisodeSource {
+		return &FixtureSource{Path: ref}
+	})
+}
+
+// episodeSources returns the sources configured for the show, in order of
+// priority. Shows without any are read from their article on Wikipedia.
+func (s *Show) episodeSources() ([]EpisodeSource, error) {
+	configs := s.Sources
+	if len(configs) == 0 {
+		configs = []*SourceConfig{{Source: "wikipedia", Ref: s.WikipediaURL}}
+	}
+
+	sources := make([]EpisodeSource, len(configs))
+	for i, c := range configs {
+		f, ok := sourceFuncs[c.Source]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownSource, c.Source)
+		}
+		sources[i] = f(c.Ref)
+	}
+	return sources, nil
+}
+
+// loadSources returns the sources configured for every show, by show.
+func loadSources(db *sql.DB) (map[int][]*SourceConfig, error) {
+	rows, err := db.Query("SELECT show_id, source, ref FROM show_sources ORDER BY show_id, position")
+	if err != nil {
+		return nil, fmt.Errorf("unable to query show sources: %w", err)
+	}
+	defer rows.Close()
+
+	sources := map[int][]*SourceConfig{}
+	for rows.Next() {
+		var id int
+		c := &SourceConfig{}
+		if err := rows.Scan(&id, &c.Source, &c.Ref); err != nil {
+			return nil, fmt.Errorf("unable to scan show source: %w", err)
+		}
+		sources[id] = append(sources[id], c)
+	}
+	return sources, rows.Err()
+}
+
+// WikipediaSource reads a show from its article on Wikipedia, as wikitext
+// from the API or from the rendered pages if the wikitext doesn't list the
+// episodes with templates. Articles are referred to in the configuration of
+// shows by their title, prefixed by the language of their Wikipedia if it
+// isn't that of the show, as in "de:Tracker_(Fernsehserie)".
+type WikipediaSource struct {
+	// Client is the API of the Wikipedia, that of the language if nil.
+	Client *mediawiki.Client
+	// Lang is the language of the Wikipedia, that of the show if empty.
+	Lang string
+	// Article is the title of the article, that of the show if empty.
+	Article string
+}
+
+func (w *WikipediaSource) Name() string {
+	return "wikipedia"
+}
+
+func (w *WikipediaSource) Listing(ctx context.Context, s *Show) (*Listing, error) {
+	article := w.Article
+	if article == "" {
+		article = s.WikipediaURL
+	}
+
+	lang := w.Lang
+	if lang == "" {
+		lang = s.Language
+	}
+	read := &Show{ID: s.ID, WikipediaURL: article, Language: lang,
+		Revision: s.Revision, ArticleRevision: s.ArticleRevision, reread: s.reread}
+	client := w.Client
+	if client == nil {
+		client = wikiOf(read.lang())
+	}
+
+	err := read.scrapeWiki(ctx, client)
+	if errors.Is(err, errNoEpisodeTemplates) || errors.Is(err, fetch.ErrDisallowed) {
+		trackable.ReportFrom(ctx).Warn("scraping the rendered pages: %v", err)
+		err = read.scrape(ctx, fmt.Sprintf("https://%s.wikipedia.org/wiki/%s", read.lang(), article))
+	}
+
+	unchanged := errors.Is(err, trackable.ErrUnchanged)
+	if err != nil && !unchanged {
+		return nil, err
+	}
+	return &Listing{
+		Name:            read.Name,
+		EpisodeURL:      read.EpisodeURL,
+		Revision:        read.Revision,
+		ArticleRevision: read.ArticleRevision,
+		Episodes:        read.Episodes,
+		Info:            read.Info,
+		Unchanged:       unchanged,
+	}, nil
+}
+
+// Scrape the show and its episodes from its sources, merging what they list.
+// A source which fails is skipped as long as another one doesn't, and no
+// episode is removed when the show is written then.
+func (s *Show) Scrape(ctx context.Context) error {
+	sources, err := s.episodeSources()
+	if err != nil {
+		return err
+	}
+	s.partial = false
+
+	report := trackable.ReportFrom(ctx)
+	var (
+		listings  []*Listing
+		listed    []EpisodeSource
+		unchanged = true
+		failed    error
+	)
+	for _, src := range sources {
+		l, err := src.Listing(ctx, s)
+		if err != nil {
+			if len(sources) == 1 {
+				return err
+			}
+			report.Warn("%s: %v", src.Name(), err)
+			failed = err
+			continue
+		}
+		l.Source = src.Name()
+		unchanged = unchanged && l.Unchanged
+		listings = append(listings, l)
+		listed = append(listed, src)
+	}
+
+	if len(listings) == 0 {
+		return fmt.Errorf("every source failed: %w", failed)
+	}
+	if unchanged {
+		return trackable.ErrUnchanged
+	}
+
+	// Unchanged sources may not have read their episodes, which are needed to
+	// merge them with those of the sources which changed.
+	s.reread = true
+	defer func() { s.reread = false }()
+	kept := listings[:0]
+	for i, l := range listings {
+		if l.Unchanged && l.Episodes == nil {
+			var err error
+			if l, err = listed[i].Listing(ctx, s); err != nil {
+				report.Warn("%s: %v", listed[i].Name(), err)
+				failed = err
+				continue
+			}
+			l.Source = listed[i].Name()
+		}
+		kept = append(kept, l)
+	}
+	listings = kept
+
+	s.partial = failed != nil
+	s.merge(listings, report)
+	return nil
+}
+
+// placeholderRegexp matches titles which stand in for one yet to be known.
+var placeholderRegexp = regexp.MustCompile(`(?i)^(?:tba|tbd|tbc|untitled|episode\s*#?[0-9]+)?$`)
+
+// normalTitle is the title without the quotes some sources add.
+func normalTitle(title string) string {
+	return strings.ToLower(strings.Trim(title, `"“” `))
+}
+
+// merge the listings, in order of priority, into the show. Episodes listed by
+// any source are kept, in the order they are first listed. The name and info
+// of the show are those of the first source which has them. For each episode:
+//
+//   - the title is that of the first source which has one, other than a
+//     placeholder such as "TBA";
+//   - the release date is the most precise one, and confirmed rather than
+//     tentative, of the first source if several are as precise.
+//
+// Sources which disagree are reported as warnings, and the source of every
+// field is kept in the episode.
+func (s *Show) merge(listings []*Listing, report *trackable.Report) {
+	type key struct{ season, episode int }
+	type sourced struct {
+		*Episode
+		source string
+	}
+
+	var (
+		order      []key
+		candidates = map[key][]sourced{}
+		named      bool
+		linked     bool
+		revised    bool
+		informed   bool
+	)
+	for _, l := range listings {
+		if l.Name != "" && !named {
+			s.Name, named = l.Name, true
+		}
+		if l.EpisodeURL != "" && !linked {
+			s.EpisodeURL, linked = l.EpisodeURL, true
+		}
+		if l.Revision != 0 && !revised {
+			s.Revision, s.ArticleRevision, revised = l.Revision, l.ArticleRevision, true
+		}
+		if l.Info != nil && !informed {
+			s.Info, informed = l.Info, true
+		}
+
+		seen := map[key]bool{}
+		for _, e := range l.Episodes {
+			k := key{e.Season, e.Episode}
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			if _, ok := candidates[k]; !ok {
+				order = append(order, k)
+			}
+			candidates[k] = append(candidates[k], sourced{e, l.Source})
+		}
+	}
+
+	s.Episodes = make([]*Episode, 0, len(order))
+	for _, k := range order {
+		list := candidates[k]
+		e := &Episode{Season: k.season, Episode: k.episode, Sources: map[string]string{}}
+
+		title := list[0]
+		for _, c := range list {
+			if !placeholderRegexp.MatchString(normalTitle(c.Title)) {
+				title = c
+				break
+			}
+		}
+		e.Title, e.Sources[FieldTitle] = title.Title, title.source
+
+		date := list[0]
+		for _, c := range list[1:] {
+			if betterDate(c.ReleaseDate, date.ReleaseDate) {
+				date = c
+			}
+		}
+		e.ReleaseDate, e.Sources[FieldReleaseDate] = date.ReleaseDate, date.source
+
+		for _, c := range list {
+			if c.source == title.source || placeholderRegexp.MatchString(normalTitle(c.Title)) {
+				continue
+			}
+			if normalTitle(c.Title) != normalTitle(title.Title) {
+				report.Warn("S%02dE%02d: %s title %q conflicts with %s %q", e.Season, e.Episode,
+					c.source, c.Title, title.source, title.Title)
+			}
+		}
+		for _, c := range list {
+			if c.source == date.source || !c.ReleaseDate.Known() || !e.ReleaseDate.Known() {
+				continue
+			}
+			if !c.ReleaseDate.Overlaps(e.ReleaseDate.Time, e.ReleaseDate.End()) {
+				report.Warn("S%02dE%02d: %s release date %s conflicts with %s %s", e.Season, e.Episode,
+					c.source, c.ReleaseDate, date.source, e.ReleaseDate)
+			}
+		}
+		s.Episodes = append(s.Episodes, e)
+	}
+}
+
+// betterDate returns true if the date a is more precise than b, or as
+// precise and confirmed while b is tentative.
+func betterDate(a, b timeutil.Date) bool {
+	switch {
+	case !a.Known():
+		return false
+	case !b.Known():
+		return true
+	case a.Precision != b.Precision:
+		return a.Precision < b.Precision
+	}
+	return !a.Tentative && b.Tentative
+}
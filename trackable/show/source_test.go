@@ -0,0 +1,321 @@
+package show
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"tracker/internal/fetch"
+	"tracker/internal/mediawiki"
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+)
+
+// guideServer stands in for the API of the TV guide, answering with the
+// recorded show of the ID requested.
+func guideServer(t *testing.T) *GuideSource {
+	srv := httptest.NewServer(http.StripPrefix("/shows/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("embed") != "episodes" {
+			http.Error(w, "episodes not embedded", http.StatusBadRequest)
+			return
+		}
+		http.ServeFile(w, r, "testdata/guide/"+r.URL.Path+".json")
+	})))
+	t.Cleanup(srv.Close)
+
+	return &GuideSource{Endpoint: srv.URL, Client: fetch.New(fetch.RateLimit(0, 0))}
+}
+
+// useSources replaces the adapters of the sources for the test.
+func useSources(t *testing.T, wiki *mediawiki.Client, guide *GuideSource) {
+	old := sourceFuncs
+	t.Cleanup(func() { sourceFuncs = old })
+
+	sourceFuncs = map[string]func(string) EpisodeSource{}
+	RegisterSource("wikipedia", func(ref string) EpisodeSource {
+		return &WikipediaSource{Client: wiki, Article: ref}
+	})
+	RegisterSource("guide", func(ref string) EpisodeSource {
+		return &GuideSource{Endpoint: guide.Endpoint, ID: ref, Client: guide.Client}
+	})
+	RegisterSource("fixture", func(ref string) EpisodeSource {
+		return &FixtureSource{Path: ref}
+	})
+}
+
+func exact(year int, month time.Month, day int) timeutil.Date {
+	return timeutil.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestGuideSource(t *testing.T) {
+	g := guideServer(t)
+	g.ID = "1"
+
+	report := &trackable.Report{}
+	l, err := g.Listing(trackable.WithReport(context.Background(), report), &Show{})
+	if err != nil {
+		t.Fatalf("Listing() err = %v, want %v", err, nil)
+	}
+
+	want := &Listing{
+		Name:       "Tracker",
+		EpisodeURL: "https://guide.example/shows/1/tracker",
+		Episodes: []*Episode{
+			{Title: "Pilot", Season: 1, Episode: 1, ReleaseDate: exact(2020, time.January, 5)},
+			{Title: "Second Thoughts", Season: 1, Episode: 2, ReleaseDate: exact(2020, time.January, 13)},
+			{Title: "Three and Out", Season: 1, Episode: 3, ReleaseDate: exact(2020, time.January, 19)},
+			{Title: "Holiday Special", Season: 0, Episode: 1, ReleaseDate: exact(2020, time.December, 24)},
+			{Title: "Return", Season: 2, Episode: 1, ReleaseDate: exact(2021, time.March, 6)},
+			{Title: "The Reckoning", Season: 2, Episode: 2, ReleaseDate: timeutil.Date{
+				Time: time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC), Precision: timeutil.PrecisionMonth}},
+		},
+	}
+	if diff := deep.Equal(l, want); diff != nil {
+		t.Errorf("Listing() diff = %v", diff)
+	}
+	if report.Parsed != 6 || len(report.Pages) != 1 {
+		t.Errorf("Listing() parsed = %d, pages = %v, want %d and 1 page", report.Parsed, report.Pages, 6)
+	}
+
+	g.ID = "2"
+	if _, err := g.Listing(context.Background(), &Show{}); !errors.As(err, new(*fetch.StatusError)) {
+		t.Errorf("Listing() err = %v, want a status error", err)
+	}
+}
+
+func TestFixtureSource(t *testing.T) {
+	f := &FixtureSource{Path: "testdata/fixture.json"}
+	l, err := f.Listing(context.Background(), &Show{})
+	if err != nil {
+		t.Fatalf("Listing() err = %v, want %v", err, nil)
+	}
+
+	want := &Listing{
+		Name:       "Tracker",
+		EpisodeURL: "https://tracker.example/episodes",
+		Episodes: []*Episode{
+			{Title: "Pilot", Season: 1, Episode: 1, ReleaseDate: exact(2020, time.January, 5)},
+			{Title: "Return", Season: 2, Episode: 1, ReleaseDate: timeutil.Date{
+				Time:      time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+				Precision: timeutil.PrecisionQuarter,
+				Tentative: true,
+			}},
+			{Title: "TBA", Season: 2, Episode: 2, ReleaseDate: timeutil.Date{Precision: timeutil.PrecisionUnknown}},
+		},
+	}
+	if diff := deep.Equal(l, want); diff != nil {
+		t.Errorf("Listing() diff = %v", diff)
+	}
+
+	f.Path = "testdata/missing.json"
+	if _, err := f.Listing(context.Background(), &Show{}); err == nil {
+		t.Errorf("Listing() err = %v, want an error", err)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	month := timeutil.Date{Time: time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC), Precision: timeutil.PrecisionMonth}
+	tentative := exact(2021, time.March, 6)
+	tentative.Tentative = true
+
+	testCases := map[string]struct {
+		listings     []*Listing
+		want         []*Episode
+		wantWarnings []string
+	}{
+		"first source wins": {
+			listings: []*Listing{
+				{Source: "a", Episodes: []*Episode{{Title: "Pilot", Season: 1, Episode: 1, ReleaseDate: exact(2021, time.March, 6)}}},
+				{Source: "b", Episodes: []*Episode{{Title: `"Pilot"`, Season: 1, Episode: 1, ReleaseDate: exact(2021, time.March, 6)}}},
+			},
+			want: []*Episode{{Title: "Pilot", Season: 1, Episode: 1, ReleaseDate: exact(2021, time.March, 6),
+				Sources: map[string]string{FieldTitle: "a", FieldReleaseDate: "a"}}},
+		},
+		"placeholder title": {
+			listings: []*Listing{
+				{Source: "a", Episodes: []*Episode{{Title: "TBA", Season: 1, Episode: 2}}},
+				{Source: "b", Episodes: []*Episode{{Title: "Second", Season: 1, Episode: 2}}},
+			},
+			want: []*Episode{{Title: "Second", Season: 1, Episode: 2,
+				Sources: map[string]string{FieldTitle: "b", FieldReleaseDate: "a"}}},
+		},
+		"more precise date": {
+			listings: []*Listing{
+				{Source: "a", Episodes: []*Episode{{Title: "Return", Season: 2, Episode: 1, ReleaseDate: month}}},
+				{Source: "b", Episodes: []*Episode{{Title: "Return", Season: 2, Episode: 1, ReleaseDate: tentative}}},
+				{Source: "c", Episodes: []*Episode{{Title: "Return", Season: 2, Episode: 1, ReleaseDate: exact(2021, time.March, 6)}}},
+			},
+			want: []*Episode{{Title: "Return", Season: 2, Episode: 1, ReleaseDate: exact(2021, time.March, 6),
+				Sources: map[string]string{FieldTitle: "a", FieldReleaseDate: "c"}}},
+		},
+		"conflicts": {
+			listings: []*Listing{
+				{Source: "a", Episodes: []*Episode{{Title: "Return", Season: 2, Episode: 1, ReleaseDate: exact(2021, time.March, 6)}}},
+				{Source: "b", Episodes: []*Episode{{Title: "Returns", Season: 2, Episode: 1, ReleaseDate: month}}},
+				{Source: "c", Episodes: []*Episode{{Title: "TBA", Season: 2, Episode: 1, ReleaseDate: exact(2021, time.March, 13)}}},
+			},
+			want: []*Episode{{Title: "Return", Season: 2, Episode: 1, ReleaseDate: exact(2021, time.March, 6),
+				Sources: map[string]string{FieldTitle: "a", FieldReleaseDate: "a"}}},
+			wantWarnings: []string{
+				`S02E01: b title "Returns" conflicts with a "Return"`,
+				`S02E01: c release date 2021-03-13 conflicts with a 2021-03-06`,
+			},
+		},
+		"episodes of every source": {
+			listings: []*Listing{
+				{Source: "a", Episodes: []*Episode{{Title: "Pilot", Season: 1, Episode: 1}}},
+				{Source: "b", Episodes: []*Episode{{Title: "Special", Season: 0, Episode: 1}, {Title: "Pilot", Season: 1, Episode: 1}}},
+			},
+			want: []*Episode{
+				{Title: "Pilot", Season: 1, Episode: 1, Sources: map[string]string{FieldTitle: "a", FieldReleaseDate: "a"}},
+				{Title: "Special", Season: 0, Episode: 1, Sources: map[string]string{FieldTitle: "b", FieldReleaseDate: "b"}},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s := &Show{}
+			report := &trackable.Report{}
+			s.merge(tc.listings, report)
+			if diff := deep.Equal(s.Episodes, tc.want); diff != nil {
+				t.Errorf("merge() diff = %v", diff)
+			}
+			if diff := deep.Equal(report.Warnings, tc.wantWarnings); diff != nil {
+				t.Errorf("merge() warnings diff = %v", diff)
+			}
+		})
+	}
+}
+
+func TestScrapeSources(t *testing.T) {
+	useSources(t, wikiServer(t), guideServer(t))
+
+	s := &Show{ID: 1, Sources: []*SourceConfig{
+		{Source: "wikipedia", Ref: "Tracker_(TV_series)"},
+		{Source: "guide", Ref: "1"},
+	}}
+	report := &trackable.Report{}
+	if err := s.Scrape(trackable.WithReport(context.Background(), report)); err != nil {
+		t.Fatalf("Scrape() err = %v, want %v", err, nil)
+	}
+
+	sources := func(title, date string) map[string]string {
+		return map[string]string{FieldTitle: title, FieldReleaseDate: date}
+	}
+	want := []*Episode{
+		{Title: "Pilot", Season: 1, Episode: 1, ReleaseDate: exact(2020, time.January, 5),
+			Sources: sources("wikipedia", "wikipedia")},
+		{Title: "Second Thoughts", Season: 1, Episode: 2, ReleaseDate: exact(2020, time.January, 12),
+			Sources: sources("wikipedia", "wikipedia")},
+		{Title: "Three & Out", Season: 1, Episode: 3, ReleaseDate: exact(2020, time.January, 19),
+			Sources: sources("wikipedia", "wikipedia")},
+		{Title: "Return", Season: 2, Episode: 1, ReleaseDate: exact(2021, time.March, 6),
+			Sources: sources("wikipedia", "wikipedia")},
+		{Title: "The Reckoning", Season: 2, Episode: 2, ReleaseDate: timeutil.Date{
+			Time: time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC), Precision: timeutil.PrecisionMonth},
+			Sources: sources("guide", "guide")},
+		{Title: "Holiday Special", Season: 0, Episode: 1, ReleaseDate: exact(2020, time.December, 24),
+			Sources: sources("guide", "guide")},
+	}
+	if diff := deep.Equal(s.Episodes, want); diff != nil {
+		t.Errorf("Scrape() diff = %v", diff)
+	}
+	if s.Name != "Tracker" || s.Revision != 2002 {
+		t.Errorf("Scrape() name = %q, revision = %d, want %q and %d", s.Name, s.Revision, "Tracker", 2002)
+	}
+
+	wantWarnings := []string{
+		`S01E02: guide release date 2020-01-13 conflicts with wikipedia 2020-01-12`,
+		`S01E03: guide title "Three and Out" conflicts with wikipedia "Three & Out"`,
+	}
+	if diff := deep.Equal(report.Warnings, wantWarnings); diff != nil {
+		t.Errorf("Scrape() warnings diff = %v", diff)
+	}
+}
+
+func TestScrapeFailedSource(t *testing.T) {
+	useSources(t, wikiServer(t), guideServer(t))
+
+	s := &Show{ID: 1, Sources: []*SourceConfig{
+		{Source: "guide", Ref: "2"},
+		{Source: "fixture", Ref: "testdata/fixture.json"},
+	}}
+	report := &trackable.Report{}
+	if err := s.Scrape(trackable.WithReport(context.Background(), report)); err != nil {
+		t.Fatalf("Scrape() err = %v, want %v", err, nil)
+	}
+	if len(s.Episodes) != 3 || len(report.Warnings) != 1 {
+		t.Errorf("Scrape() = %d episodes, warnings %q, want %d episodes and a warning",
+			len(s.Episodes), report.Warnings, 3)
+	}
+	if !s.partial {
+		t.Errorf("Scrape() partial = %v, want %v", s.partial, true)
+	}
+
+	s.Sources = []*SourceConfig{{Source: "unknown"}}
+	if err := s.Scrape(context.Background()); !errors.Is(err, ErrUnknownSource) {
+		t.Errorf("Scrape() err = %v, want %v", err, ErrUnknownSource)
+	}
+}
+
+// lazySource is unchanged, and only lists its episode when the show is
+// reread, as the rendered pages of Wikipedia do.
+type lazySource struct{}
+
+func (lazySource) Name() string { return "lazy" }
+
+func (lazySource) Listing(_ context.Context, s *Show) (*Listing, error) {
+	if !s.reread {
+		return &Listing{Unchanged: true}, nil
+	}
+	return &Listing{Unchanged: true, Episodes: []*Episode{{Title: "Lazy", Season: 9, Episode: 1}}}, nil
+}
+
+func TestScrapeUnchangedSource(t *testing.T) {
+	useSources(t, wikiServer(t), guideServer(t))
+	RegisterSource("lazy", func(string) EpisodeSource { return lazySource{} })
+
+	s := &Show{ID: 1, Sources: []*SourceConfig{
+		{Source: "lazy"},
+		{Source: "fixture", Ref: "testdata/fixture.json"},
+	}}
+	if err := s.Scrape(context.Background()); err != nil {
+		t.Fatalf("Scrape() err = %v, want %v", err, nil)
+	}
+	if len(s.Episodes) != 4 || s.Episodes[0].Title != "Lazy" {
+		t.Errorf("Scrape() = %d episodes, first %v, want %d episodes and the lazy one first",
+			len(s.Episodes), s.Episodes[0], 4)
+	}
+	if s.partial || s.reread {
+		t.Errorf("Scrape() partial = %v, reread = %v, want both %v", s.partial, s.reread, false)
+	}
+
+	s.Sources = []*SourceConfig{{Source: "lazy"}}
+	if err := s.Scrape(context.Background()); !errors.Is(err, trackable.ErrUnchanged) {
+		t.Errorf("Scrape() err = %v, want %v", err, trackable.ErrUnchanged)
+	}
+}
+
+func TestSplitLang(t *testing.T) {
+	testCases := []struct {
+		ref, lang, article string
+	}{
+		{ref: "Tracker_(TV_series)", article: "Tracker_(TV_series)"},
+		{ref: "de:Tracker_(Fernsehserie)", lang: "de", article: "Tracker_(Fernsehserie)"},
+		{ref: "es:Anexo:Episodios_de_Tracker", lang: "es", article: "Anexo:Episodios_de_Tracker"},
+		{ref: "Star_Trek:_Discovery", article: "Star_Trek:_Discovery"},
+		{ref: "Go:_The_Series", article: "Go:_The_Series"},
+	}
+
+	for _, tc := range testCases {
+		if lang, article := splitLang(tc.ref); lang != tc.lang || article != tc.article {
+			t.Errorf("splitLang(%q) = %q, %q, want %q, %q", tc.ref, lang, article, tc.lang, tc.article)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package show
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"tracker/internal/mediawiki"
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+)
+
+// errNoEpisodeTemplates is returned when the wikitext doesn't list episodes
+// with templates, for example when they are transcluded from other pages.
+const errNoEpisodeTemplates = trackable.Error("show: no episode templates in wikitext")
+
+// wiki is the API of the English Wikipedia, which shows are read from
+// unless they say otherwise.
+var wiki = mediawiki.New()
+
+// scrapeWiki reads the show and its episodes from the wikitext of the
+// article, and of its list of episodes if it has one. The revisions of the
+// article and of the page listing the episodes are kept, and ErrUnchanged
+// returned once the show is read if neither has been edited since.
+func (s *Show) scrapeWiki(ctx context.Context, c *mediawiki.Client) error {
+	title, err := url.PathUnescape(s.WikipediaURL)
+	if err != nil {
+		return fmt.Errorf("show: invalid article %q: %w", s.WikipediaURL, err)
+	}
+
+	report := trackable.ReportFrom(ctx)
+	article, err := c.Page(ctx, title)
+	if err != nil {
+		return err
+	}
+	report.Fetched(wikiURL(s.lang(), article.Title))
+
+	name := strings.TrimSpace(strings.SplitN(article.Title, " (", 2)[0])
+	list := article
+	var info *Info
+	if infoboxes := mediawiki.Templates(article.Wikitext, "Infobox television"); len(infoboxes) > 0 {
+		info = s.parseInfoTemplate(infoboxes[0], report)
+		if n := mediawiki.Plain(infoboxes[0].Param("name")); n != "" {
+			name = n
+		}
+		if l := mediawiki.Plain(infoboxes[0].Param("list_episodes")); l != "" {
+			if list, err = c.Page(ctx, l); err != nil {
+				return err
+			}
+			report.Fetched(wikiURL(s.lang(), list.Title))
+		}
+	}
+
+	episodes, err := parseEpisodeTemplates(list.Wikitext, s.locale().dates, report)
+	if err != nil {
+		return err
+	}
+
+	unchanged := s.Revision != 0 && list.RevisionID == s.Revision &&
+		article.RevisionID == s.ArticleRevision
+	s.Name = name
+	s.Info = info
+	s.EpisodeURL = wikiURL(s.lang(), list.Title)
+	s.Episodes = episodes
+	s.Revision = list.RevisionID
+	s.ArticleRevision = article.RevisionID
+	if unchanged {
+		return trackable.ErrUnchanged
+	}
+	return nil
+}
+
+// wikiURL returns the URL of the article with the title on the Wikipedia of
+// the language.
+func wikiURL(lang, title string) string {
+	return "https://" + lang + ".wikipedia.org/wiki/" + strings.ReplaceAll(title, " ", "_")
+}
+
+// parseEpisodeTemplates returns the episodes of the {{Episode list}}
+// templates in the wikitext. Every {{Episode table}} is assigned a season
+// from the headings it is listed under, as are lists outside of a table,
+// such as on the page of a single season. Dates which aren't templates are
+// read in the language of the dates. Episodes which can't be parsed are
+// skipped, and recorded in the report.
+func parseEpisodeTemplates(text string, dates *timeutil.Locale, report *trackable.Report) ([]*Episode, error) {
+	var (
+		episodes []*Episode
+		seasons  = newSeasonTracker()
+		headings [7]string
+	)
+	for _, sec := range mediawiki.Sections(text) {
+		if sec.Level > 0 {
+			headings[sec.Level] = sec.Title
+			for i := sec.Level + 1; i < len(headings); i++ {
+				headings[i] = ""
+			}
+		}
+
+		var (
+			kind    sectionKind
+			started bool
+		)
+		for _, t := range mediawiki.Templates(sec.Text, "Episode table", "Episode list") {
+			if table := t.Is("Episode table"); table || !started {
+				started = true
+				report.Table()
+				if kind = seasons.table(headings[:]); kind == extrasSection {
+					report.Skip(strings.Join(nonEmpty(headings[:]), " / "), "not tracking extras")
+				}
+				if table {
+					continue
+				}
+			}
+			if kind == extrasSection {
+				continue
+			}
+
+			// EpisodeNumber2 is the number within the season, if the list
+			// numbers episodes across seasons as well.
+			numStr := mediawiki.Plain(t.Param("EpisodeNumber2", "EpisodeNumber"))
+			num, err := strconv.Atoi(numStr)
+			if err != nil && kind != specialsSection {
+				report.Skip(fmt.Sprintf("season %d, %q", seasons.season, mediawiki.Plain(t.Param("Title"))),
+					"episode number %q isn't a number", numStr)
+				continue
+			}
+
+			episode := &Episode{Title: strings.Trim(mediawiki.Plain(t.Param("Title")), `"`)}
+			episode.Season, episode.Episode = seasons.episode(num)
+
+			date := t.Param("OriginalAirDate", "AltDate")
+			if d, ok := mediawiki.Date(date); ok {
+				episode.ReleaseDate = d
+			} else if text := mediawiki.Plain(date); text != "" {
+				if episode.ReleaseDate, err = dates.ParseDate(text); err != nil {
+					report.Warn("S%02dE%02d: release date %q isn't a date", episode.Season, episode.Episode, text)
+				}
+			}
+
+			episodes = append(episodes, episode)
+			report.Parse()
+		}
+	}
+
+	if len(episodes) == 0 {
+		return nil, errNoEpisodeTemplates
+	}
+	return episodes, nil
+}
@@ -0,0 +1,182 @@
+package show
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"tracker/internal/fetch"
+	"tracker/internal/mediawiki"
+	"tracker/internal/timeutil"
+	"tracker/trackable"
+)
+
+// wikiServer stands in for the API of Wikipedia, answering with the
+// recorded response of the title requested.
+func wikiServer(t *testing.T) *mediawiki.Client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		title := strings.ReplaceAll(r.URL.Query().Get("titles"), " ", "_")
+		http.ServeFile(w, r, "testdata/"+title+".json")
+	}))
+	t.Cleanup(srv.Close)
+
+	return mediawiki.New(
+		mediawiki.Endpoint(srv.URL+mediawiki.APIPath),
+		mediawiki.Fetcher(fetch.New(fetch.RateLimit(0, 0))),
+	)
+}
+
+func TestScrapeWiki(t *testing.T) {
+	c := wikiServer(t)
+	date := func(year int, month time.Month, day int) timeutil.Date {
+		return timeutil.Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+	}
+
+	s := &Show{ID: 1, WikipediaURL: "Tracker_(TV_series)"}
+	if err := s.scrapeWiki(context.Background(), c); err != nil {
+		t.Fatalf("scrapeWiki() err = %v, want %v", err, nil)
+	}
+
+	want := &Show{
+		ID:              1,
+		Name:            "Tracker",
+		WikipediaURL:    "Tracker_(TV_series)",
+		EpisodeURL:      "https://en.wikipedia.org/wiki/List_of_Tracker_episodes",
+		Revision:        2002,
+		ArticleRevision: 1001,
+		Info: &Info{
+			Genres:           []string{"Drama", "Mystery"},
+			Creators:         []string{"Jane Doe"},
+			Starring:         []string{"John Roe", "Max Mustermann"},
+			Country:          "United States",
+			OriginalLanguage: "English",
+			Seasons:          2,
+			Episodes:         5,
+			Network:          "Example Network",
+			FirstAired:       date(2020, time.January, 5),
+			Website:          "https://tracker.example.com",
+		},
+		Episodes: []*Episode{
+			{Title: "Pilot", Season: 1, Episode: 1, ReleaseDate: date(2020, time.January, 5)},
+			{Title: "Second Thoughts", Season: 1, Episode: 2, ReleaseDate: date(2020, time.January, 12)},
+			{Title: "Three & Out", Season: 1, Episode: 3, ReleaseDate: date(2020, time.January, 19)},
+			{Title: "Return", Season: 2, Episode: 1, ReleaseDate: date(2021, time.March, 6)},
+			{Title: "TBA", Season: 2, Episode: 2},
+		},
+	}
+	if diff := deep.Equal(s, want); diff != nil {
+		t.Errorf("scrapeWiki() diff = %v", diff)
+	}
+
+	// Neither the article nor the list of episodes has been edited since.
+	if err := s.scrapeWiki(context.Background(), c); !errors.Is(err, trackable.ErrUnchanged) {
+		t.Errorf("scrapeWiki() err = %v, want %v", err, trackable.ErrUnchanged)
+	}
+
+	// Only the infobox of the article has been edited since.
+	s.ArticleRevision = 1000
+	if err := s.scrapeWiki(context.Background(), c); err != nil {
+		t.Errorf("scrapeWiki() err = %v, want %v", err, nil)
+	}
+}
+
+func TestParseEpisodeTemplates(t *testing.T) {
+	testCases := map[string]struct {
+		text    string
+		dates   *timeutil.Locale
+		want    []*Episode
+		wantErr error
+	}{
+		"without tables": {
+			text: "{{Episode list|EpisodeNumber=1|Title=Pilot}}",
+			want: []*Episode{{Title: "Pilot", Season: 1, Episode: 1}},
+		},
+		"sections": {
+			text: `== Season 1 ==
+{{Episode table|episodes=
+{{Episode list|EpisodeNumber=1|Title=Pilot}}
+}}
+== Specials ==
+{{Episode table|episodes=
+{{Episode list|Title=Holiday Special}}
+}}
+== Webisodes ==
+{{Episode table|episodes=
+{{Episode list|EpisodeNumber=1|Title=Behind the Tracker}}
+}}
+== Season 2 ==
+=== Part 2 ===
+{{Episode table|episodes=
+{{Episode list|EpisodeNumber=1|Title=Return}}
+}}`,
+			want: []*Episode{
+				{Title: "Pilot", Season: 1, Episode: 1},
+				{Title: "Holiday Special", Season: 0, Episode: 1},
+				{Title: "Return", Season: 2, Episode: 1},
+			},
+		},
+		"localised dates": {
+			text:  "{{Episode list|EpisodeNumber=1|Title=Pilot|OriginalAirDate=5. Januar 2020}}",
+			dates: timeutil.German,
+			want: []*Episode{{Title: "Pilot", Season: 1, Episode: 1,
+				ReleaseDate: timeutil.Date{Time: time.Date(2020, time.January, 5, 0, 0, 0, 0, time.UTC)}}},
+		},
+		"transcluded": {
+			text:    "{{:List of Tracker episodes (season 1)}}",
+			wantErr: errNoEpisodeTemplates,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dates := tc.dates
+			if dates == nil {
+				dates = timeutil.English
+			}
+			got, err := parseEpisodeTemplates(tc.text, dates, nil)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("parseEpisodeTemplates() err = %v, want %v", err, tc.wantErr)
+			}
+			if diff := deep.Equal(got, tc.want); diff != nil {
+				t.Errorf("parseEpisodeTemplates() diff = %v", diff)
+			}
+		})
+	}
+}
+
+func TestParseEpisodeTemplatesReport(t *testing.T) {
+	text := `{{Episode table|episodes=
+{{Episode list|EpisodeNumber=1|Title=Pilot|OriginalAirDate={{Start date|2020|1|5}}}}
+{{Episode list|EpisodeNumber=TBA|Title=Special}}
+{{Episode list|EpisodeNumber=2|Title=Second|OriginalAirDate=Early January 2020}}
+{{Episode list|EpisodeNumber=3|Title=Third|OriginalAirDate=After the second}}
+}}`
+
+	report := &trackable.Report{}
+	episodes, err := parseEpisodeTemplates(text, timeutil.English, report)
+	if err != nil {
+		t.Fatalf("parseEpisodeTemplates() err = %v, want %v", err, nil)
+	}
+	if len(episodes) != 3 {
+		t.Fatalf("parseEpisodeTemplates() = %d episodes, want %d", len(episodes), 3)
+	}
+	if got := episodes[1].ReleaseDate.String(); got != "2020-01" {
+		t.Errorf("parseEpisodeTemplates() release date = %q, want %q", got, "2020-01")
+	}
+
+	want := &trackable.Report{
+		Tables:   1,
+		Parsed:   3,
+		Skipped:  []*trackable.Skip{{Where: `season 1, "Special"`, Reason: `episode number "TBA" isn't a number`}},
+		Warnings: []string{`S01E03: release date "After the second" isn't a date`},
+	}
+	if diff := deep.Equal(report, want); diff != nil {
+		t.Errorf("parseEpisodeTemplates() report diff = %v", diff)
+	}
+}
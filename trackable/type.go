@@ -1,5 +1,106 @@
 package trackable
 
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"tracker/internal/timeutil"
+)
+
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// ErrUnchanged is returned by Scrape when the source of the trackable hasn't
+// changed since it was last scraped, so there is nothing to write.
+const ErrUnchanged = Error("trackable: unchanged since the last scrape")
+
+// Kind identifies a type of trackable, such as shows or music.
+type Kind string
+
+// Ref uniquely identifies a single trackable.
+type Ref struct {
+	Kind Kind `json:"kind"`
+	ID   int  `json:"id"`
+}
+
+func (r Ref) String() string {
+	return fmt.Sprintf("%s/%d", r.Kind, r.ID)
+}
+
+// Release is a single release of a trackable, such as an episode of a show
+// or an album of an artist.
+type Release struct {
+	Ref
+
+	// Name of the trackable, and the title of the release itself.
+	Name  string    `json:"name"`
+	Title string    `json:"title"`
+	Date  time.Time `json:"date"`
+
+	// Precision and Tentative qualify dates which aren't known to the day,
+	// or aren't confirmed. Dates of most kinds are exact.
+	Precision timeutil.Precision `json:"precision,omitempty"`
+	Tentative bool               `json:"tentative,omitempty"`
+
+	// Season and Number identify episodes of kinds which have them.
+	Season int `json:"season,omitempty"`
+	Number int `json:"number,omitempty"`
+
+	// Sources names the source which supplied each field, for kinds read
+	// from several sources.
+	Sources map[string]string `json:"sources,omitempty"`
+}
+
+// PartialDate returns the date of the release, as precisely as it is known.
+func (r *Release) PartialDate() timeutil.Date {
+	return timeutil.Date{Time: r.Date, Precision: r.Precision, Tentative: r.Tentative}
+}
+
+// Trackable is anything which can be tracked for new releases.
 type Trackable interface {
+	// Ref identifies the trackable.
+	Ref() Ref
+
+	// Scrape the latest details of the trackable from its source. It returns
+	// ErrUnchanged if the source is the same as when it was last scraped.
+	Scrape(ctx context.Context) error
+
+	// Write persists the trackable.
 	Write() error
+
+	// Releases returns the releases in [start, end), ordered by date.
+	Releases(start, end time.Time) []*Release
+}
+
+// Status is how actively a trackable is releasing, which decides how often
+// it is scraped.
+type Status string
+
+const (
+	// Active trackables have released recently, or are about to.
+	Active Status = "active"
+	// Upcoming trackables have releases announced further out.
+	Upcoming Status = "upcoming"
+	// Idle trackables have no recent or announced releases.
+	Idle Status = "idle"
+	// Finished trackables won't release anything anymore.
+	Finished Status = "finished"
+)
+
+// Statuser is implemented by trackables which know their status. Trackables
+// which don't are treated as idle.
+type Statuser interface {
+	Status(now time.Time) Status
+}
+
+// Unscheduler is implemented by trackables whose releases may be announced
+// without an exact date, such as "March 2025" or "TBA".
+type Unscheduler interface {
+	// Unscheduled returns the releases which may be released in [start, end),
+	// but whose date isn't known to the day.
+	Unscheduled(start, end time.Time) []*Release
 }